@@ -4,132 +4,216 @@ import (
 	"fmt"
 
 	"github.com/YeridStick/cleango/internal/generator"
+	"github.com/YeridStick/cleango/internal/i18n"
 	"github.com/spf13/cobra"
 )
 
-var adapterWithTests bool
+var (
+	adapterWithTests bool
+	usecaseWithTests bool
+	modelWithTests   bool
+	handlerWithTests bool
+	usecaseModel     string
+	handlerModel     string
+	apiSpec          string
+	grpcUsecases     []string
+	addMocks         bool
+)
 
 var addCmd = &cobra.Command{
 	Use:   "add",
-	Short: "Agrega componentes al proyecto",
-	Long: `Agrega nuevos componentes a tu proyecto siguiendo Clean Architecture.
-
-Componentes disponibles:
-  • usecase  - Crea un nuevo caso de uso en domain/usecases
-  • adapter  - Crea un nuevo adaptador en infrastructure/adapters/database
-  • model    - Crea un nuevo modelo en domain/models
-  • handler  - Crea un nuevo handler HTTP en infrastructure/entrypoints/http`,
+	Short: i18n.T("add.short"),
+	Long:  i18n.T("add.long"),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGlobalFlags(cmd, args); err != nil {
+			return err
+		}
+		// The files of add api are re-generated when the spec changes
+		fallback := generator.ConflictFail
+		if cmd == addAPICmd {
+			fallback = generator.ConflictSkip
+		}
+		return setConflicts(fallback, !jsonOutput() && interactiveTerminal())
+	},
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		if addMocks {
+			if err := generator.EnableMocks(); err != nil {
+				return err
+			}
+		}
+
+		result, err := generator.RefreshMocks()
+		if err != nil {
+			return i18n.Error("add.err.mocks", err)
+		}
+		if result != nil && (len(result.Written) > 0 || len(result.Removed) > 0) {
+			printMocksResult(result)
+			fmt.Fprintln(stdout, i18n.T("mocks.done"))
+		}
+		return nil
+	},
 }
 
 var addUsecaseCmd = &cobra.Command{
-	Use:   "usecase [nombre]",
-	Short: "Crea un nuevo caso de uso",
-	Long: `Crea un nuevo caso de uso en domain/usecases/.
-
-El caso de uso seguirá el patrón de Clean Architecture con:
-  • Interface del caso de uso (puerto)
-  • Implementación concreta
-  • Struct de entrada (Input)
-  • Struct de salida (Output)
-
-Ejemplo:
-  cleango add usecase GetUser
-  cleango add usecase CreateOrder`,
-	Args: cobra.ExactArgs(1),
+	Use:   "usecase " + i18n.T("arg.name"),
+	Short: i18n.T("add.usecase.short"),
+	Long:  i18n.T("add.usecase.long"),
+	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
-		fmt.Printf("🔧 Generando caso de uso '%s'...\n", name)
+		fmt.Fprintln(stdout, i18n.T("add.usecase.generating", name))
 
-		if err := generator.GenerateUsecase(name); err != nil {
-			return fmt.Errorf("error generando caso de uso: %w", err)
+		if err := generator.GenerateUsecase(name, usecaseModel, usecaseWithTests); err != nil {
+			return i18n.Error("add.usecase.err", err)
 		}
 
-		fmt.Printf("✅ Caso de uso '%s' creado exitosamente!\n", name)
-		fmt.Printf("   Archivo: domain/usecases/%s.go\n", generator.ToSnakeCase(name))
+		fmt.Fprintln(stdout, i18n.T("add.usecase.done", name))
+		fmt.Fprintln(stdout, i18n.T("add.file", "domain/usecases/"+generator.ToSnakeCase(name)+".go"))
 		return nil
 	},
 }
 
 var addAdapterCmd = &cobra.Command{
-	Use:   "adapter [nombre]",
-	Short: "Crea un nuevo adaptador/repositorio",
-	Long: `Crea un nuevo adaptador en infrastructure/adapters/database/.
-
-El adaptador seguirá el patrón Repository con:
-  • Interface del repositorio
-  • Implementación concreta
-  • Métodos CRUD básicos
-  • Opción de generar un test base con --with-tests para personalizar tu conexión
-
-Ejemplo:
-  cleango add adapter UserRepository
-  cleango add adapter ProductRepository`,
-	Args: cobra.ExactArgs(1),
+	Use:   "adapter " + i18n.T("arg.name"),
+	Short: i18n.T("add.adapter.short"),
+	Long:  i18n.T("add.adapter.long"),
+	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
-		fmt.Printf("🔧 Generando adaptador '%s'...\n", name)
+		fmt.Fprintln(stdout, i18n.T("add.adapter.generating", name))
 
 		if err := generator.GenerateAdapter(name, adapterWithTests); err != nil {
-			return fmt.Errorf("error generando adaptador: %w", err)
+			return i18n.Error("add.adapter.err", err)
 		}
 
-		fmt.Printf("✅ Adaptador '%s' creado exitosamente!\n", name)
-		fmt.Printf("   Archivo: infrastructure/adapters/database/%s.go\n", generator.ToSnakeCase(name))
+		fmt.Fprintln(stdout, i18n.T("add.adapter.done", name))
+		fmt.Fprintln(stdout, i18n.T("add.file", "infrastructure/adapters/database/"+generator.ToSnakeCase(name)+".go"))
 		return nil
 	},
 }
 
 var addModelCmd = &cobra.Command{
-	Use:   "model [nombre]",
-	Short: "Crea un nuevo modelo de dominio",
-	Long: `Crea un nuevo modelo en domain/models/.
+	Use:   "model " + i18n.T("arg.name") + " " + i18n.T("arg.fields"),
+	Short: i18n.T("add.model.short"),
+	Long:  i18n.T("add.model.long"),
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		fields, err := generator.ParseFieldSpecs(args[1:])
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, i18n.T("add.model.generating", name))
+
+		if err := generator.GenerateModel(name, fields, modelWithTests); err != nil {
+			return i18n.Error("add.model.err", err)
+		}
 
-El modelo será una estructura básica que representa una entidad de dominio.
-Entidades de dominio son objetos puros de negocio sin dependencias externas.
+		fmt.Fprintln(stdout, i18n.T("add.model.done", name))
+		fmt.Fprintln(stdout, i18n.T("add.file", "domain/models/"+generator.ToSnakeCase(name)+".go"))
+		return nil
+	},
+}
 
-Ejemplo:
-  cleango add model User
-  cleango add model Product`,
-	Args: cobra.ExactArgs(1),
+var addHandlerCmd = &cobra.Command{
+	Use:   "handler " + i18n.T("arg.name"),
+	Short: i18n.T("add.handler.short"),
+	Long:  i18n.T("add.handler.long"),
+	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
-		fmt.Printf("🔧 Generando modelo '%s'...\n", name)
+		fmt.Fprintln(stdout, i18n.T("add.handler.generating", name))
 
-		if err := generator.GenerateModel(name); err != nil {
-			return fmt.Errorf("error generando modelo: %w", err)
+		if err := generator.GenerateHandler(name, handlerModel, handlerWithTests); err != nil {
+			return i18n.Error("add.handler.err", err)
 		}
 
-		fmt.Printf("✅ Modelo '%s' creado exitosamente!\n", name)
-		fmt.Printf("   Archivo: domain/models/%s.go\n", generator.ToSnakeCase(name))
+		fmt.Fprintln(stdout, i18n.T("add.handler.done", name))
+		fmt.Fprintln(stdout, i18n.T("add.file", "infrastructure/entrypoints/http/"+generator.ToSnakeCase(name)+"_handler.go"))
 		return nil
 	},
 }
 
-var addHandlerCmd = &cobra.Command{
-	Use:   "handler [nombre]",
-	Short: "Crea un nuevo handler HTTP",
-	Long: `Crea un nuevo handler HTTP en infrastructure/entrypoints/http/.
-
-El handler incluirá:
-  • Estructura del handler
-  • Métodos HTTP básicos (Get, Post, Put, Delete)
-  • Validación de entrada
-  • Manejo de errores
-
-Ejemplo:
-  cleango add handler User
-  cleango add handler Product`,
-	Args: cobra.ExactArgs(1),
+var addAPICmd = &cobra.Command{
+	Use:   "api --from openapi.yaml",
+	Short: i18n.T("add.api.short"),
+	Long:  i18n.T("add.api.long"),
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Fprintln(stdout, i18n.T("add.api.generating", apiSpec))
+
+		result, err := generator.GenerateAPI(apiSpec)
+		if err != nil {
+			return i18n.Error("add.api.err", err)
+		}
+
+		for _, file := range result.Created {
+			fmt.Fprintf(stdout, "   + %s\n", file)
+		}
+		for _, file := range result.Updated {
+			fmt.Fprintf(stdout, "   ~ %s\n", file)
+		}
+		for _, file := range result.Skipped {
+			printSkipped(file, i18n.T("add.api.skipped"))
+		}
+		for _, warning := range result.Warnings {
+			printWarning("%s", warning)
+		}
+
+		fmt.Fprintln(stdout, i18n.T("add.api.done"))
+		return nil
+	},
+}
+
+var addGRPCCmd = &cobra.Command{
+	Use:   "grpc " + i18n.T("arg.service"),
+	Short: i18n.T("add.grpc.short"),
+	Long:  i18n.T("add.grpc.long"),
+	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
-		fmt.Printf("🔧 Generando handler '%s'...\n", name)
+		fmt.Fprintln(stdout, i18n.T("add.grpc.generating", name))
+
+		result, err := generator.GenerateGRPC(name, grpcUsecases)
+		if err != nil {
+			return i18n.Error("add.grpc.err", err)
+		}
 
-		if err := generator.GenerateHandler(name); err != nil {
-			return fmt.Errorf("error generando handler: %w", err)
+		for _, file := range result.Files {
+			fmt.Fprintf(stdout, "   + %s\n", file)
 		}
+		for _, warning := range result.Warnings {
+			printWarning("%s", warning)
+		}
+		if result.Generated {
+			fmt.Fprintf(stdout, "✅ %s\n", result.Protoc)
+		} else {
+			printWarning("%s", result.Protoc)
+		}
+
+		fmt.Fprintln(stdout, i18n.T("add.grpc.done", name))
+		return nil
+	},
+}
 
-		fmt.Printf("✅ Handler '%s' creado exitosamente!\n", name)
-		fmt.Printf("   Archivo: infrastructure/entrypoints/http/%s_handler.go\n", generator.ToSnakeCase(name))
+var addGraphQLCmd = &cobra.Command{
+	Use:   "graphql",
+	Short: i18n.T("add.graphql.short"),
+	Long:  i18n.T("add.graphql.long"),
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Fprintln(stdout, i18n.T("add.graphql.generating"))
+
+		files, err := generator.GenerateGraphQL()
+		if err != nil {
+			return i18n.Error("add.graphql.err", err)
+		}
+
+		for _, file := range files {
+			fmt.Fprintf(stdout, "   + %s\n", file)
+		}
+		fmt.Fprintln(stdout, i18n.T("add.graphql.done"))
 		return nil
 	},
 }
@@ -139,6 +223,19 @@ func init() {
 	addCmd.AddCommand(addAdapterCmd)
 	addCmd.AddCommand(addModelCmd)
 	addCmd.AddCommand(addHandlerCmd)
-
-	addAdapterCmd.Flags().BoolVar(&adapterWithTests, "with-tests", false, "Genera también un test base para personalizar el adapter")
+	addCmd.AddCommand(addAPICmd)
+	addCmd.AddCommand(addGRPCCmd)
+	addCmd.AddCommand(addGraphQLCmd)
+
+	addCmd.PersistentFlags().BoolVar(&addMocks, "mocks", false, i18n.T("add.flag.mocks"))
+	addCmd.PersistentFlags().StringVar(&onConflict, "on-conflict", "", conflictFlagUsage(generator.ConflictFail))
+	addAdapterCmd.Flags().BoolVar(&adapterWithTests, "with-tests", false, i18n.T("add.adapter.flag.with_tests"))
+	addUsecaseCmd.Flags().BoolVar(&usecaseWithTests, "with-tests", false, i18n.T("add.usecase.flag.with_tests"))
+	addModelCmd.Flags().BoolVar(&modelWithTests, "with-tests", false, i18n.T("add.model.flag.with_tests"))
+	addHandlerCmd.Flags().BoolVar(&handlerWithTests, "with-tests", false, i18n.T("add.handler.flag.with_tests"))
+	addUsecaseCmd.Flags().StringVar(&usecaseModel, "model", "", i18n.T("add.usecase.flag.model"))
+	addHandlerCmd.Flags().StringVar(&handlerModel, "model", "", i18n.T("add.handler.flag.model"))
+	addAPICmd.Flags().StringVar(&apiSpec, "from", "", i18n.T("add.api.flag.from"))
+	_ = addAPICmd.MarkFlagRequired("from")
+	addGRPCCmd.Flags().StringSliceVar(&grpcUsecases, "usecase", nil, i18n.T("add.grpc.flag.usecase"))
 }
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/YeridStick/cleango/internal/generator"
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/manifoldco/promptui"
+)
+
+// onConflict is the value of the --on-conflict flag of the generators
+var onConflict string
+
+// setConflicts sets the conflict policy of the generators from --on-conflict.
+// Without the flag, each existing file is asked about when interactive and
+// otherwise handled with fallback.
+func setConflicts(fallback string, interactive bool) error {
+	policy := generator.ConflictPolicy{Strategy: onConflict}
+	if policy.Strategy == "" {
+		policy.Strategy = fallback
+		if interactive {
+			policy.Strategy = generator.ConflictPrompt
+		}
+	}
+	if interactive {
+		policy.Ask = askConflict
+	}
+	if err := policy.Validate(); err != nil {
+		return generator.WithCode(generator.CodeUsage, err)
+	}
+	generator.Conflicts = policy
+	return nil
+}
+
+// conflictFlagUsage is the help of --on-conflict for a command whose default
+// without a terminal is fallback
+func conflictFlagUsage(fallback string) string {
+	return i18n.T("conflict.flag", fallback)
+}
+
+// askConflict shows the diff of a file that already exists and asks what to
+// do with it
+func askConflict(path, diff string) (string, error) {
+	fmt.Fprintln(stdout)
+	fmt.Fprint(stdout, diff)
+	strategies := []string{generator.ConflictSkip, generator.ConflictOverwrite, generator.ConflictBackup, generator.ConflictNew}
+	prompt := promptui.Select{
+		Label: i18n.T("conflict.prompt", path),
+		Items: []string{
+			i18n.T("conflict.prompt.skip"),
+			i18n.T("conflict.prompt.overwrite"),
+			i18n.T("conflict.prompt.backup", path+".bak"),
+			i18n.T("conflict.prompt.new", path+".new"),
+		},
+	}
+	idx, _, err := prompt.Run()
+	if err != nil {
+		return "", cancelled()
+	}
+	return strategies[idx], nil
+}
+
+// printConflicts prints what was done with the existing files the command
+// found in its way
+func printConflicts() {
+	conflicts := cmdResult.Conflicts
+	if len(conflicts) == 0 {
+		return
+	}
+
+	fmt.Fprintln(stdout, i18n.T("conflict.summary"))
+	skipped, copies := 0, 0
+	for _, conflict := range conflicts {
+		switch conflict.Strategy {
+		case generator.ConflictSkip:
+			skipped++
+			fmt.Fprintln(stdout, i18n.T("conflict.summary.skip", conflict.Path))
+		case generator.ConflictBackup:
+			fmt.Fprintln(stdout, i18n.T("conflict.summary.backup", conflict.Path, conflict.Copy))
+		case generator.ConflictNew:
+			copies++
+			fmt.Fprintln(stdout, i18n.T("conflict.summary.new", conflict.Copy))
+		default:
+			fmt.Fprintln(stdout, i18n.T("conflict.summary.overwrite", conflict.Path))
+		}
+	}
+	if skipped > 0 {
+		fmt.Fprintln(stdout, i18n.T("conflict.summary.skipped", skipped))
+	}
+	if copies > 0 {
+		fmt.Fprintln(stdout, i18n.T("conflict.summary.review"))
+	}
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/YeridStick/cleango/internal/generator"
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var doctorFix bool
+
+var doctorCmd = &cobra.Command{
+	Use:           "doctor",
+	Short:         i18n.T("doctor.short"),
+	Long:          i18n.T("doctor.long"),
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		checks, err := generator.Doctor()
+		if err != nil {
+			fmt.Fprintln(stdout, i18n.T("doctor.err", err))
+			return err
+		}
+
+		problems, errors, fixable := 0, 0, 0
+		var results []doctorResult
+		for _, check := range checks {
+			result := doctorResult{Name: check.Name, Status: check.Status, Message: check.Message, Hint: check.Hint, Fixable: check.Fixable()}
+			if check.Status != generator.DoctorOK && doctorFix && check.Fixable() {
+				if err := check.Fix(); err != nil {
+					printDoctorCheck(check)
+					fmt.Fprintln(stdout, i18n.T("doctor.fix_failed", err))
+					result.FixError = err.Error()
+					results = append(results, result)
+					problems++
+					if check.Status == generator.DoctorError {
+						errors++
+					}
+					continue
+				}
+				fmt.Fprintln(stdout, i18n.T("doctor.fixed", check.Name, check.Message))
+				result.Fixed = true
+				results = append(results, result)
+				continue
+			}
+			results = append(results, result)
+
+			printDoctorCheck(check)
+			if check.Status != generator.DoctorOK {
+				problems++
+				if check.Fixable() {
+					fixable++
+				}
+			}
+			if check.Status == generator.DoctorError {
+				errors++
+			}
+		}
+
+		setData(results)
+		if problems == 0 {
+			fmt.Fprintln(stdout, "\n"+i18n.T("doctor.healthy"))
+			return nil
+		}
+		if fixable > 0 {
+			fmt.Fprintln(stdout, "\n"+i18n.T("doctor.run_fix"))
+			nextStep("cleango doctor --fix")
+		}
+		if errors > 0 {
+			return generator.WithCode(generator.CodeCheckFailed, i18n.Error("doctor.errors", errors))
+		}
+		return nil
+	},
+}
+
+// doctorResult is a check in the JSON output of doctor
+type doctorResult struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Message  string `json:"message"`
+	Hint     string `json:"hint,omitempty"`
+	Fixable  bool   `json:"fixable"`
+	Fixed    bool   `json:"fixed,omitempty"`
+	FixError string `json:"fixError,omitempty"`
+}
+
+// printDoctorCheck prints the status of a check and, for problems, the hint
+func printDoctorCheck(check *generator.DoctorCheck) {
+	icon := "✅"
+	switch check.Status {
+	case generator.DoctorWarning:
+		icon = "⚠️ "
+	case generator.DoctorError:
+		icon = "❌"
+	}
+	fmt.Fprintf(stdout, "%s %s: %s\n", icon, check.Name, check.Message)
+	if check.Status != generator.DoctorOK && check.Hint != "" {
+		fix := ""
+		if check.Fixable() {
+			fix = " [--fix]"
+		}
+		fmt.Fprintf(stdout, "   → %s%s\n", check.Hint, fix)
+	}
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, i18n.T("doctor.flag.fix"))
+}
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"os"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/generator"
+	"github.com/YeridStick/cleango/internal/graph"
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var graphFormat string
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: i18n.T("graph.short"),
+	Long:  i18n.T("graph.long"),
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		g, err := generator.Graph()
+		if err != nil {
+			return i18n.Error("cli.err.analyse", err)
+		}
+		if jsonOutput() {
+			setData(g)
+			return nil
+		}
+		return graph.Write(os.Stdout, g, graphFormat)
+	},
+}
+
+func init() {
+	graphCmd.Flags().StringVar(&graphFormat, "format", graph.FormatDOT, i18n.T("cli.flag.format", strings.Join(graph.Formats, ", ")))
+}
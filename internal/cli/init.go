@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/YeridStick/cleango/internal/generator"
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var initCmd = &cobra.Command{
+	Use:          "init",
+	Short:        i18n.T("init.short"),
+	Long:         i18n.T("init.long"),
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runInit,
+}
+
+func init() {
+	initCmd.Flags().StringVarP(&framework, "framework", "f", "", i18n.T("init.flag.framework"))
+	initCmd.Flags().StringVarP(&database, "database", "d", "", i18n.T("init.flag.database"))
+	initCmd.Flags().StringVar(&loggerName, "logger", "", i18n.T("init.flag.logger"))
+	initCmd.Flags().BoolVar(&useRedis, "redis", false, i18n.T("new.flag.redis"))
+	initCmd.Flags().BoolVar(&useKafka, "kafka", false, i18n.T("new.flag.kafka"))
+	initCmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, i18n.T("init.flag.non_interactive"))
+	initCmd.Flags().StringVar(&onConflict, "on-conflict", "", conflictFlagUsage(generator.ConflictSkip))
+	initCmd.Flags().BoolVar(&depOptions.Skip, "skip-deps", false, i18n.T("new.flag.skip_deps"))
+	initCmd.Flags().BoolVar(&depOptions.Offline, "offline", false, i18n.T("new.flag.offline"))
+	initCmd.Flags().StringVar(&depOptions.GoProxy, "goproxy", "", i18n.T("new.flag.goproxy"))
+	initCmd.Flags().BoolVar(&depOptions.Vendor, "vendor", false, i18n.T("new.flag.vendor"))
+	initCmd.Flags().BoolVar(&depOptions.Strict, "strict-deps", false, i18n.T("new.flag.strict_deps"))
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	if err := depOptions.Validate(); err != nil {
+		return generator.WithCode(generator.CodeUsage, err)
+	}
+	// Prompts cannot be answered when the output is parsed by a tool
+	if jsonOutput() || !interactiveTerminal() {
+		nonInteractive = true
+	}
+
+	config, err := generator.DetectProject()
+	if err != nil {
+		return err
+	}
+
+	// The flags prevail over what was detected
+	if framework != "" {
+		config.Framework = framework
+	}
+	if database != "" {
+		config.Database = database
+	}
+	if loggerName != "" {
+		config.Logger = loggerName
+	}
+	if cmd.Flags().Changed("redis") {
+		config.UseRedis = useRedis
+	}
+	if cmd.Flags().Changed("kafka") {
+		config.UseKafka = useKafka
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	if err := setConflicts(generator.ConflictSkip, !nonInteractive); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, i18n.T("init.summary.title"))
+	fmt.Fprintln(stdout, i18n.T("new.summary.name", config.Name))
+	fmt.Fprintln(stdout, i18n.T("new.summary.module", config.ModulePath))
+	fmt.Fprintln(stdout, i18n.T("new.summary.framework", config.Framework))
+	fmt.Fprintln(stdout, i18n.T("new.summary.database", config.Database))
+	fmt.Fprintln(stdout, i18n.T("new.summary.logger", config.Logger))
+	fmt.Fprintln(stdout, i18n.T("new.summary.redis", config.UseRedis))
+	fmt.Fprintln(stdout, i18n.T("new.summary.kafka", config.UseKafka))
+	fmt.Fprintln(stdout, i18n.T("init.summary.conflicts", generator.Conflicts.Strategy))
+	fmt.Fprintln(stdout)
+
+	if !nonInteractive {
+		prompt := promptui.Prompt{
+			Label:     i18n.T("init.prompt.confirm"),
+			IsConfirm: true,
+		}
+		if _, err := prompt.Run(); err != nil {
+			return cancelled()
+		}
+	}
+
+	fmt.Fprintln(stdout, i18n.T("init.generating"))
+	result, err := generator.InitProject(config, depOptions)
+	if err != nil {
+		return i18n.Error("init.err", err)
+	}
+
+	for _, dir := range result.Dirs {
+		fmt.Fprintf(stdout, "   + %s/\n", dir)
+	}
+	if result.Manifest {
+		fmt.Fprintf(stdout, "   + %s\n", generator.ManifestFile)
+	}
+	for _, file := range result.Created {
+		fmt.Fprintf(stdout, "   + %s\n", file)
+	}
+
+	setData(initData{Config: config, Directories: orEmpty(result.Dirs)})
+	fmt.Fprintln(stdout, i18n.T("init.done"))
+	if depOptions.Skip {
+		nextStep("go mod tidy")
+	}
+	nextStep("go run ./cmd/api")
+	fmt.Fprintln(stdout, i18n.T("new.next_steps"))
+	for _, step := range cmdResult.NextSteps {
+		fmt.Fprintf(stdout, "  %s\n", step)
+	}
+	return nil
+}
+
+// initData describes the initialised project in the JSON output; created
+// lists the files and conflicts the existing ones
+type initData struct {
+	Config      generator.ProjectConfig `json:"config"`
+	Directories []string                `json:"directories"`
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/generator"
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/YeridStick/cleango/internal/lint"
+	"github.com/spf13/cobra"
+)
+
+var lintFormat string
+
+var lintCmd = &cobra.Command{
+	Use:           "lint",
+	Short:         i18n.T("lint.short"),
+	Long:          i18n.T("lint.long"),
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, err := generator.Lint()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("lint.err", err))
+			return err
+		}
+
+		if jsonOutput() {
+			setData(report)
+		} else if err := lint.Write(os.Stdout, report, lintFormat); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return err
+		}
+
+		if count := report.Errors(); count > 0 {
+			return generator.WithCode(generator.CodeCheckFailed, i18n.Error("lint.violations", count))
+		}
+		return nil
+	},
+}
+
+func init() {
+	lintCmd.Flags().StringVar(&lintFormat, "format", lint.FormatHuman, i18n.T("cli.flag.format", strings.Join(lint.Formats, ", ")))
+}
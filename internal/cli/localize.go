@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// langFlag is the value of the global --lang flag. The language is already
+// selected when the commands are declared (see package i18n); the flag is
+// declared so it shows in the help and its value is validated.
+var langFlag string
+
+// usageHeadings are the texts of the cobra usage template, by message key
+var usageHeadings = []struct{ text, key string }{
+	{`Use "{{.CommandPath}} [command] --help" for more information about a command.`, "usage.more"},
+	{"Additional help topics:", "usage.topics"},
+	{"Available Commands:", "usage.commands"},
+	{"Global Flags:", "usage.global_flags"},
+	{"Flags:", "usage.flags"},
+	{"Aliases:", "usage.aliases"},
+	{"Examples:", "usage.examples"},
+	{"Usage:", "usage.usage"},
+}
+
+// localizeCobra translates the texts cobra generates itself: the usage
+// template, the version line and the help flag, command and completion
+func localizeCobra(root *cobra.Command) {
+	template := root.UsageTemplate()
+	for _, heading := range usageHeadings {
+		template = strings.ReplaceAll(template, heading.text, i18n.T(heading.key))
+	}
+	root.SetUsageTemplate(template)
+	root.SetVersionTemplate(i18n.T("usage.version"))
+	root.Flags().BoolP("version", "v", false, i18n.T("usage.version_flag", root.Name()))
+
+	root.InitDefaultHelpCmd()
+	root.InitDefaultCompletionCmd()
+	for _, cmd := range root.Commands() {
+		switch cmd.Name() {
+		case "help":
+			cmd.Short = i18n.T("usage.help_command")
+		case "completion":
+			cmd.Short = i18n.T("usage.completion_command")
+		}
+	}
+	localizeHelpFlag(root)
+}
+
+// localizeHelpFlag adds the --help flag of cmd and its subcommands with a
+// translated usage
+func localizeHelpFlag(cmd *cobra.Command) {
+	if cmd.Flags().Lookup("help") == nil {
+		cmd.Flags().BoolP("help", "h", false, i18n.T("usage.help_flag", cmd.Name()))
+	}
+	for _, sub := range cmd.Commands() {
+		localizeHelpFlag(sub)
+	}
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/YeridStick/cleango/internal/generator"
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var mocksCmd = &cobra.Command{
+	Use:   "mocks",
+	Short: i18n.T("mocks.short"),
+	Long:  i18n.T("mocks.long"),
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Fprintln(stdout, i18n.T("mocks.generating"))
+
+		result, err := generator.GenerateMocks()
+		if err != nil {
+			return i18n.Error("mocks.err", err)
+		}
+
+		printMocksResult(result)
+		fmt.Fprintln(stdout, i18n.T("mocks.done"))
+		return nil
+	},
+}
+
+// printMocksResult lists the mock files written and removed
+func printMocksResult(result *generator.MocksResult) {
+	for _, file := range result.Written {
+		fmt.Fprintf(stdout, "   + %s\n", file)
+	}
+	for _, file := range result.Removed {
+		fmt.Fprintf(stdout, "   - %s\n", file)
+	}
+	for _, warning := range result.Warnings {
+		printWarning("%s", warning)
+	}
+}
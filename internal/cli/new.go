@@ -3,90 +3,181 @@ package cli
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
 	"github.com/YeridStick/cleango/internal/generator"
+	"github.com/YeridStick/cleango/internal/i18n"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	modulePath string
-	framework  string
-	database   string
-	useRedis   bool
-	useKafka   bool
+	modulePath     string
+	framework      string
+	database       string
+	useRedis       bool
+	useKafka       bool
 	nonInteractive bool
+	packName       string
+	packVars       map[string]string
+	depOptions     generator.DependencyOptions
+	loggerName     string
+	presetSource   string
+	savePreset     string
 )
 
 var newCmd = &cobra.Command{
-	Use:   "new [project-name]",
-	Short: "Crea un nuevo proyecto Go con Clean Architecture",
-	Long: `Crea un nuevo proyecto Go con estructura de Clean Architecture.
-
-Ejemplos:
-  cleango new my-service
-  cleango new my-service --module github.com/user/my-service
-  cleango new my-service --framework chi --database postgres
-  cleango new my-service -m github.com/user/my-service -f gin -d postgres --redis --kafka`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runNew,
+	Use:          "new [project-name]",
+	Short:        i18n.T("new.short"),
+	Long:         i18n.T("new.long"),
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runNew,
 }
 
 func init() {
-	newCmd.Flags().StringVarP(&modulePath, "module", "m", "", "Ruta del módulo Go (ej: github.com/user/project)")
-	newCmd.Flags().StringVarP(&framework, "framework", "f", "", "Framework HTTP: nethttp, chi, gin, fiber")
-	newCmd.Flags().StringVarP(&database, "database", "d", "", "Base de datos: none, postgres, mysql, mongodb, oracle")
-	newCmd.Flags().BoolVar(&useRedis, "redis", false, "Incluir Redis")
-	newCmd.Flags().BoolVar(&useKafka, "kafka", false, "Incluir Kafka")
-	newCmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "Modo no interactivo (usa valores por defecto)")
+	newCmd.Flags().StringVarP(&modulePath, "module", "m", "", i18n.T("new.flag.module"))
+	newCmd.Flags().StringVarP(&framework, "framework", "f", "", i18n.T("new.flag.framework"))
+	newCmd.Flags().StringVarP(&database, "database", "d", "", i18n.T("new.flag.database"))
+	newCmd.Flags().BoolVar(&useRedis, "redis", false, i18n.T("new.flag.redis"))
+	newCmd.Flags().BoolVar(&useKafka, "kafka", false, i18n.T("new.flag.kafka"))
+	newCmd.Flags().StringVar(&loggerName, "logger", "", i18n.T("new.flag.logger"))
+	newCmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, i18n.T("new.flag.non_interactive"))
+	newCmd.Flags().StringVar(&packName, "pack", "", i18n.T("new.flag.pack"))
+	newCmd.Flags().StringToStringVar(&packVars, "var", nil, i18n.T("new.flag.var"))
+	newCmd.Flags().BoolVar(&depOptions.Skip, "skip-deps", false, i18n.T("new.flag.skip_deps"))
+	newCmd.Flags().BoolVar(&depOptions.Offline, "offline", false, i18n.T("new.flag.offline"))
+	newCmd.Flags().StringVar(&depOptions.GoProxy, "goproxy", "", i18n.T("new.flag.goproxy"))
+	newCmd.Flags().BoolVar(&depOptions.Vendor, "vendor", false, i18n.T("new.flag.vendor"))
+	newCmd.Flags().BoolVar(&depOptions.Strict, "strict-deps", false, i18n.T("new.flag.strict_deps"))
+	newCmd.Flags().StringVar(&presetSource, "from", "", i18n.T("new.flag.from"))
+	newCmd.Flags().StringVar(&savePreset, "save-preset", "", i18n.T("new.flag.save_preset"))
+	newCmd.Flags().StringVar(&onConflict, "on-conflict", "", conflictFlagUsage(generator.ConflictSkip))
 }
 
 func runNew(cmd *cobra.Command, args []string) error {
+	if err := depOptions.Validate(); err != nil {
+		return generator.WithCode(generator.CodeUsage, err)
+	}
+	// Prompts cannot be answered when the output is parsed by a tool
+	if jsonOutput() {
+		nonInteractive = true
+	}
+	// Re-running new in the same folder keeps the files already there
+	if err := setConflicts(generator.ConflictSkip, !nonInteractive && interactiveTerminal()); err != nil {
+		return err
+	}
+
 	var projectName string
 
 	if len(args) > 0 {
 		projectName = args[0]
 	} else if !nonInteractive {
 		prompt := promptui.Prompt{
-			Label: "Nombre del proyecto",
+			Label:    i18n.T("new.prompt.name"),
+			Validate: generator.ValidateProjectName,
 		}
 		result, err := prompt.Run()
 		if err != nil {
-			return fmt.Errorf("operación cancelada")
+			return cancelled()
 		}
 		projectName = result
 	} else {
-		return fmt.Errorf("nombre del proyecto requerido en modo no interactivo")
+		return generator.WithCode(generator.CodeUsage, i18n.Error("new.err.name_required"))
+	}
+	if err := generator.ValidateProjectName(projectName); err != nil {
+		return err
+	}
+
+	// Aplicar el preset; los flags indicados prevalecen sobre sus valores
+	redisSet, kafkaSet := cmd.Flags().Changed("redis"), cmd.Flags().Changed("kafka")
+	if presetSource != "" {
+		preset, err := generator.LoadPreset(presetSource)
+		if err != nil {
+			return err
+		}
+		if modulePath == "" && preset.ModulePrefix != "" {
+			modulePath = path.Join(preset.ModulePrefix, projectName)
+		}
+		if framework == "" {
+			framework = preset.Framework
+		}
+		if database == "" {
+			database = preset.Database
+		}
+		if loggerName == "" {
+			loggerName = preset.Logger
+		}
+		if !redisSet && preset.Redis != nil {
+			useRedis, redisSet = *preset.Redis, true
+		}
+		if !kafkaSet && preset.Kafka != nil {
+			useKafka, kafkaSet = *preset.Kafka, true
+		}
+		if packName == "" {
+			packName = preset.Pack
+		}
+		if packName == preset.Pack {
+			if packVars == nil {
+				packVars = map[string]string{}
+			}
+			for name, value := range preset.Vars {
+				if _, ok := packVars[name]; !ok {
+					packVars[name] = value
+				}
+			}
+		}
+	}
+
+	// Cargar el pack de plantillas, cuyas opciones sirven de valores por defecto
+	var pack *generator.Pack
+	defaults := generator.PackDefaults{}
+	if packName != "" {
+		var err error
+		if pack, err = generator.LoadPack(packName); err != nil {
+			return err
+		}
+		defaults = pack.Defaults
+	} else if len(packVars) > 0 {
+		return generator.WithCode(generator.CodeUsage, i18n.Error("new.err.var_without_pack"))
 	}
 
 	// Obtener module path
+	defaultModule := fmt.Sprintf("github.com/user/%s", projectName)
+	if defaults.ModulePrefix != "" {
+		defaultModule = path.Join(defaults.ModulePrefix, projectName)
+	}
 	if modulePath == "" && !nonInteractive {
 		prompt := promptui.Prompt{
-			Label:   "Módulo Go",
-			Default: fmt.Sprintf("github.com/user/%s", projectName),
+			Label:    i18n.T("new.prompt.module"),
+			Default:  defaultModule,
+			Validate: generator.ValidateModulePath,
 		}
 		result, err := prompt.Run()
 		if err != nil {
-			return fmt.Errorf("operación cancelada")
+			return cancelled()
 		}
 		modulePath = result
 	} else if modulePath == "" {
-		modulePath = fmt.Sprintf("github.com/user/%s", projectName)
+		modulePath = defaultModule
 	}
 
 	// Obtener framework si no se especificó
 	if framework == "" && !nonInteractive {
 		prompt := promptui.Select{
-			Label: "Selecciona framework HTTP",
-			Items: []string{"nethttp", "chi", "gin", "fiber"},
+			Label:     i18n.T("new.prompt.framework"),
+			Items:     generator.Frameworks,
+			CursorPos: indexOf(generator.Frameworks, defaults.Framework),
 		}
 		_, result, err := prompt.Run()
 		if err != nil {
-			return fmt.Errorf("operación cancelada")
+			return cancelled()
 		}
 		framework = result
+	} else if framework == "" && defaults.Framework != "" {
+		framework = defaults.Framework
 	} else if framework == "" {
 		framework = "nethttp"
 	}
@@ -94,35 +185,95 @@ func runNew(cmd *cobra.Command, args []string) error {
 	// Obtener base de datos si no se especificó
 	if database == "" && !nonInteractive {
 		prompt := promptui.Select{
-			Label: "Selecciona base de datos",
-			Items: []string{"none", "postgres", "mysql", "mongodb", "oracle"},
+			Label:     i18n.T("new.prompt.database"),
+			Items:     generator.Databases,
+			CursorPos: indexOf(generator.Databases, defaults.Database),
 		}
 		_, result, err := prompt.Run()
 		if err != nil {
-			return fmt.Errorf("operación cancelada")
+			return cancelled()
 		}
 		database = result
+	} else if database == "" && defaults.Database != "" {
+		database = defaults.Database
 	} else if database == "" {
 		database = "none"
 	}
 
+	// Obtener logger si no se especificó
+	if loggerName == "" && !nonInteractive {
+		prompt := promptui.Select{
+			Label:     i18n.T("new.prompt.logger"),
+			Items:     generator.Loggers,
+			CursorPos: indexOf(generator.Loggers, defaults.Logger),
+		}
+		_, result, err := prompt.Run()
+		if err != nil {
+			return cancelled()
+		}
+		loggerName = result
+	} else if loggerName == "" && defaults.Logger != "" {
+		loggerName = defaults.Logger
+	} else if loggerName == "" {
+		loggerName = "zap"
+	}
+
 	// Preguntar por Redis y Kafka en modo interactivo
-	if !nonInteractive && !cmd.Flags().Changed("redis") {
+	if !nonInteractive && !redisSet {
 		prompt := promptui.Prompt{
-			Label:     "¿Agregar Redis?",
+			Label:     i18n.T("new.prompt.redis"),
 			IsConfirm: true,
+			Default:   confirmDefault(defaults.Redis),
 		}
 		_, err := prompt.Run()
 		useRedis = (err == nil)
+	} else if !redisSet && defaults.Redis != nil {
+		useRedis = *defaults.Redis
 	}
 
-	if !nonInteractive && !cmd.Flags().Changed("kafka") {
+	if !nonInteractive && !kafkaSet {
 		prompt := promptui.Prompt{
-			Label:     "¿Agregar Kafka?",
+			Label:     i18n.T("new.prompt.kafka"),
 			IsConfirm: true,
+			Default:   confirmDefault(defaults.Kafka),
 		}
 		_, err := prompt.Run()
 		useKafka = (err == nil)
+	} else if !kafkaSet && defaults.Kafka != nil {
+		useKafka = *defaults.Kafka
+	}
+
+	// Variables del pack no indicadas con --var
+	var packRef *generator.PackRef
+	if pack != nil {
+		given := map[string]string{}
+		for name, value := range packVars {
+			given[name] = value
+		}
+		for _, variable := range pack.Variables {
+			if _, ok := given[variable.Name]; ok || nonInteractive {
+				continue
+			}
+			label := variable.Prompt
+			if label == "" {
+				label = variable.Name
+			}
+			prompt := promptui.Prompt{
+				Label:    label,
+				Default:  variable.Default,
+				Validate: variable.Check,
+			}
+			result, err := prompt.Run()
+			if err != nil {
+				return cancelled()
+			}
+			given[variable.Name] = result
+		}
+		vars, err := pack.Vars(given)
+		if err != nil {
+			return err
+		}
+		packRef = pack.Ref(vars)
 	}
 
 	// Crear configuración del proyecto
@@ -133,34 +284,56 @@ func runNew(cmd *cobra.Command, args []string) error {
 		Database:   database,
 		UseRedis:   useRedis,
 		UseKafka:   useKafka,
+		Logger:     loggerName,
+		Lang:       i18n.Lang(),
+		Pack:       packRef,
+	}
+	if err := config.Validate(); err != nil {
+		return err
 	}
 
 	// Mostrar resumen
-	fmt.Println("\n=== Resumen del proyecto ===")
-	fmt.Printf("Nombre:     %s\n", config.Name)
-	fmt.Printf("Módulo:     %s\n", config.ModulePath)
-	fmt.Printf("Framework:  %s\n", config.Framework)
-	fmt.Printf("Database:   %s\n", config.Database)
-	fmt.Printf("Redis:      %v\n", config.UseRedis)
-	fmt.Printf("Kafka:      %v\n", config.UseKafka)
-	fmt.Println()
+	fmt.Fprintln(stdout, i18n.T("new.summary.title"))
+	fmt.Fprintln(stdout, i18n.T("new.summary.name", config.Name))
+	fmt.Fprintln(stdout, i18n.T("new.summary.module", config.ModulePath))
+	fmt.Fprintln(stdout, i18n.T("new.summary.framework", config.Framework))
+	fmt.Fprintln(stdout, i18n.T("new.summary.database", config.Database))
+	fmt.Fprintln(stdout, i18n.T("new.summary.logger", config.Logger))
+	fmt.Fprintln(stdout, i18n.T("new.summary.redis", config.UseRedis))
+	fmt.Fprintln(stdout, i18n.T("new.summary.kafka", config.UseKafka))
+	if config.Pack != nil {
+		fmt.Fprintln(stdout, i18n.T("new.summary.pack", config.Pack.Name, config.Pack.Version))
+		for _, variable := range pack.Variables {
+			fmt.Fprintf(stdout, "  %s: %s\n", variable.Name, config.Pack.Vars[variable.Name])
+		}
+	}
+	fmt.Fprintln(stdout)
 
 	// Confirmar en modo interactivo
 	if !nonInteractive {
 		prompt := promptui.Prompt{
-			Label:     "¿Crear proyecto?",
+			Label:     i18n.T("new.prompt.confirm"),
 			IsConfirm: true,
 		}
 		_, err := prompt.Run()
 		if err != nil {
-			return fmt.Errorf("operación cancelada")
+			return cancelled()
+		}
+	}
+
+	// Guardar las respuestas como preset
+	if savePreset != "" {
+		path, err := generator.SavePreset(savePreset, generator.PresetFromConfig(config), false)
+		if err != nil {
+			return err
 		}
+		fmt.Fprintln(stdout, i18n.T("new.preset_saved", path))
 	}
 
 	// Obtener directorio actual
 	cwd, err := os.Getwd()
 	if err != nil {
-		return fmt.Errorf("error obteniendo directorio actual: %w", err)
+		return i18n.Error("new.err.cwd", err)
 	}
 
 	targetDir := filepath.Join(cwd, projectName)
@@ -169,47 +342,57 @@ func runNew(cmd *cobra.Command, args []string) error {
 	if _, err := os.Stat(targetDir); err == nil {
 		if !nonInteractive {
 			prompt := promptui.Select{
-				Label: fmt.Sprintf("La carpeta '%s' ya existe. ¿Qué deseas hacer?", projectName),
-				Items: []string{"Usar carpeta existente", "Cancelar"},
+				Label: i18n.T("new.prompt.dir_exists", projectName),
+				Items: []string{i18n.T("new.prompt.dir_use"), i18n.T("new.prompt.dir_cancel")},
 			}
 			idx, _, err := prompt.Run()
 			if err != nil || idx != 0 {
-				return fmt.Errorf("operación cancelada")
+				return cancelled()
 			}
 		} else {
-			fmt.Printf("⚠️  La carpeta '%s' ya existe. Usando carpeta existente.\n", projectName)
-		}
-	} else {
-		if err := os.MkdirAll(targetDir, 0755); err != nil {
-			return fmt.Errorf("error creando directorio: %w", err)
+			printWarning("%s", i18n.T("new.warn.dir_exists", projectName))
 		}
 	}
 
 	// Generar proyecto
-	fmt.Println("\n🚀 Generando proyecto...")
-	if err := generator.GenerateProject(targetDir, config); err != nil {
-		return fmt.Errorf("error generando proyecto: %w", err)
+	fmt.Fprintln(stdout, i18n.T("new.generating"))
+	if err := generator.GenerateProject(targetDir, config, depOptions); err != nil {
+		return i18n.Error("new.err.generate", err)
 	}
 
 	// Mensaje final
-	fmt.Println("\n✅ Proyecto creado exitosamente!")
-	fmt.Println("\nPróximos pasos:")
-	fmt.Printf("  cd %s\n", projectName)
-	fmt.Println("  go mod tidy")
-	fmt.Println("  go run ./cmd/api")
+	fmt.Fprintln(stdout, i18n.T("new.done"))
+	setData(newData{Directory: targetDir, Config: config})
+	steps := []string{"cd " + projectName}
+	if depOptions.Skip {
+		steps = append(steps, "go mod tidy")
+	}
+	steps = append(steps, "go run ./cmd/api")
+	fmt.Fprintln(stdout, i18n.T("new.next_steps"))
+	for _, step := range steps {
+		fmt.Fprintf(stdout, "  %s\n", step)
+		nextStep(step)
+	}
 	if envs := databaseEnvVars(config.Database); len(envs) > 0 {
-		fmt.Printf("\nConfiguración base de %s generada en infrastructure/adapters/database/%s.go\n", config.Database, config.Database)
-		fmt.Printf("Completa tus variables de conexión en .env (ejemplos en .env.example): %s\n", strings.Join(envs, ", "))
+		fmt.Fprintln(stdout, i18n.T("new.database_config", config.Database, config.Database))
+		fmt.Fprintln(stdout, i18n.T("new.database_env", strings.Join(envs, ", ")))
 	}
-	fmt.Println("\nPara agregar componentes:")
-	fmt.Println("  cleango add usecase <nombre>")
-	fmt.Println("  cleango add adapter <nombre>")
-	fmt.Println("  cleango add model <nombre>")
-	fmt.Println("  cleango add handler <nombre>")
+	fmt.Fprintln(stdout, i18n.T("new.add_components"))
+	fmt.Fprintln(stdout, "  cleango add usecase", i18n.T("new.placeholder_name"))
+	fmt.Fprintln(stdout, "  cleango add adapter", i18n.T("new.placeholder_name"))
+	fmt.Fprintln(stdout, "  cleango add model", i18n.T("new.placeholder_name"))
+	fmt.Fprintln(stdout, "  cleango add handler", i18n.T("new.placeholder_name"))
 
 	return nil
 }
 
+// newData describes the created project in the JSON output. Paths of the
+// result are relative to Directory.
+type newData struct {
+	Directory string                  `json:"directory"`
+	Config    generator.ProjectConfig `json:"config"`
+}
+
 func databaseEnvVars(db string) []string {
 	switch db {
 	case "postgres":
@@ -224,3 +407,22 @@ func databaseEnvVars(db string) []string {
 		return nil
 	}
 }
+
+// indexOf returns the position of value in items, or 0 when absent
+func indexOf(items []string, value string) int {
+	for i, item := range items {
+		if item == value {
+			return i
+		}
+	}
+	return 0
+}
+
+// confirmDefault returns the default answer of a confirm prompt for an
+// optional pack default
+func confirmDefault(value *bool) string {
+	if value != nil && *value {
+		return "y"
+	}
+	return ""
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/YeridStick/cleango/internal/generator"
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var openapiOpts generator.OpenAPIOptions
+
+var openapiCmd = &cobra.Command{
+	Use:   "openapi",
+	Short: i18n.T("openapi.short"),
+	Long:  i18n.T("openapi.long"),
+}
+
+var openapiGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: i18n.T("openapi.generate.short"),
+	Long:  i18n.T("openapi.generate.long"),
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if openapiOpts.Check {
+			if _, err := generator.GenerateOpenAPI(openapiOpts); err != nil {
+				return err
+			}
+			fmt.Fprintln(stdout, i18n.T("openapi.up_to_date", openapiOpts.Output))
+			return nil
+		}
+
+		fmt.Fprintln(stdout, i18n.T("openapi.generating"))
+
+		files, err := generator.GenerateOpenAPI(openapiOpts)
+		if err != nil {
+			return i18n.Error("openapi.err", err)
+		}
+
+		fmt.Fprintln(stdout, i18n.T("openapi.done"))
+		for _, file := range files {
+			fmt.Fprintln(stdout, i18n.T("add.file", file))
+		}
+		if openapiOpts.Serve {
+			fmt.Fprintln(stdout, i18n.T("openapi.swagger"))
+		}
+		return nil
+	},
+}
+
+func init() {
+	openapiCmd.AddCommand(openapiGenerateCmd)
+
+	openapiGenerateCmd.Flags().StringVarP(&openapiOpts.Output, "file", "o", "openapi.yaml", i18n.T("openapi.flag.file"))
+	openapiGenerateCmd.Flags().StringVar(&openapiOpts.Title, "title", "", i18n.T("openapi.flag.title"))
+	openapiGenerateCmd.Flags().StringVar(&openapiOpts.Version, "api-version", "1.0.0", i18n.T("openapi.flag.api_version"))
+	openapiGenerateCmd.Flags().BoolVar(&openapiOpts.Serve, "serve", false, i18n.T("openapi.flag.serve"))
+	openapiGenerateCmd.Flags().BoolVar(&openapiOpts.Check, "check", false, i18n.T("openapi.flag.check"))
+}
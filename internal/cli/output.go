@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/generator"
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/chzyer/readline"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Output formats of the --output flag
+const (
+	outputHuman = "human"
+	outputJSON  = "json"
+)
+
+var outputFormats = []string{outputHuman, outputJSON}
+
+// outputFormat is the value of the global --output flag
+var outputFormat string
+
+// stdout receives the human output of the commands; it discards it when the
+// result is printed as JSON
+var stdout io.Writer = os.Stdout
+
+// commandResult is the JSON document printed by every command with
+// --output json. The lists are always present, possibly empty, so consumers
+// need no null checks.
+type commandResult struct {
+	Command string `json:"command"`
+	OK      bool   `json:"ok"`
+	*generator.Report
+	NextSteps []string     `json:"nextSteps"`
+	Data      interface{}  `json:"data,omitempty"`
+	Error     *resultError `json:"error,omitempty"`
+}
+
+// resultError is a failed command in the JSON output. Code is stable across
+// versions (see the generator.Code* constants); Message is for humans.
+type resultError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// cmdResult is the result of the running command
+var cmdResult = &commandResult{Report: generator.StartReport()}
+
+// jsonOutput reports whether the result is printed as JSON
+func jsonOutput() bool {
+	return outputFormat == outputJSON
+}
+
+// requestedOutput reads --output from args before cobra parses them, so even
+// flag and argument errors are reported in the requested format
+func requestedOutput(args []string) string {
+	flags := pflag.NewFlagSet("output", pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	format := flags.String("output", outputHuman, "")
+	_ = flags.Parse(args)
+	return *format
+}
+
+// setupOutput prepares the commands for the output format
+func setupOutput(format string) {
+	outputFormat = format
+	if !jsonOutput() {
+		return
+	}
+	stdout = io.Discard
+	generator.Output = io.Discard
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+	markRun(rootCmd)
+}
+
+// commandRan is set once the RunE of the command starts, so errors returned
+// before it are usage errors detected by cobra
+var commandRan bool
+
+// markRun wraps the RunE of cmd and its subcommands to set commandRan
+func markRun(cmd *cobra.Command) {
+	if run := cmd.RunE; run != nil {
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			commandRan = true
+			return run(cmd, args)
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		markRun(sub)
+	}
+}
+
+// checkGlobalFlags validates the --output and --lang flags. Their errors are
+// printed even by the commands that report their own errors.
+func checkGlobalFlags(cmd *cobra.Command, args []string) error {
+	cmd.SilenceErrors = false
+	if !i18n.Supported(langFlag) {
+		return generator.WithCode(generator.CodeUsage, generator.ValidateOption("--lang", langFlag, i18n.Languages))
+	}
+	if outputFormat == outputHuman || outputFormat == outputJSON {
+		return nil
+	}
+	err := generator.ValidateOption("--output", outputFormat, outputFormats)
+	if cmd == openapiGenerateCmd {
+		err = i18n.Error("output.err.openapi_file", err)
+	}
+	return generator.WithCode(generator.CodeUsage, err)
+}
+
+// writeResult prints the JSON result of cmd, failed with err if not nil
+func writeResult(cmd *cobra.Command, err error) {
+	if cmd != nil {
+		cmdResult.Command = strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" ")
+	}
+	cmdResult.OK = err == nil
+	if err != nil {
+		code := generator.ErrorCode(err)
+		if code == generator.CodeFailed && !commandRan {
+			code = generator.CodeUsage
+		}
+		cmdResult.Error = &resultError{Code: code, Message: err.Error()}
+	}
+
+	for _, list := range []*[]string{&cmdResult.Created, &cmdResult.Modified, &cmdResult.Removed, &cmdResult.Skipped, &cmdResult.Warnings, &cmdResult.NextSteps} {
+		*list = orEmpty(*list)
+	}
+	if cmdResult.Commands == nil {
+		cmdResult.Commands = []generator.CommandRun{}
+	}
+	if cmdResult.Conflicts == nil {
+		cmdResult.Conflicts = []generator.Conflict{}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(cmdResult)
+}
+
+// printWarning prints a warning and records it in the result
+func printWarning(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	fmt.Fprintf(stdout, "⚠️  %s\n", message)
+	cmdResult.Warn(message)
+}
+
+// printSkipped prints a file left untouched and records it in the result
+func printSkipped(file, reason string) {
+	fmt.Fprintf(stdout, "   = %s (%s)\n", file, reason)
+	cmdResult.Skip(file)
+}
+
+// nextStep records a command suggested to the user after the command
+func nextStep(step string) {
+	cmdResult.NextSteps = append(cmdResult.NextSteps, step)
+}
+
+// setData attaches the data specific to the command to the result
+func setData(data interface{}) {
+	cmdResult.Data = data
+}
+
+// orEmpty returns list, or an empty list instead of nil for the JSON output
+func orEmpty(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
+// interactiveTerminal reports whether stdin is a terminal, where prompts can
+// be answered
+func interactiveTerminal() bool {
+	return readline.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// cancelled is the error of an operation cancelled in a prompt
+func cancelled() error {
+	return generator.WithCode(generator.CodeCancelled, i18n.Error("cli.cancelled"))
+}
+
+// requireConfirmation fails in JSON mode, where prompts cannot be answered,
+// naming the flag that skips the confirmation
+func requireConfirmation(flag string) error {
+	if jsonOutput() {
+		return generator.WithCode(generator.CodeUsage, i18n.Error("output.err.confirmation", flag))
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/YeridStick/cleango/internal/generator"
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var packForce bool
+
+var packCmd = &cobra.Command{
+	Use:   "pack",
+	Short: i18n.T("pack.short"),
+	Long:  i18n.T("pack.long"),
+}
+
+var packInstallCmd = &cobra.Command{
+	Use:          "install " + i18n.T("arg.path"),
+	Short:        i18n.T("pack.install.short"),
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pack, previous, err := generator.InstallPack(args[0], packForce)
+		if err != nil {
+			return err
+		}
+		if previous != "" && previous != pack.Version {
+			fmt.Fprintln(stdout, i18n.T("pack.install.updated", pack.Name, previous, pack.Version))
+		} else {
+			fmt.Fprintln(stdout, i18n.T("pack.install.done", pack.Name, pack.Version))
+		}
+		fmt.Fprintln(stdout, "\n"+i18n.T("pack.install.usage", pack.Name))
+		setData(packData{Name: pack.Name, Version: pack.Version, Description: pack.Description, Previous: previous})
+		nextStep(i18n.T("pack.install.next", pack.Name))
+		return nil
+	},
+}
+
+var packListCmd = &cobra.Command{
+	Use:   "list",
+	Short: i18n.T("pack.list.short"),
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		packs, err := generator.InstalledPacks()
+		if err != nil {
+			return err
+		}
+		list := []packData{}
+		for _, pack := range packs {
+			list = append(list, packData{Name: pack.Name, Version: pack.Version, Description: pack.Description})
+		}
+		setData(list)
+		if len(packs) == 0 {
+			fmt.Fprintln(stdout, i18n.T("pack.list.empty"))
+			return nil
+		}
+
+		w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		for _, pack := range packs {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", pack.Name, pack.Version, pack.Description)
+		}
+		return w.Flush()
+	},
+}
+
+var packRemoveCmd = &cobra.Command{
+	Use:          "remove " + i18n.T("arg.name"),
+	Short:        i18n.T("pack.remove.short"),
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := generator.RemovePack(args[0]); err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, i18n.T("pack.remove.done", args[0]))
+		return nil
+	},
+}
+
+// packData describes a pack in the JSON output
+type packData struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+	Previous    string `json:"previousVersion,omitempty"`
+}
+
+func init() {
+	packCmd.AddCommand(packInstallCmd)
+	packCmd.AddCommand(packListCmd)
+	packCmd.AddCommand(packRemoveCmd)
+
+	packInstallCmd.Flags().BoolVar(&packForce, "force", false, i18n.T("pack.install.flag.force"))
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/YeridStick/cleango/internal/generator"
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var presetCmd = &cobra.Command{
+	Use:   "preset",
+	Short: i18n.T("preset.short"),
+	Long:  i18n.T("preset.long"),
+}
+
+var presetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: i18n.T("preset.list.short"),
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names, err := generator.PresetNames()
+		if err != nil {
+			return err
+		}
+		setData(orEmpty(names))
+		if len(names) == 0 {
+			fmt.Fprintln(stdout, i18n.T("preset.list.empty"))
+			return nil
+		}
+		for _, name := range names {
+			fmt.Fprintln(stdout, name)
+		}
+		return nil
+	},
+}
+
+var presetRemoveCmd = &cobra.Command{
+	Use:          "remove " + i18n.T("arg.name"),
+	Short:        i18n.T("preset.remove.short"),
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := generator.RemovePreset(args[0]); err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, i18n.T("preset.remove.done", args[0]))
+		return nil
+	},
+}
+
+func init() {
+	presetCmd.AddCommand(presetListCmd)
+	presetCmd.AddCommand(presetRemoveCmd)
+}
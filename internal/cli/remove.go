@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/YeridStick/cleango/internal/generator"
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var removeForce bool
+
+var removeCmd = &cobra.Command{
+	Use:     "remove",
+	Aliases: []string{"destroy"},
+	Short:   i18n.T("remove.short"),
+	Long:    i18n.T("remove.long"),
+}
+
+// newRemoveKindCmd builds the subcommand removing one kind of component
+func newRemoveKindCmd(kind, short string) *cobra.Command {
+	return &cobra.Command{
+		Use:          kind + " " + i18n.T("arg.name"),
+		Short:        short,
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			fmt.Fprintln(stdout, i18n.T("remove.removing", kind, name))
+
+			result, err := generator.RemoveComponent(kind, name, removeForce)
+			if result != nil {
+				for _, line := range result.Unregistered {
+					fmt.Fprintf(stdout, "   - %s\n", line)
+				}
+				for _, file := range result.Removed {
+					fmt.Fprintf(stdout, "   - %s\n", file)
+				}
+				if result.Mocks != nil {
+					printMocksResult(result.Mocks)
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(stdout, i18n.T("remove.done", kind, name))
+			return nil
+		},
+	}
+}
+
+func init() {
+	removeCmd.AddCommand(newRemoveKindCmd("usecase", i18n.T("remove.usecase.short")))
+	removeCmd.AddCommand(newRemoveKindCmd("adapter", i18n.T("remove.adapter.short")))
+	removeCmd.AddCommand(newRemoveKindCmd("model", i18n.T("remove.model.short")))
+	removeCmd.AddCommand(newRemoveKindCmd("handler", i18n.T("remove.handler.short")))
+
+	removeCmd.PersistentFlags().BoolVar(&removeForce, "force", false, i18n.T("remove.flag.force"))
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/YeridStick/cleango/internal/generator"
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	renameDryRun bool
+	renameYes    bool
+)
+
+var renameCmd = &cobra.Command{
+	Use:   "rename",
+	Short: i18n.T("rename.short"),
+}
+
+var renameModelCmd = &cobra.Command{
+	Use:          "model " + i18n.T("rename.model.args"),
+	Short:        i18n.T("rename.model.short"),
+	Long:         i18n.T("rename.model.long"),
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plan, err := generator.PlanRenameModel(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		if len(plan.Changes) == 0 {
+			fmt.Fprintln(stdout, i18n.T("rename.nothing"))
+			return nil
+		}
+
+		diff := plan.Diff()
+		fmt.Fprint(stdout, diff)
+		fmt.Fprintln(stdout, "\n"+i18n.T("rename.changes", len(plan.Changes)))
+		setData(renameData{From: plan.From, To: plan.To, DryRun: renameDryRun, Diff: diff})
+		if renameDryRun {
+			fmt.Fprintln(stdout, i18n.T("cli.dry_run"))
+			return nil
+		}
+
+		if !renameYes {
+			if err := requireConfirmation("--yes"); err != nil {
+				return err
+			}
+			prompt := promptui.Prompt{
+				Label:     i18n.T("rename.confirm", plan.From, plan.To),
+				IsConfirm: true,
+			}
+			if _, err := prompt.Run(); err != nil {
+				return cancelled()
+			}
+		}
+
+		if err := plan.Apply(); err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, i18n.T("rename.done", plan.From, plan.To))
+		return nil
+	},
+}
+
+// renameData describes a rename in the JSON output; the files are listed in
+// created, modified and removed
+type renameData struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	DryRun bool   `json:"dryRun"`
+	Diff   string `json:"diff"`
+}
+
+func init() {
+	renameCmd.AddCommand(renameModelCmd)
+
+	renameModelCmd.Flags().BoolVar(&renameDryRun, "dry-run", false, i18n.T("rename.flag.dry_run"))
+	renameModelCmd.Flags().BoolVarP(&renameYes, "yes", "y", false, i18n.T("rename.flag.yes"))
+}
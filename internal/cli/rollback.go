@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// printRollback prints the changes undone after the command failed
+func printRollback() {
+	rollback := cmdResult.RolledBack
+	if rollback == nil {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, i18n.T("rollback.summary"))
+	for _, path := range rollback.Removed {
+		fmt.Fprintln(os.Stderr, i18n.T("rollback.removed", path))
+	}
+	for _, path := range rollback.Restored {
+		fmt.Fprintln(os.Stderr, i18n.T("rollback.restored", path))
+	}
+}
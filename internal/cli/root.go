@@ -1,30 +1,53 @@
 package cli
 
 import (
+	"os"
+
+	"github.com/YeridStick/cleango/internal/i18n"
 	"github.com/spf13/cobra"
 )
 
 var rootCmd = &cobra.Command{
-	Use:   "cleango",
-	Short: "Scaffold CLI para proyectos Go con Clean Architecture",
-	Long: `cleango es un CLI que facilita la creación y gestión de proyectos Go
-siguiendo los principios de Clean Architecture.
-
-Características:
-  • Generación rápida de proyectos con estructura predefinida
-  • Múltiples frameworks HTTP (net/http, chi, gin, fiber)
-  • Soporte para múltiples bases de datos (Postgres, MySQL, MongoDB, Oracle)
-  • Generación de componentes (usecases, adapters, models, handlers)
-  • Configuración centralizada y logger estructurado`,
-	Version: "1.0.0",
+	Use:               "cleango",
+	Short:             i18n.T("root.short"),
+	Long:              i18n.T("root.long"),
+	Version:           "1.0.0",
+	PersistentPreRunE: checkGlobalFlags,
 }
 
-// Execute runs the root command
+// Execute runs the root command. With --output json the result, or the
+// error, is printed as a single JSON document on stdout.
 func Execute() error {
-	return rootCmd.Execute()
+	localizeCobra(rootCmd)
+	setupOutput(requestedOutput(os.Args[1:]))
+	cmd, err := rootCmd.ExecuteC()
+	if !jsonOutput() {
+		printConflicts()
+		printRollback()
+	}
+	// Help and --version print their own text and run no command
+	if jsonOutput() && (commandRan || err != nil) {
+		writeResult(cmd, err)
+	}
+	return err
 }
 
 func init() {
 	rootCmd.AddCommand(newCmd)
+	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(addCmd)
+	rootCmd.AddCommand(openapiCmd)
+	rootCmd.AddCommand(mocksCmd)
+	rootCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(graphCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(upgradeCmd)
+	rootCmd.AddCommand(removeCmd)
+	rootCmd.AddCommand(renameCmd)
+	rootCmd.AddCommand(templatesCmd)
+	rootCmd.AddCommand(packCmd)
+	rootCmd.AddCommand(presetCmd)
+
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", outputHuman, i18n.T("root.flag.output"))
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", i18n.Detect(), i18n.T("root.flag.lang"))
 }
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/YeridStick/cleango/internal/generator"
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ejectUser  bool
+	ejectForce bool
+	ejectAll   bool
+)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: i18n.T("templates.short"),
+	Long:  i18n.T("templates.long"),
+}
+
+var templatesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: i18n.T("templates.list.short"),
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var list []templateData
+		w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		for _, name := range generator.TemplateNames() {
+			_, source, err := generator.ResolveTemplate(name)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "%s\t%s\n", name, describeSource(source))
+			list = append(list, templateData{Name: name, Source: source.Kind, Pack: source.Pack, Path: source.Path})
+		}
+		setData(list)
+		return w.Flush()
+	},
+}
+
+var templatesEjectCmd = &cobra.Command{
+	Use:          "eject " + i18n.T("arg.names"),
+	Short:        i18n.T("templates.eject.short"),
+	Long:         i18n.T("templates.eject.long"),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names := args
+		if ejectAll {
+			names = generator.TemplateNames()
+		}
+		if len(names) == 0 {
+			return generator.WithCode(generator.CodeUsage, i18n.Error("templates.eject.no_names"))
+		}
+
+		for _, name := range names {
+			path, err := generator.EjectTemplate(name, ejectUser, ejectForce)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(stdout, "   + %s\n", path)
+		}
+		fmt.Fprintln(stdout, i18n.T("templates.eject.done"))
+		return nil
+	},
+}
+
+// templateData describes a template in the JSON output. Source is one of
+// the language-neutral ids project, pack, user and builtin.
+type templateData struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Pack   string `json:"pack,omitempty"`
+	Path   string `json:"path,omitempty"`
+}
+
+// describeSource returns where a template is loaded from in the user's
+// language, as "pack acme (path)"
+func describeSource(source generator.TemplateSource) string {
+	text := i18n.T("templates.source." + source.Kind)
+	if source.Pack != "" {
+		text += " " + source.Pack
+	}
+	if source.Path != "" {
+		text += " (" + source.Path + ")"
+	}
+	return text
+}
+
+func init() {
+	templatesCmd.AddCommand(templatesListCmd)
+	templatesCmd.AddCommand(templatesEjectCmd)
+
+	templatesEjectCmd.Flags().BoolVar(&ejectUser, "user", false, i18n.T("templates.eject.flag.user"))
+	templatesEjectCmd.Flags().BoolVar(&ejectForce, "force", false, i18n.T("templates.eject.flag.force"))
+	templatesEjectCmd.Flags().BoolVar(&ejectAll, "all", false, i18n.T("templates.eject.flag.all"))
+}
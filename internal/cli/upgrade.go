@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/YeridStick/cleango/internal/generator"
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var upgradeDryRun bool
+
+var upgradeCmd = &cobra.Command{
+	Use:           "upgrade",
+	Short:         i18n.T("upgrade.short"),
+	Long:          i18n.T("upgrade.long"),
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		result, err := generator.Upgrade(upgradeDryRun)
+		if err != nil {
+			fmt.Fprintln(stdout, i18n.T("upgrade.err", err))
+			return err
+		}
+
+		for _, file := range result.Created {
+			fmt.Fprintf(stdout, "   + %s\n", file)
+		}
+		for _, file := range result.Updated {
+			fmt.Fprintf(stdout, "   ↑ %s\n", file)
+		}
+		for _, file := range result.Merged {
+			fmt.Fprintln(stdout, i18n.T("upgrade.merged", file))
+		}
+		for _, file := range result.Conflicts {
+			fmt.Fprintln(stdout, i18n.T("upgrade.conflict", file))
+		}
+		for _, warning := range result.Warnings {
+			printWarning("%s", warning)
+		}
+
+		setData(upgradeData{
+			Updated:   orEmpty(result.Updated),
+			Merged:    orEmpty(result.Merged),
+			Conflicts: orEmpty(result.Conflicts),
+			Created:   orEmpty(result.Created),
+			DryRun:    upgradeDryRun,
+		})
+		switch {
+		case !result.Changed():
+			fmt.Fprintln(stdout, i18n.T("upgrade.up_to_date"))
+		case upgradeDryRun:
+			fmt.Fprintln(stdout, i18n.T("cli.dry_run"))
+		case len(result.Conflicts) > 0:
+			fmt.Fprintln(stdout, i18n.T("upgrade.resolve"))
+			return generator.WithCode(generator.CodeConflict, i18n.Error("upgrade.conflicts", len(result.Conflicts)))
+		default:
+			fmt.Fprintln(stdout, i18n.T("upgrade.done"))
+		}
+		return nil
+	},
+}
+
+// upgradeData is the outcome of upgrade per file in the JSON output, which
+// also covers a dry run that writes nothing
+type upgradeData struct {
+	Updated   []string `json:"updated"`
+	Merged    []string `json:"merged"`
+	Conflicts []string `json:"conflicts"`
+	Created   []string `json:"created"`
+	DryRun    bool     `json:"dryRun"`
+}
+
+func init() {
+	upgradeCmd.Flags().BoolVar(&upgradeDryRun, "dry-run", false, i18n.T("upgrade.flag.dry_run"))
+}
@@ -0,0 +1,650 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/YeridStick/cleango/internal/openapi"
+)
+
+// APIResult lists the files touched by GenerateAPI
+type APIResult struct {
+	Created  []string
+	Updated  []string
+	Skipped  []string
+	Warnings []string
+}
+
+// warn records a warning once, as shared schemas are read several times
+func (r *APIResult) warn(message string) {
+	for _, warning := range r.Warnings {
+		if warning == message {
+			return
+		}
+	}
+	r.Warnings = append(r.Warnings, message)
+}
+
+// apiOperation is an OpenAPI operation mapped onto a use case and handler
+type apiOperation struct {
+	Name      string
+	Field     string
+	Method    string
+	Path      string
+	Group     string
+	HasBody   bool
+	NoContent bool
+	Params    []apiParam
+	Route     string
+	Respond   string
+
+	input  []FieldSpec
+	output []FieldSpec
+	status int
+}
+
+// apiParam copies a path or query parameter into the use case input
+type apiParam struct {
+	Field string
+	Value string
+}
+
+// apiSyntax holds the framework specific fragments of apiHandlerTemplate
+type apiSyntax struct {
+	Signature string
+	Router    string
+	Context   string
+	Decode    string
+	Fail      string
+	// The format strings below take the parameter name, the status and
+	// the route method/path respectively
+	PathParam  string
+	QueryParam string
+	Respond    string
+	NoContent  string
+	Route      func(method, path, handler string) string
+}
+
+var apiSyntaxes = map[string]apiSyntax{
+	"nethttp": {
+		Signature:  "(w http.ResponseWriter, r *http.Request)",
+		Router:     "*http.ServeMux",
+		Context:    "r.Context()",
+		Decode:     "DecodeJSON(w, r, &input)",
+		Fail:       "WriteError(w, r, err)\n\t\treturn",
+		PathParam:  "r.PathValue(%q)",
+		QueryParam: "r.URL.Query().Get(%q)",
+		Respond:    "WriteJSON(w, %s, out)",
+		NoContent:  "w.WriteHeader(%s)",
+		Route: func(method, path, handler string) string {
+			return fmt.Sprintf("r.HandleFunc(%q, h.%s)", method+" "+path, handler)
+		},
+	},
+	"chi": {
+		Signature:  "(w http.ResponseWriter, r *http.Request)",
+		Router:     "chi.Router",
+		Context:    "r.Context()",
+		Decode:     "DecodeJSON(w, r, &input)",
+		Fail:       "WriteError(w, r, err)\n\t\treturn",
+		PathParam:  "chi.URLParam(r, %q)",
+		QueryParam: "r.URL.Query().Get(%q)",
+		Respond:    "WriteJSON(w, %s, out)",
+		NoContent:  "w.WriteHeader(%s)",
+		Route: func(method, path, handler string) string {
+			return fmt.Sprintf("r.%s(%q, h.%s)", ToPascalCase(method), path, handler)
+		},
+	},
+	"gin": {
+		Signature:  "(c *gin.Context)",
+		Router:     "gin.IRouter",
+		Context:    "c.Request.Context()",
+		Decode:     "DecodeJSON(c, &input)",
+		Fail:       "WriteError(c, err)\n\t\treturn",
+		PathParam:  "c.Param(%q)",
+		QueryParam: "c.Query(%q)",
+		Respond:    "WriteJSON(c, %s, out)",
+		NoContent:  "c.Status(%s)",
+		Route: func(method, path, handler string) string {
+			return fmt.Sprintf("r.%s(%q, h.%s)", method, colonParams(path), handler)
+		},
+	},
+	"fiber": {
+		Signature:  "(c *fiber.Ctx) error",
+		Router:     "fiber.Router",
+		Context:    "c.UserContext()",
+		Decode:     "DecodeJSON(c, &input)",
+		Fail:       "return WriteError(c, err)",
+		PathParam:  "c.Params(%q)",
+		QueryParam: "c.Query(%q)",
+		Respond:    "return WriteJSON(c, %s, out)",
+		NoContent:  "return c.SendStatus(%s)",
+		Route: func(method, path, handler string) string {
+			return fmt.Sprintf("r.%s(%q, h.%s)", ToPascalCase(method), colonParams(path), handler)
+		},
+	},
+}
+
+// colonParams rewrites "{id}" path parameters as ":id"
+func colonParams(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = ":" + segment[1:len(segment)-1]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// GenerateAPI generates models, use cases, handlers and route registration
+// for every operation of a local OpenAPI spec. Files holding business logic
+// (models and use case implementations) are only created when missing;
+// contracts and HTTP glue are regenerated and marked as generated code.
+func GenerateAPI(specPath string) (_ *APIResult, err error) {
+	defer beginTransaction().end(&err)
+
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := openapi.Load(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	syntax, ok := apiSyntaxes[config.Framework]
+	if !ok {
+		syntax = apiSyntaxes["nethttp"]
+	}
+
+	result := &APIResult{}
+	spec := specLabel(specPath)
+
+	if err := generateHTTPSupportFiles(config, false); err != nil {
+		return nil, err
+	}
+
+	// Models from component schemas
+	schemaNames := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		schemaNames = append(schemaNames, name)
+	}
+	sort.Strings(schemaNames)
+
+	for _, name := range schemaNames {
+		schema := doc.Schema(doc.Components.Schemas[name])
+		if schema == nil || (schema.Type != "object" && len(schema.Properties) == 0) {
+			continue
+		}
+		if err := ValidateName(i18n.T("kind.model"), name); err != nil {
+			result.warn(i18n.T("api.schema.skipped", err))
+			continue
+		}
+
+		filename := filepath.Join("domain/models", ToSnakeCase(ToPascalCase(name))+".go")
+		var fields []FieldSpec
+		for _, field := range schemaFields(doc, name, schema, result) {
+			if !isBaseModelField(field.GoName()) {
+				fields = append(fields, field)
+			}
+		}
+
+		content, err := renderModel(config, name, fields)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeAPIStub(filename, content, result); err != nil {
+			return nil, err
+		}
+	}
+
+	// Use cases and handlers from operations
+	operations, err := apiOperations(doc, syntax, result)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[string][]*apiOperation{}
+	var groupNames []string
+	for _, op := range operations {
+		if _, ok := groups[op.Group]; !ok {
+			groupNames = append(groupNames, op.Group)
+		}
+		groups[op.Group] = append(groups[op.Group], op)
+
+		if err := generateAPIUsecase(config, spec, op, result); err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(groupNames)
+
+	for _, group := range groupNames {
+		if err := generateAPIHandler(config, syntax, spec, group, groups[group], result); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := RefreshGraphQL(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// specLabel returns how the generated files name the spec: its path relative
+// to the project, or its base name when it lives outside, so that the code
+// does not depend on where the spec was read from
+func specLabel(specPath string) string {
+	abs, err := filepath.Abs(specPath)
+	if err != nil {
+		return filepath.Base(specPath)
+	}
+	root, err := os.Getwd()
+	if err != nil {
+		return filepath.Base(specPath)
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.Base(specPath)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// apiOperations maps every operation of the spec, sorted by path and method
+func apiOperations(doc *openapi.Document, syntax apiSyntax, result *APIResult) ([]*apiOperation, error) {
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var operations []*apiOperation
+	seen := map[string]string{}
+
+	for _, path := range paths {
+		item := doc.Paths[path]
+		for _, mo := range item.Operations() {
+			op := mo.Operation
+			if op.OperationID != "" {
+				if err := ValidateName(i18n.T("kind.operation"), op.OperationID); err != nil {
+					return nil, fmt.Errorf("%s %s: %w", mo.Method, path, err)
+				}
+			}
+			name := operationName(mo.Method, path, op.OperationID)
+			if previous, dup := seen[name]; dup {
+				return nil, i18n.Error("api.operation.clash", previous, mo.Method, path, name)
+			}
+			seen[name] = mo.Method + " " + path
+
+			mapped := &apiOperation{
+				Name:   name,
+				Field:  ToCamelCase(name),
+				Method: mo.Method,
+				Path:   path,
+				Group:  operationGroup(path, op),
+			}
+
+			// Path and query parameters become input fields filled from the URL
+			for _, param := range append(append([]*openapi.Parameter{}, item.Parameters...), op.Parameters...) {
+				param = doc.Parameter(param)
+				if param == nil || (param.In != "path" && param.In != "query") {
+					continue
+				}
+				field := FieldSpec{Name: param.Name, JSON: param.Name, Type: "string", Location: param.In}
+				if param.Required && param.In == "query" {
+					field.Rules = []FieldRule{{Name: "required"}}
+				}
+				mapped.input = append(mapped.input, field)
+
+				format := syntax.QueryParam
+				if param.In == "path" {
+					format = syntax.PathParam
+				}
+				mapped.Params = append(mapped.Params, apiParam{
+					Field: field.GoName(),
+					Value: fmt.Sprintf(format, param.Name),
+				})
+			}
+
+			if body := doc.RequestBody(op.RequestBody); body != nil {
+				raw := openapi.JSONSchema(body.Content)
+				if schema := doc.Schema(raw); schema != nil {
+					mapped.HasBody = true
+					for _, field := range schemaFields(doc, schemaOwner(raw, name+" request body"), schema, result) {
+						if hasField(mapped.input, field.GoName()) {
+							result.warn(i18n.T("api.body.param", name, field.Name))
+							continue
+						}
+						mapped.input = append(mapped.input, field)
+					}
+				}
+			}
+
+			mapped.status, mapped.output = successResponse(doc, name, op, result)
+			status := strconv.Itoa(mapped.status)
+			if constant := openapi.StatusName(mapped.status); constant != "" {
+				status = "http." + constant
+			}
+			if mapped.status == 204 {
+				mapped.NoContent = true
+				mapped.Respond = fmt.Sprintf(syntax.NoContent, status)
+			} else {
+				mapped.Respond = fmt.Sprintf(syntax.Respond, status)
+			}
+			mapped.Route = syntax.Route(mo.Method, path, name)
+
+			operations = append(operations, mapped)
+		}
+	}
+
+	return operations, nil
+}
+
+// operationName derives the Go name of an operation from its operationId,
+// or from its method and path when the spec does not declare one
+func operationName(method, path, operationID string) string {
+	if operationID != "" {
+		return ToPascalCase(operationID)
+	}
+
+	words := []string{strings.ToLower(method)}
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") {
+			words = append(words, "by", strings.Trim(segment, "{}"))
+		} else if segment != "" {
+			words = append(words, segment)
+		}
+	}
+	return ToPascalCase(strings.Join(words, "_"))
+}
+
+// operationGroup returns the handler an operation belongs to: its first tag,
+// or the first segment of its path
+func operationGroup(path string, op *openapi.Operation) string {
+	if len(op.Tags) > 0 && ToPascalCase(op.Tags[0]) != "" {
+		return ToPascalCase(op.Tags[0])
+	}
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" && !strings.HasPrefix(segment, "{") {
+			return ToPascalCase(segment)
+		}
+	}
+	return "Root"
+}
+
+// successResponse returns the first 2xx status of an operation and the
+// fields of its JSON body
+func successResponse(doc *openapi.Document, name string, op *openapi.Operation, result *APIResult) (int, []FieldSpec) {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		status, err := strconv.Atoi(code)
+		if err != nil || status < 200 || status > 299 {
+			continue
+		}
+
+		var fields []FieldSpec
+		if response := doc.Response(op.Responses[code]); response != nil {
+			raw := openapi.JSONSchema(response.Content)
+			if schema := doc.Schema(raw); schema != nil {
+				for _, field := range schemaFields(doc, schemaOwner(raw, name+" response"), schema, result) {
+					field.Rules = nil
+					fields = append(fields, field)
+				}
+			}
+		}
+		return status, fields
+	}
+
+	return 200, nil
+}
+
+// schemaFields maps the scalar properties of an object schema to field specs
+func schemaFields(doc *openapi.Document, owner string, schema *openapi.Schema, result *APIResult) []FieldSpec {
+	required := map[string]bool{}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fields []FieldSpec
+	for _, name := range names {
+		prop := doc.Schema(schema.Properties[name])
+		if prop == nil {
+			continue
+		}
+
+		field := FieldSpec{Name: name, JSON: name}
+		switch prop.Type {
+		case "string":
+			field.Type = "string"
+			if prop.Format == "date-time" || prop.Format == "date" {
+				field.Type = "time"
+			}
+		case "integer":
+			field.Type = "int"
+			if prop.Format == "int64" {
+				field.Type = "int64"
+			}
+		case "number":
+			field.Type = "float"
+		case "boolean":
+			field.Type = "bool"
+		default:
+			result.warn(i18n.T("api.property.scalar", owner, name, prop.Type))
+			continue
+		}
+
+		if required[name] {
+			field.Rules = append(field.Rules, FieldRule{Name: "required"})
+		}
+		field.Rules = append(field.Rules, schemaRules(field.Type, prop)...)
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// schemaOwner names a schema in warnings: its component name when it is a
+// reference, or fallback for inline schemas
+func schemaOwner(raw *openapi.Schema, fallback string) string {
+	if raw != nil && raw.Ref != "" {
+		return openapi.RefName(raw.Ref)
+	}
+	return fallback
+}
+
+// schemaRules maps schema constraints onto validation rules
+func schemaRules(fieldType string, prop *openapi.Schema) []FieldRule {
+	var rules []FieldRule
+	number := func(v float64) (string, bool) {
+		if fieldType == "float" {
+			return strconv.FormatFloat(v, 'f', -1, 64), true
+		}
+		if v != float64(int64(v)) {
+			return "", false
+		}
+		return strconv.FormatInt(int64(v), 10), true
+	}
+
+	switch fieldType {
+	case "string":
+		if prop.MinLength != nil {
+			rules = append(rules, FieldRule{Name: "min", Arg: strconv.Itoa(*prop.MinLength)})
+		}
+		if prop.MaxLength != nil {
+			rules = append(rules, FieldRule{Name: "max", Arg: strconv.Itoa(*prop.MaxLength)})
+		}
+		if prop.Format == "email" {
+			rules = append(rules, FieldRule{Name: "email"})
+		}
+		if len(prop.Enum) > 0 {
+			rules = append(rules, FieldRule{Name: "oneof", Arg: strings.Join(prop.Enum, "|")})
+		}
+	case "int", "int64", "float":
+		if prop.Minimum != nil {
+			if arg, ok := number(*prop.Minimum); ok {
+				rules = append(rules, FieldRule{Name: "min", Arg: arg})
+			}
+		}
+		if prop.Maximum != nil {
+			if arg, ok := number(*prop.Maximum); ok {
+				rules = append(rules, FieldRule{Name: "max", Arg: arg})
+			}
+		}
+	}
+	return rules
+}
+
+func hasField(fields []FieldSpec, goName string) bool {
+	for _, field := range fields {
+		if field.GoName() == goName {
+			return true
+		}
+	}
+	return false
+}
+
+// generateAPIUsecase writes the contract of an operation's use case and the
+// implementation stub
+func generateAPIUsecase(config ProjectConfig, spec string, op *apiOperation, result *APIResult) error {
+	implFile := filepath.Join("domain/usecases", ToSnakeCase(op.Name)+".go")
+	contractFile := filepath.Join("domain/usecases", ToSnakeCase(op.Name)+"_contract.go")
+
+	// Use cases created with "cleango add usecase" declare their DTOs inline
+	if existing, err := os.ReadFile(implFile); err == nil && bytes.Contains(existing, []byte("type "+op.Name+"Input struct")) {
+		result.warn(i18n.T("api.impl.dtos", implFile, op.Name, contractFile))
+		result.Skipped = append(result.Skipped, implFile)
+		return nil
+	}
+
+	std := []string{"context"}
+	for _, field := range op.output {
+		if field.Type == "time" {
+			std = append(std, "time")
+		}
+	}
+
+	data := fieldData(config.ModulePath, "in", op.Name+" input", op.input, std, nil, nil)
+	data["Name"] = op.Name
+	data["Spec"] = spec
+	data["OutputFields"] = renderStructFields(op.output)
+
+	content, err := renderGoTemplate("usecaseContract", data)
+	if err != nil {
+		return err
+	}
+	if err := writeAPIFile(contractFile, content, result); err != nil {
+		return err
+	}
+
+	content, err = renderGoTemplate("usecaseImpl", map[string]string{
+		"Name":      op.Name,
+		"LowerName": ToCamelCase(op.Name),
+	})
+	if err != nil {
+		return err
+	}
+	return writeAPIStub(implFile, content, result)
+}
+
+// generateAPIHandler writes the HTTP glue of a group of operations and
+// registers its routes
+func generateAPIHandler(config ProjectConfig, syntax apiSyntax, spec, group string, ops []*apiOperation, result *APIResult) error {
+	std := []string{"net/http"}
+	var external []string
+	switch config.Framework {
+	case "chi":
+		external = []string{"github.com/go-chi/chi/v5"}
+	case "gin":
+		external = []string{"github.com/gin-gonic/gin"}
+	case "fiber":
+		external = []string{"github.com/gofiber/fiber/v2"}
+	}
+
+	// gin and fiber only need net/http for the status constants
+	if config.Framework == "gin" || config.Framework == "fiber" {
+		usesHTTP := false
+		for _, op := range ops {
+			usesHTTP = usesHTTP || strings.Contains(op.Respond, "http.")
+		}
+		if !usesHTTP {
+			std = nil
+		}
+	}
+
+	data := map[string]interface{}{
+		"Spec":       spec,
+		"Group":      group,
+		"Syntax":     syntax,
+		"Operations": ops,
+		"Imports":    renderImports(std, external, []string{fmt.Sprintf("%q", config.ModulePath+"/domain/usecases")}),
+	}
+
+	content, err := renderGoTemplate("apiHandler", data)
+	if err != nil {
+		return err
+	}
+
+	filename := filepath.Join("infrastructure/entrypoints/http", ToSnakeCase(group)+"_api.go")
+	if err := writeAPIFile(filename, content, result); err != nil {
+		return err
+	}
+
+	registration := fmt.Sprintf("New%sAPI().RegisterRoutes(r)", group)
+	if err := InsertBeforeMarker(filepath.Join("infrastructure/entrypoints/http", "routes.go"), routesMarker, registration); err != nil {
+		result.warn(i18n.T("api.route", registration, err))
+	}
+	return nil
+}
+
+// writeAPIFile writes content and records whether the file was created or
+// updated. Unchanged files are left untouched.
+func writeAPIFile(filename string, content []byte, result *APIResult) error {
+	existing, err := os.ReadFile(filename)
+	switch {
+	case err == nil && bytes.Equal(existing, content):
+		return nil
+	case err == nil:
+		result.Updated = append(result.Updated, filename)
+	default:
+		result.Created = append(result.Created, filename)
+	}
+
+	if err := EnsureDir(filepath.Dir(filename)); err != nil {
+		return err
+	}
+	return WriteFile(filename, content)
+}
+
+// writeAPIStub writes a file the user completes, a model or a use case
+// implementation, handling an existing one with the conflict policy, which
+// reports it
+func writeAPIStub(filename string, content []byte, result *APIResult) error {
+	outcome, err := Conflicts.write(generatedFile{filename, content})
+	if err != nil {
+		return err
+	}
+	switch outcome {
+	case fileCreated:
+		result.Created = append(result.Created, filename)
+	case fileOverwritten:
+		result.Updated = append(result.Updated, filename)
+	}
+	return nil
+}
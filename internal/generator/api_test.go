@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerateAPISpecHeader generates the API of the same spec read from two
+// places outside the project: the generated files name it by its base name,
+// so the second run leaves them untouched
+func TestGenerateAPISpecHeader(t *testing.T) {
+	spec, err := os.ReadFile(filepath.Join("testdata", "api.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testProject(t)
+
+	var paths []string
+	for i := 0; i < 2; i++ {
+		path := filepath.Join(t.TempDir(), "api.yaml")
+		if err := os.WriteFile(path, spec, 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+
+	if _, err := GenerateAPI(paths[0]); err != nil {
+		t.Fatalf("add api: %v", err)
+	}
+	contract, err := os.ReadFile("domain/usecases/create_order_contract.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header, _, _ := strings.Cut(string(contract), "\n"); header != "// Code generated by cleango from api.yaml. DO NOT EDIT." {
+		t.Errorf("header is %q, want the base name of the spec", header)
+	}
+
+	report := StartReport()
+	t.Cleanup(func() { activeReport = nil })
+	if _, err := GenerateAPI(paths[1]); err != nil {
+		t.Fatalf("add api: %v", err)
+	}
+	if len(report.Modified) != 0 {
+		t.Errorf("the same spec read from elsewhere rewrote %v", report.Modified)
+	}
+}
+
+// TestSpecLabel names specs inside the project by their relative path and
+// those outside by their base name
+func TestSpecLabel(t *testing.T) {
+	dir := t.TempDir()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(cwd) })
+	project := filepath.Join(dir, "shop")
+	if err := os.Mkdir(project, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(project); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"api.yaml", "api.yaml"},
+		{filepath.Join("api", "v1", "openapi.yaml"), "api/v1/openapi.yaml"},
+		{filepath.Join(project, "api", "openapi.yaml"), "api/openapi.yaml"},
+		{filepath.Join("..", "specs", "openapi.yaml"), "openapi.yaml"},
+		{filepath.Join(dir, "shop-specs", "openapi.yaml"), "openapi.yaml"},
+	}
+	for _, tt := range tests {
+		if got := specLabel(tt.path); got != tt.want {
+			t.Errorf("specLabel(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -1,61 +1,96 @@
 package generator
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
-	"text/template"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
 )
 
-// GenerateUsecase generates a new use case
-func GenerateUsecase(name string) error {
-	// Ensure we're in a Go project
-	if !FileExists("go.mod") {
-		return fmt.Errorf("no se encontró go.mod. Asegúrate de estar en la raíz del proyecto")
+// GenerateUsecase generates a new use case. When model is set, the input
+// struct and its validation are derived from that model's fields, and the use
+// case takes the model's port as an option when the port has a mock.
+// withTests adds a table-driven test covering those validations.
+func GenerateUsecase(name, model string, withTests bool) (err error) {
+	defer beginTransaction().end(&err)
+
+	if err := ValidateName(i18n.T("kind.usecase"), name); err != nil {
+		return err
+	}
+
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return err
 	}
 
 	// Ensure usecase directory exists
 	usecaseDir := "domain/usecases"
 	if err := EnsureDir(usecaseDir); err != nil {
-		return fmt.Errorf("error creando directorio usecases: %w", err)
+		return i18n.Error("err.mkdir.usecases", err)
 	}
 
-	// Prepare template data
-	data := map[string]string{
-		"Name":      ToPascalCase(name),
-		"LowerName": ToCamelCase(name),
+	var fields []FieldSpec
+	var port *usecasePort
+	if model != "" {
+		if fields, err = LoadModelFields(model); err != nil {
+			return err
+		}
+		if port, err = findUsecasePort(config.ModulePath, model); err != nil {
+			return err
+		}
 	}
 
-	// Parse template
-	tmpl, err := template.New("usecase").Parse(usecaseTemplate)
-	if err != nil {
-		return err
+	// Prepare template data
+	var local []string
+	if port != nil {
+		local = append(local, port.importSpec())
+	}
+	data := fieldData(config.ModulePath, "in", ToPascalCase(name)+" input", fields, []string{"context"}, nil, local)
+	data["Name"] = ToPascalCase(name)
+	data["LowerName"] = ToCamelCase(name)
+	if port != nil {
+		data["PortField"] = port.Field
+		data["PortType"] = port.Type
+		data["PortName"] = port.Name
 	}
 
-	// Execute template
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
+	content, err := renderGoTemplate("usecase", data)
+	if err != nil {
 		return err
 	}
 
 	// Write file
 	filename := filepath.Join(usecaseDir, ToSnakeCase(name)+".go")
-	if FileExists(filename) {
-		return fmt.Errorf("el archivo %s ya existe", filename)
+	if err := writeComponent(filename, content); err != nil {
+		return err
 	}
 
-	return WriteFile(filename, buf.Bytes())
+	if withTests {
+		if err := generateUsecaseTest(config, name, fields, port); err != nil {
+			return err
+		}
+	}
+	return RefreshGraphQL()
 }
 
 // GenerateAdapter generates a new adapter/repository
-func GenerateAdapter(name string, withTests bool) error {
+func GenerateAdapter(name string, withTests bool) (err error) {
+	defer beginTransaction().end(&err)
+
+	if err := ValidateName(i18n.T("kind.adapter"), name); err != nil {
+		return err
+	}
+
 	if !FileExists("go.mod") {
-		return fmt.Errorf("no se encontró go.mod. Asegúrate de estar en la raíz del proyecto")
+		return i18n.Error("err.no_gomod")
 	}
 
 	repoDir := "infrastructure/adapters/database"
 	if err := EnsureDir(repoDir); err != nil {
-		return fmt.Errorf("error creando directorio database: %w", err)
+		return i18n.Error("err.mkdir.database", err)
 	}
 
 	data := map[string]string{
@@ -63,111 +98,238 @@ func GenerateAdapter(name string, withTests bool) error {
 		"LowerName": ToCamelCase(name),
 	}
 
-	tmpl, err := template.New("adapter").Parse(adapterTemplate)
+	content, err := renderGoTemplate("adapter", data)
 	if err != nil {
 		return err
 	}
+	files := []generatedFile{{filepath.Join(repoDir, ToSnakeCase(name)+".go"), content}}
+
+	// The test is rendered too before writing either file
+	if withTests {
+		testContent, err := renderGoTemplate("adapterTest", data)
+		if err != nil {
+			return err
+		}
+		files = append(files, generatedFile{filepath.Join(repoDir, ToSnakeCase(name)+"_test.go"), testContent})
+	}
+
+	_, err = writeFiles(files)
+	return err
+}
+
+// GenerateModel generates a new domain model with the given fields. withTests
+// adds a table-driven Validate test derived from the field rules.
+func GenerateModel(name string, fields []FieldSpec, withTests bool) (err error) {
+	defer beginTransaction().end(&err)
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
+	if err := ValidateName(i18n.T("kind.model"), name); err != nil {
 		return err
 	}
 
-	filename := filepath.Join(repoDir, ToSnakeCase(name)+".go")
-	if FileExists(filename) {
-		return fmt.Errorf("el archivo %s ya existe", filename)
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return err
 	}
 
-	if err := WriteFile(filename, buf.Bytes()); err != nil {
+	domainDir := "domain/models"
+	if err := EnsureDir(domainDir); err != nil {
+		return i18n.Error("err.mkdir.models", err)
+	}
+
+	content, err := renderModel(config, name, fields)
+	if err != nil {
+		return err
+	}
+
+	filename := filepath.Join(domainDir, ToSnakeCase(name)+".go")
+	if err := writeComponent(filename, content); err != nil {
 		return err
 	}
 
 	if withTests {
-		testTmpl, err := template.New("adapter_test").Parse(adapterTestTemplate)
-		if err != nil {
+		if err := generateModelTest(config, name, fields); err != nil {
 			return err
 		}
+	}
+	return RefreshGraphQL()
+}
 
-		var testBuf bytes.Buffer
-		if err := testTmpl.Execute(&testBuf, data); err != nil {
-			return err
-		}
+// renderModel renders the source of a domain model
+func renderModel(config ProjectConfig, name string, fields []FieldSpec) ([]byte, error) {
+	data := fieldData(config.ModulePath, "m", ToPascalCase(name), fields, []string{"time"}, nil, nil)
+	data["Name"] = ToPascalCase(name)
+	return renderGoTemplate("model", data)
+}
 
-		testFile := filepath.Join(repoDir, ToSnakeCase(name)+"_test.go")
-		if FileExists(testFile) {
-			return fmt.Errorf("el archivo %s ya existe", testFile)
-		}
+// GenerateHandler generates a new HTTP handler for the project's framework.
+// The request DTO is derived from model, or from the model sharing the
+// handler's name when model is empty and such a model exists. withTests adds
+// an httptest test served through the project's router.
+func GenerateHandler(name, model string, withTests bool) (err error) {
+	defer beginTransaction().end(&err)
 
-		if err := WriteFile(testFile, testBuf.Bytes()); err != nil {
-			return err
-		}
+	if err := ValidateName(i18n.T("kind.handler"), name); err != nil {
+		return err
 	}
 
-	return nil
-}
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return err
+	}
 
-// GenerateModel generates a new domain model
-func GenerateModel(name string) error {
-	if !FileExists("go.mod") {
-		return fmt.Errorf("no se encontró go.mod. Asegúrate de estar en la raíz del proyecto")
+	httpDir := "infrastructure/entrypoints/http"
+	if err := EnsureDir(httpDir); err != nil {
+		return i18n.Error("err.mkdir.http", err)
 	}
 
-	domainDir := "domain/models"
-	if err := EnsureDir(domainDir); err != nil {
-		return fmt.Errorf("error creando directorio models: %w", err)
+	// Projects created before the error model existed get it on demand
+	if err := generateHTTPSupportFiles(config, false); err != nil {
+		return err
 	}
 
-	data := map[string]string{
-		"Name": ToPascalCase(name),
+	fields, err := handlerFields(name, model)
+	if err != nil {
+		return err
+	}
+
+	var external []string
+	switch config.Framework {
+	case "chi":
+		external = []string{"github.com/go-chi/chi/v5"}
+	case "gin":
+		external = []string{"github.com/gin-gonic/gin"}
+	case "fiber":
+		external = []string{"github.com/gofiber/fiber/v2"}
 	}
 
-	tmpl, err := template.New("model").Parse(modelTemplate)
+	data := fieldData(config.ModulePath, "req", ToPascalCase(name)+" request", fields, []string{"net/http"}, external, nil)
+	data["Name"] = ToPascalCase(name)
+	data["LowerName"] = ToCamelCase(name)
+	data["ModulePath"] = config.ModulePath
+	data["Framework"] = config.Framework
+	data["Resource"] = ToResourcePath(name)
+
+	content, err := renderGoTemplate(handlerTemplateFor(config.Framework), data)
 	if err != nil {
 		return err
 	}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
+	filename := filepath.Join(httpDir, ToSnakeCase(name)+"_handler.go")
+	if err := writeComponent(filename, content); err != nil {
 		return err
 	}
 
-	filename := filepath.Join(domainDir, ToSnakeCase(name)+".go")
-	if FileExists(filename) {
-		return fmt.Errorf("el archivo %s ya existe", filename)
+	if withTests {
+		if err := generateHandlerTest(config, name, fields); err != nil {
+			return err
+		}
 	}
 
-	return WriteFile(filename, buf.Bytes())
+	registration := fmt.Sprintf("New%sHandler().RegisterRoutes(r)", ToPascalCase(name))
+	if err := InsertBeforeMarker(filepath.Join(httpDir, "routes.go"), routesMarker, registration); err != nil {
+		return i18n.Error("component.route", registration, err)
+	}
+	return nil
 }
 
-// GenerateHandler generates a new HTTP handler
-func GenerateHandler(name string) error {
-	if !FileExists("go.mod") {
-		return fmt.Errorf("no se encontró go.mod. Asegúrate de estar en la raíz del proyecto")
+// handlerFields returns the request fields for a handler
+func handlerFields(name, model string) ([]FieldSpec, error) {
+	if model != "" {
+		return LoadModelFields(model)
 	}
 
-	httpDir := "infrastructure/entrypoints/http"
-	if err := EnsureDir(httpDir); err != nil {
-		return fmt.Errorf("error creando directorio http: %w", err)
+	fields, err := LoadModelFields(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
 	}
+	return fields, err
+}
 
-	data := map[string]string{
-		"Name": ToPascalCase(name),
+// handlerTemplateFor returns the name of the handler template for the given
+// framework
+func handlerTemplateFor(framework string) string {
+	switch framework {
+	case "gin":
+		return "handlerGin"
+	case "fiber":
+		return "handlerFiber"
+	default:
+		return "handler"
 	}
+}
 
-	tmpl, err := template.New("handler").Parse(handlerTemplate)
+// routesMarker marks where generated handlers are registered in routes.go
+const routesMarker = "// cleango:routes"
+
+// generateHTTPSupportFiles writes the domain error model, the HTTP error
+// responder, the JSON request decoder and the route registry. Existing files
+// are kept unless overwrite is set.
+func generateHTTPSupportFiles(config ProjectConfig, overwrite bool) error {
+	files, err := renderHTTPSupportFiles(config)
 	if err != nil {
 		return err
 	}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return err
+	var written []generatedFile
+	for _, file := range files {
+		if !overwrite && FileExists(file.path) {
+			continue
+		}
+
+		if err := EnsureDir(filepath.Dir(file.path)); err != nil {
+			return i18n.Error("err.mkdir", filepath.Dir(file.path), err)
+		}
+		if err := WriteFile(file.path, file.content); err != nil {
+			return i18n.Error("err.create", file.path, err)
+		}
+		written = append(written, file)
 	}
 
-	filename := filepath.Join(httpDir, ToSnakeCase(name)+"_handler.go")
-	if FileExists(filename) {
-		return fmt.Errorf("el archivo %s ya existe", filename)
+	return recordPristine(written)
+}
+
+// renderHTTPSupportFiles renders the files written by generateHTTPSupportFiles
+func renderHTTPSupportFiles(config ProjectConfig) ([]generatedFile, error) {
+	templates := []struct {
+		path     string
+		template string
+	}{
+		{"domain/errors/errors.go", "domainErrors"},
+		{"infrastructure/entrypoints/http/errors.go", "httpErrors"},
+		{"infrastructure/entrypoints/http/request.go", "httpRequest"},
+		{"infrastructure/entrypoints/http/routes.go", "httpRoutes"},
 	}
 
-	return WriteFile(filename, buf.Bytes())
+	var files []generatedFile
+	for _, t := range templates {
+		content, err := renderGoTemplate(t.template, config)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, generatedFile{t.path, content})
+	}
+	return files, nil
+}
+
+// fieldData renders the struct fields, Validate body and import block shared
+// by the model, use case and handler templates. local lists the project
+// packages the template needs besides domain/errors.
+func fieldData(modulePath, receiver, subject string, fields []FieldSpec, std, external, local []string) map[string]string {
+	validation, validationImports := renderValidation(receiver, subject, fields)
+	std = append(std, validationImports...)
+	for _, field := range fields {
+		if field.Type == "time" {
+			std = append(std, "time")
+		}
+	}
+
+	if strings.Contains(validation, "domainerrors.") {
+		local = append(local, fmt.Sprintf("domainerrors %q", modulePath+"/domain/errors"))
+	}
+
+	return map[string]string{
+		"Fields":     renderStructFields(fields),
+		"Validation": validation,
+		"Imports":    renderImports(std, external, local),
+	}
 }
@@ -1,19 +1,36 @@
 package generator
 
+import (
+	"os"
+	"path"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+	"gopkg.in/yaml.v3"
+)
+
 // ProjectConfig holds the configuration for a new project
 type ProjectConfig struct {
-	Name       string
-	ModulePath string
-	Framework  string
-	Database   string
-	UseRedis   bool
-	UseKafka   bool
+	Name       string `json:"name"`
+	ModulePath string `json:"module"`
+	Framework  string `json:"framework"`
+	Database   string `json:"database"`
+	UseRedis   bool   `json:"redis"`
+	UseKafka   bool   `json:"kafka"`
+	// Logger is the logging library: zap, the default, or slog
+	Logger string `json:"logger"`
+	// Lang is the language of the generated documentation, es or en
+	Lang string `json:"lang,omitempty"`
+
+	// Pack is the template pack the project was created with, if any
+	Pack *PackRef `json:"pack,omitempty"`
 }
 
 // GetDependencies returns the list of Go dependencies to install
 func (c *ProjectConfig) GetDependencies() []string {
-	deps := []string{
-		"go.uber.org/zap", // logger
+	var deps []string
+	if c.Logger != "slog" {
+		deps = append(deps, "go.uber.org/zap") // logger
 	}
 
 	// Add framework dependencies
@@ -49,3 +66,165 @@ func (c *ProjectConfig) GetDependencies() []string {
 
 	return deps
 }
+
+// frameworkModules maps each framework to the module that identifies it in go.mod
+var frameworkModules = map[string]string{
+	"chi":   "github.com/go-chi/chi/v5",
+	"gin":   "github.com/gin-gonic/gin",
+	"fiber": "github.com/gofiber/fiber/v2",
+}
+
+// databaseModules maps each database to the module that identifies it in go.mod
+var databaseModules = map[string]string{
+	"postgres": "github.com/jackc/pgx/v5",
+	"mysql":    "github.com/go-sql-driver/mysql",
+	"mongodb":  "go.mongodb.org/mongo-driver",
+	"oracle":   "github.com/godror/godror",
+}
+
+// Frameworks and Databases list the options accepted by 'cleango new'
+var (
+	Frameworks = []string{"nethttp", "chi", "gin", "fiber"}
+	Databases  = []string{"none", "postgres", "mysql", "mongodb", "oracle"}
+	Loggers    = []string{"zap", "slog"}
+)
+
+// Validate checks the name, module path and options of a new project
+func (c ProjectConfig) Validate() error {
+	if err := ValidateProjectName(c.Name); err != nil {
+		return err
+	}
+	if err := ValidateModulePath(c.ModulePath); err != nil {
+		return err
+	}
+	if err := ValidateOption("framework", c.Framework, Frameworks); err != nil {
+		return err
+	}
+	if err := ValidateOption(i18n.T("option.database"), c.Database, Databases); err != nil {
+		return err
+	}
+	if c.Logger != "" {
+		if err := ValidateOption("logger", c.Logger, Loggers); err != nil {
+			return err
+		}
+	}
+	if c.Lang != "" {
+		return ValidateOption("lang", c.Lang, i18n.Languages)
+	}
+	return nil
+}
+
+// contains reports whether list holds value
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// goMod holds the parts of go.mod read by the generators
+type goMod struct {
+	Module   string
+	Go       string
+	Requires map[string]string
+}
+
+// readGoMod parses the go.mod of the current directory
+func readGoMod() (*goMod, error) {
+	content, err := os.ReadFile("go.mod")
+	if err != nil {
+		return nil, codedError(CodeNotAProject, "err.no_gomod")
+	}
+
+	mod := &goMod{Requires: map[string]string{}}
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "module" && len(fields) > 1 {
+			mod.Module = strings.Trim(fields[1], "\"")
+			continue
+		}
+		if fields[0] == "go" && len(fields) > 1 {
+			mod.Go = fields[1]
+			continue
+		}
+		if fields[0] == "require" && len(fields) > 2 {
+			fields = fields[1:]
+		}
+		if len(fields) > 1 && strings.HasPrefix(fields[1], "v") {
+			mod.Requires[fields[0]] = fields[1]
+		}
+	}
+
+	if mod.Module == "" {
+		return nil, i18n.Error("config.no_module")
+	}
+	return mod, nil
+}
+
+// Provides reports whether a required module provides the package path
+func (m *goMod) Provides(pkg string) bool {
+	for module := range m.Requires {
+		if pkg == module || strings.HasPrefix(pkg, module+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadProjectConfig rebuilds the configuration of the project in the current
+// directory from its go.mod and, when present, its manifest
+func LoadProjectConfig() (ProjectConfig, error) {
+	mod, err := readGoMod()
+	if err != nil {
+		return ProjectConfig{}, err
+	}
+
+	config := ProjectConfig{
+		ModulePath: mod.Module,
+		Framework:  "nethttp",
+		Database:   "none",
+	}
+	config.Name = path.Base(config.ModulePath)
+
+	for framework, module := range frameworkModules {
+		if _, ok := mod.Requires[module]; ok {
+			config.Framework = framework
+		}
+	}
+	for database, module := range databaseModules {
+		if _, ok := mod.Requires[module]; ok {
+			config.Database = database
+		}
+	}
+	config.UseRedis = mod.Provides("github.com/redis/go-redis/v9")
+	config.UseKafka = mod.Provides("github.com/segmentio/kafka-go")
+
+	// The manifest, when present, records the choices made at creation
+	if content, err := os.ReadFile(ManifestFile); err == nil {
+		var manifest Manifest
+		if err := yaml.Unmarshal(content, &manifest); err != nil {
+			return ProjectConfig{}, i18n.Error("err.read", ManifestFile, err)
+		}
+		if manifest.Name != "" {
+			config.Name = manifest.Name
+		}
+		if manifest.Framework != "" {
+			config.Framework = manifest.Framework
+		}
+		if manifest.Database != "" {
+			config.Database = manifest.Database
+		}
+		config.UseRedis = config.UseRedis || manifest.Redis
+		config.UseKafka = config.UseKafka || manifest.Kafka
+		config.Logger = manifest.Logger
+		config.Lang = manifest.Lang
+		config.Pack = manifest.Pack
+	}
+
+	return config, nil
+}
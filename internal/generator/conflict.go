@@ -0,0 +1,185 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/YeridStick/cleango/internal/merge"
+)
+
+// Conflict strategies for the files a generator would write over
+const (
+	// ConflictSkip keeps the existing file
+	ConflictSkip = "skip"
+	// ConflictOverwrite replaces the existing file
+	ConflictOverwrite = "overwrite"
+	// ConflictBackup copies the existing file to <file>.bak and replaces it
+	ConflictBackup = "backup"
+	// ConflictNew keeps the existing file and writes the generated one next
+	// to it with the .new extension
+	ConflictNew = "new"
+	// ConflictPrompt shows the diff of each file and asks what to do
+	ConflictPrompt = "prompt"
+	// ConflictFail stops the generator with an already_exists error
+	ConflictFail = "fail"
+)
+
+// ConflictStrategies lists the values of --on-conflict
+var ConflictStrategies = []string{ConflictSkip, ConflictOverwrite, ConflictBackup, ConflictNew, ConflictPrompt, ConflictFail}
+
+// Suffixes of the files written next to an existing one
+const (
+	newSuffix    = ".new"
+	backupSuffix = ".bak"
+)
+
+// ConflictPolicy decides what happens to an existing file whose content
+// differs from the one a generator creates
+type ConflictPolicy struct {
+	Strategy string
+	// Ask chooses the strategy of a single file with ConflictPrompt, given
+	// the unified diff from the file on disk to the generated one. It returns
+	// any strategy but ConflictPrompt.
+	Ask func(path, diff string) (string, error)
+}
+
+// Conflicts is the policy of the generators for the files they create. Files
+// they own and regenerate (mocks, GraphQL schema, API contracts, OpenAPI
+// specification) and the lines they insert into existing files are not
+// conflicts. The CLI sets it from --on-conflict.
+var Conflicts = ConflictPolicy{Strategy: ConflictFail}
+
+// Validate reports an unknown strategy or a prompt nobody can answer
+func (p ConflictPolicy) Validate() error {
+	if err := ValidateOption("--on-conflict", p.Strategy, ConflictStrategies); err != nil {
+		return err
+	}
+	if p.Strategy == ConflictPrompt && p.Ask == nil {
+		return codedError(CodeUsage, "conflict.no_prompt")
+	}
+	return nil
+}
+
+// Conflict is an existing file a generator found in its way, with the
+// strategy applied to it
+type Conflict struct {
+	Path     string `json:"path"`
+	Strategy string `json:"strategy"`
+	// Copy is the .bak or .new file written next to it, if any
+	Copy string `json:"copy,omitempty"`
+}
+
+// Outcomes of writing a generated file
+const (
+	fileCreated     = "created"
+	fileOverwritten = "overwritten"
+	fileNew         = "new"
+	fileSkipped     = "skipped"
+	fileUnchanged   = "unchanged"
+)
+
+// write writes a generated file unless it exists with other content, in which
+// case the policy decides. It returns the outcome; the generated content is
+// on disk at file.path for created, overwritten and unchanged files.
+func (p ConflictPolicy) write(file generatedFile) (string, error) {
+	current, err := os.ReadFile(file.path)
+	switch {
+	case os.IsNotExist(err):
+		if err := EnsureDir(filepath.Dir(file.path)); err != nil {
+			return "", i18n.Error("err.mkdir", filepath.Dir(file.path), err)
+		}
+		if err := WriteFile(file.path, file.content); err != nil {
+			return "", i18n.Error("err.create", file.path, err)
+		}
+		return fileCreated, nil
+	case err != nil:
+		return "", i18n.Error("err.read", file.path, err)
+	case bytes.Equal(current, file.content):
+		return fileUnchanged, nil
+	}
+
+	strategy := p.Strategy
+	if strategy == ConflictPrompt {
+		diff := merge.Unified(filepath.ToSlash(file.path), filepath.ToSlash(file.path)+" (cleango)", current, file.content)
+		if strategy, err = p.Ask(file.path, diff); err != nil {
+			return "", err
+		}
+	}
+
+	conflict := Conflict{Path: reportPath(file.path), Strategy: strategy}
+	outcome := fileOverwritten
+	switch strategy {
+	case ConflictFail:
+		return "", codedError(CodeAlreadyExists, "conflict.exists", file.path)
+	case ConflictSkip:
+		outcome = fileSkipped
+		if activeReport != nil {
+			activeReport.Skip(file.path)
+		}
+	case ConflictNew:
+		outcome = fileNew
+		conflict.Copy = file.path + newSuffix
+		if err := WriteFile(conflict.Copy, file.content); err != nil {
+			return "", i18n.Error("err.write", conflict.Copy, err)
+		}
+	case ConflictBackup:
+		conflict.Copy = backupPath(file.path)
+		if err := WriteFile(conflict.Copy, current); err != nil {
+			return "", i18n.Error("err.write", conflict.Copy, err)
+		}
+		fallthrough
+	default:
+		if err := WriteFile(file.path, file.content); err != nil {
+			return "", i18n.Error("err.write", file.path, err)
+		}
+	}
+	if conflict.Copy != "" {
+		conflict.Copy = reportPath(conflict.Copy)
+	}
+	recordConflict(conflict)
+	return outcome, nil
+}
+
+// backupPath returns the first free backup name of path: path.bak, then
+// path.bak.1 and so on, so earlier backups are never lost
+func backupPath(path string) string {
+	backup := path + backupSuffix
+	for i := 1; FileExists(backup); i++ {
+		backup = path + backupSuffix + "." + strconv.Itoa(i)
+	}
+	return backup
+}
+
+// writeFiles writes generated files with the conflict policy, recording the
+// pristine copy of those now on disk, and returns the files it created
+func writeFiles(files []generatedFile) ([]string, error) {
+	var created []string
+	var pristine []generatedFile
+	for _, file := range files {
+		outcome, err := Conflicts.write(file)
+		if err != nil {
+			return created, err
+		}
+		switch outcome {
+		case fileCreated:
+			created = append(created, file.path)
+		case fileNew, fileSkipped:
+			continue
+		}
+		pristine = append(pristine, file)
+	}
+	if err := recordPristine(pristine); err != nil {
+		return created, i18n.Error("upgrade.record", err)
+	}
+	return created, nil
+}
+
+// writeComponent writes a file created by a component generator with the
+// conflict policy, recording its pristine copy when written
+func writeComponent(path string, content []byte) error {
+	_, err := writeFiles([]generatedFile{{path, content}})
+	return err
+}
@@ -0,0 +1,209 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// dependencyVersions pins the modules imported by generated code to the
+// versions the templates are tested with, so every project resolves the same
+// dependency graph
+var dependencyVersions = map[string]string{
+	"go.uber.org/zap":                     "v1.27.0",
+	"github.com/go-chi/chi/v5":            "v5.2.1",
+	"github.com/gin-gonic/gin":            "v1.10.0",
+	"github.com/gofiber/fiber/v2":         "v2.52.5",
+	"github.com/jackc/pgx/v5":             "v5.7.1",
+	"github.com/go-sql-driver/mysql":      "v1.8.1",
+	"go.mongodb.org/mongo-driver":         "v1.17.6",
+	"github.com/godror/godror":            "v0.44.0",
+	"github.com/redis/go-redis/v9":        "v9.7.0",
+	"github.com/segmentio/kafka-go":       "v0.4.47",
+	"google.golang.org/grpc":              "v1.67.1",
+	"google.golang.org/protobuf":          "v1.35.1",
+	"github.com/graph-gophers/graphql-go": "v1.5.0",
+}
+
+// DependencyOptions control how 'cleango new' resolves the dependencies
+// pinned in go.mod
+type DependencyOptions struct {
+	// Skip leaves go.mod with the pinned requirements without running any go
+	// command that may reach the network
+	Skip bool
+	// Offline resolves from the module cache only
+	Offline bool
+	// GoProxy is the module proxy to use: a URL or a local directory laid out
+	// as a proxy
+	GoProxy string
+	// Vendor copies the dependencies to vendor/ once resolved
+	Vendor bool
+	// Strict fails instead of warning when the dependencies cannot be resolved
+	Strict bool
+}
+
+// Validate reports incompatible options
+func (o DependencyOptions) Validate() error {
+	switch {
+	case o.Skip && (o.Offline || o.GoProxy != "" || o.Vendor):
+		return i18n.Error("deps.skip.combined")
+	case o.Offline && o.GoProxy != "":
+		return i18n.Error("deps.offline.combined")
+	}
+	return nil
+}
+
+// env returns the environment of the go commands resolving dependencies
+func (o DependencyOptions) env() ([]string, error) {
+	env := os.Environ()
+	switch {
+	case o.Offline:
+		env = append(env, "GOPROXY=off", "GOFLAGS=-mod=mod")
+	case o.GoProxy != "":
+		proxy := o.GoProxy
+		if info, err := os.Stat(proxy); err == nil && info.IsDir() {
+			abs, err := filepath.Abs(proxy)
+			if err != nil {
+				return nil, err
+			}
+			proxy = "file://" + filepath.ToSlash(abs)
+		}
+		env = append(env, "GOPROXY="+proxy)
+	}
+	return env, nil
+}
+
+// dependencyModule returns the module providing the package pkg and its
+// pinned version
+func dependencyModule(pkg string) (module, version string) {
+	for candidate, pinned := range dependencyVersions {
+		if (pkg == candidate || strings.HasPrefix(pkg, candidate+"/")) && len(candidate) > len(module) {
+			module, version = candidate, pinned
+		}
+	}
+	if module == "" {
+		return pkg, ""
+	}
+	return module, version
+}
+
+// pinnedModules returns the modules providing pkgs at their pinned version,
+// as module@version, sorted and without duplicates
+func pinnedModules(pkgs []string) []string {
+	seen := map[string]bool{}
+	var modules []string
+	for _, pkg := range pkgs {
+		module, version := dependencyModule(pkg)
+		if version != "" {
+			module += "@" + version
+		}
+		if !seen[module] {
+			seen[module] = true
+			modules = append(modules, module)
+		}
+	}
+	sort.Strings(modules)
+	return modules
+}
+
+// renderGoMod renders the go.mod of a new project requiring the pinned
+// dependencies of config
+func renderGoMod(config ProjectConfig) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "module %s\n\ngo %s\n", config.ModulePath, minGoVersion)
+
+	modules := pinnedModules(config.GetDependencies())
+	if len(modules) > 0 {
+		b.WriteString("\nrequire (\n")
+		for _, module := range modules {
+			fmt.Fprintf(&b, "\t%s\n", strings.Replace(module, "@", " ", 1))
+		}
+		b.WriteString(")\n")
+	}
+	return []byte(b.String())
+}
+
+// requireDependencies adds the pinned requirements of config to an existing
+// go.mod, without touching the network. Modules already required keep their
+// version.
+func requireDependencies(config ProjectConfig) error {
+	mod, err := readGoMod()
+	if err != nil {
+		return err
+	}
+	args := []string{"mod", "edit"}
+	for _, module := range pinnedModules(config.GetDependencies()) {
+		if _, ok := mod.Requires[strings.SplitN(module, "@", 2)[0]]; !ok {
+			args = append(args, "-require="+module)
+		}
+	}
+	if len(args) == 2 {
+		return nil
+	}
+	return runGo(args...)
+}
+
+// resolveDependencies completes go.mod and go.sum with the transitive
+// dependencies of the pinned requirements, as configured by opts
+func resolveDependencies(opts DependencyOptions) error {
+	if opts.Skip {
+		fmt.Fprintln(Output, i18n.T("deps.skipped"))
+		return nil
+	}
+
+	env, err := opts.env()
+	if err != nil {
+		return err
+	}
+	steps := [][]string{{"mod", "tidy"}}
+	if opts.Offline {
+		// Caches rarely hold the test-only dependencies of dependencies, which
+		// tidy insists on: tolerate them and check that the build imports resolve
+		steps = [][]string{{"mod", "tidy", "-e"}, {"list", "-deps", "./..."}}
+	}
+	if opts.Vendor {
+		steps = append(steps, []string{"mod", "vendor"})
+	}
+
+	switch {
+	case opts.Offline:
+		fmt.Fprintln(Output, i18n.T("deps.resolving.offline"))
+	case opts.GoProxy != "":
+		fmt.Fprintln(Output, i18n.T("deps.resolving.proxy", opts.GoProxy))
+	default:
+		fmt.Fprintln(Output, i18n.T("deps.resolving"))
+	}
+	// The go commands rewrite go.mod and go.sum
+	journalModule()
+	sumExisted := FileExists("go.sum")
+	defer func() {
+		recordWrite("go.mod", true)
+		if FileExists("go.sum") {
+			recordWrite("go.sum", sumExisted)
+		}
+	}()
+
+	for _, args := range steps {
+		cmd := exec.Command("go", args...)
+		cmd.Env = env
+		if args[0] != "list" {
+			cmd.Stdout = Output
+		}
+		cmd.Stderr = os.Stderr
+		if err := runCommand(cmd); err != nil {
+			if opts.Strict {
+				return codedError(CodeDependencies, "deps.failed", strings.Join(args, " "), err)
+			}
+			fmt.Fprintln(Output, i18n.T("deps.warning", strings.Join(args, " "), err))
+			fmt.Fprintln(Output, i18n.T("deps.pinned"))
+			recordWarning(i18n.T("deps.warning.record", strings.Join(args, " "), err))
+			return nil
+		}
+	}
+	return nil
+}
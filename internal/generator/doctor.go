@@ -0,0 +1,582 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// Status of a doctor check
+const (
+	DoctorOK      = "ok"
+	DoctorWarning = "warning"
+	DoctorError   = "error"
+)
+
+// minGoVersion is the oldest Go release able to build generated projects:
+// the HTTP routes use the method patterns of Go 1.22's ServeMux
+const minGoVersion = "1.22"
+
+// DoctorCheck is the result of checking one aspect of the project. Hint
+// explains how to solve a problem; checks with a fix can apply it.
+type DoctorCheck struct {
+	Name    string
+	Status  string
+	Message string
+	Hint    string
+	fix     func() error
+}
+
+// Fixable reports whether the problem can be solved with Fix
+func (c *DoctorCheck) Fixable() bool {
+	return c.fix != nil
+}
+
+// Fix applies the safe fix of the check
+func (c *DoctorCheck) Fix() error {
+	if c.fix == nil {
+		return i18n.Error("doctor.not_fixable", c.Name)
+	}
+	return c.fix()
+}
+
+// Doctor checks the project in the current directory against its recorded
+// configuration: toolchain, manifest, directories, insertion markers,
+// dependencies, environment files and generated files, regenerable or
+// edited by hand
+func Doctor() ([]*DoctorCheck, error) {
+	mod, err := readGoMod()
+	if err != nil {
+		return nil, err
+	}
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := LoadManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	checks := []*DoctorCheck{
+		checkGoToolchain(mod),
+		checkManifest(manifest),
+		checkDirectories(),
+		checkMarkers(manifest),
+		checkDependencies(config, manifest, mod),
+	}
+	if manifest.Pack != nil {
+		checks = append(checks, checkPack(manifest.Pack))
+	}
+	checks = append(checks, checkEnvFiles(config, manifest)...)
+	checks = append(checks, checkGeneratedFiles(config, manifest)...)
+	return checks, nil
+}
+
+// checkPack verifies that the template pack of the project is installed in
+// the version it was generated with
+func checkPack(ref *PackRef) *DoctorCheck {
+	check := &DoctorCheck{Name: "pack " + ref.Name}
+
+	pack, err := LoadPack(ref.Name)
+	if err != nil {
+		check.Status = DoctorError
+		check.Message = err.Error()
+		check.Hint = i18n.T("doctor.pack.install", ref.Version)
+		return check
+	}
+	if pack.Version != ref.Version {
+		check.Status = DoctorWarning
+		check.Message = i18n.T("doctor.pack.version", pack.Version, ref.Version)
+		check.Hint = i18n.T("doctor.pack.upgrade")
+		return check
+	}
+
+	check.Status = DoctorOK
+	check.Message = i18n.T("doctor.pack.ok", pack.Version)
+	return check
+}
+
+// checkGoToolchain compares the installed Go with go.mod and with the
+// version generated projects need
+func checkGoToolchain(mod *goMod) *DoctorCheck {
+	check := &DoctorCheck{Name: "Go"}
+
+	output, err := exec.Command("go", "env", "GOVERSION").Output()
+	if err != nil {
+		check.Status = DoctorError
+		check.Message = i18n.T("doctor.go.missing")
+		check.Hint = i18n.T("doctor.go.install", minGoVersion)
+		return check
+	}
+	installed := strings.TrimPrefix(strings.TrimSpace(string(output)), "go")
+
+	required := minGoVersion
+	if mod.Go != "" && compareVersions(mod.Go, required) > 0 {
+		required = mod.Go
+	}
+	if compareVersions(installed, required) < 0 {
+		check.Status = DoctorError
+		check.Message = i18n.T("doctor.go.old", installed, required)
+		check.Hint = i18n.T("doctor.go.update", required)
+		return check
+	}
+	if mod.Go != "" && compareVersions(mod.Go, minGoVersion) < 0 {
+		check.Status = DoctorWarning
+		check.Message = i18n.T("doctor.go.mod", mod.Go, minGoVersion)
+		check.Hint = i18n.T("doctor.run", "go mod edit -go="+minGoVersion)
+		check.fix = func() error {
+			return runGo("mod", "edit", "-go="+minGoVersion)
+		}
+		return check
+	}
+
+	check.Status = DoctorOK
+	check.Message = fmt.Sprintf("Go %s (go.mod: %s)", installed, mod.Go)
+	return check
+}
+
+// checkManifest verifies that cleango.yaml exists and is consistent
+func checkManifest(manifest *Manifest) *DoctorCheck {
+	check := &DoctorCheck{Name: ManifestFile}
+
+	if !FileExists(ManifestFile) {
+		check.Status = DoctorWarning
+		check.Message = i18n.T("doctor.manifest.missing")
+		check.Hint = i18n.T("doctor.manifest.save")
+		check.fix = manifest.Save
+		return check
+	}
+
+	if _, ok := frameworkModules[manifest.Framework]; !ok && manifest.Framework != "nethttp" {
+		check.Status = DoctorError
+		check.Message = i18n.T("doctor.manifest.framework", manifest.Framework)
+		check.Hint = i18n.T("doctor.manifest.use", strings.Join(Frameworks, ", "), ManifestFile)
+		return check
+	}
+	if _, ok := databaseModules[manifest.Database]; !ok && manifest.Database != "none" {
+		check.Status = DoctorError
+		check.Message = i18n.T("doctor.manifest.database", manifest.Database)
+		check.Hint = i18n.T("doctor.manifest.use", strings.Join(Databases, ", "), ManifestFile)
+		return check
+	}
+
+	var missing []string
+	for _, entrypoint := range manifest.Entrypoints {
+		if dir, ok := entrypointDirs[entrypoint]; ok && !FileExists(dir) {
+			missing = append(missing, entrypoint)
+		}
+	}
+	if len(missing) > 0 {
+		check.Status = DoctorWarning
+		check.Message = i18n.T("doctor.manifest.entrypoints", strings.Join(missing, ", "))
+		check.Hint = i18n.T("doctor.manifest.regenerate", ManifestFile)
+		return check
+	}
+
+	check.Status = DoctorOK
+	check.Message = fmt.Sprintf("%s, %s, entrypoints: %s", manifest.Framework, manifest.Database, strings.Join(manifest.Entrypoints, ", "))
+	return check
+}
+
+// checkDirectories verifies the Clean Architecture layout
+func checkDirectories() *DoctorCheck {
+	check := &DoctorCheck{Name: i18n.T("doctor.structure")}
+
+	var missing []string
+	for _, dir := range projectDirs {
+		if !FileExists(dir) {
+			missing = append(missing, dir)
+		}
+	}
+	if len(missing) == 0 {
+		check.Status = DoctorOK
+		check.Message = i18n.T("doctor.structure.ok")
+		return check
+	}
+
+	check.Status = DoctorWarning
+	check.Message = i18n.T("doctor.structure.missing", strings.Join(missing, ", "))
+	check.Hint = i18n.T("doctor.structure.fix")
+	check.fix = func() error {
+		for _, dir := range missing {
+			if err := EnsureDir(dir); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return check
+}
+
+// checkMarkers verifies the comments 'cleango add' inserts code before
+func checkMarkers(manifest *Manifest) *DoctorCheck {
+	check := &DoctorCheck{Name: i18n.T("doctor.markers")}
+
+	markers := []struct {
+		path   string
+		marker string
+	}{
+		{filepath.Join("infrastructure/entrypoints/http", "routes.go"), routesMarker},
+		{filepath.Join("cmd/api", "main.go"), serversMarker},
+	}
+	if manifest.HasEntrypoint("grpc") {
+		markers = append(markers, struct {
+			path   string
+			marker string
+		}{filepath.Join(grpcDir, "services.go"), servicesMarker})
+	}
+
+	var missing []string
+	for _, m := range markers {
+		content, err := os.ReadFile(m.path)
+		if err != nil || !bytes.Contains(content, []byte(m.marker)) {
+			missing = append(missing, i18n.T("doctor.markers.at", m.marker, m.path))
+		}
+	}
+	if len(missing) == 0 {
+		check.Status = DoctorOK
+		check.Message = i18n.T("doctor.markers.ok")
+		return check
+	}
+
+	check.Status = DoctorWarning
+	check.Message = i18n.T("doctor.markers.missing", strings.Join(missing, "; "))
+	check.Hint = i18n.T("doctor.markers.fix")
+	return check
+}
+
+// checkDependencies verifies that go.mod requires the modules of the
+// selected framework, database, extras and entrypoints
+func checkDependencies(config ProjectConfig, manifest *Manifest, mod *goMod) *DoctorCheck {
+	check := &DoctorCheck{Name: i18n.T("doctor.dependencies")}
+
+	deps := config.GetDependencies()
+	if manifest.HasEntrypoint("grpc") {
+		deps = append(deps, grpcDependencies...)
+	}
+	if manifest.HasEntrypoint("graphql") {
+		deps = append(deps, graphqlModule)
+	}
+
+	var missing []string
+	for _, dep := range deps {
+		if !mod.Provides(dep) {
+			missing = append(missing, dep)
+		}
+	}
+	if len(missing) == 0 {
+		check.Status = DoctorOK
+		check.Message = i18n.T("doctor.dependencies.ok", len(deps))
+		return check
+	}
+
+	check.Status = DoctorError
+	check.Message = i18n.T("doctor.dependencies.missing", strings.Join(missing, ", "))
+	pinned := pinnedModules(missing)
+	check.Hint = i18n.T("doctor.run", "go get "+strings.Join(pinned, " "))
+	check.fix = func() error {
+		return runGo(append([]string{"get"}, pinned...)...)
+	}
+	return check
+}
+
+// checkEnvFiles verifies that .env.example declares the variables of the
+// selected database and entrypoints, and that .env declares every variable
+// of .env.example
+func checkEnvFiles(config ProjectConfig, manifest *Manifest) []*DoctorCheck {
+	example := &DoctorCheck{Name: ".env.example"}
+
+	expected, err := renderEnvExample(config)
+	if err != nil {
+		example.Status = DoctorError
+		example.Message = err.Error()
+		return []*DoctorCheck{example}
+	}
+	expectedLines := envLines(expected)
+	if manifest.HasEntrypoint("grpc") {
+		expectedLines["GRPC_PORT"] = "GRPC_PORT=9090"
+	}
+
+	current, err := os.ReadFile(".env.example")
+	if err != nil && !os.IsNotExist(err) {
+		example.Status = DoctorError
+		example.Message = err.Error()
+		return []*DoctorCheck{example}
+	}
+	missing := missingEnvLines(expectedLines, envLines(current))
+	if len(missing) == 0 {
+		example.Status = DoctorOK
+		example.Message = i18n.T("doctor.env_example.ok")
+	} else {
+		example.Status = DoctorWarning
+		example.Message = i18n.T("doctor.env_example.missing", strings.Join(envKeys(missing), ", "))
+		example.Hint = i18n.T("doctor.env_example.fix")
+		example.fix = func() error {
+			return appendEnvLines(".env.example", missing)
+		}
+	}
+
+	env := &DoctorCheck{Name: ".env"}
+	exampleLines := envLines(current)
+	for _, line := range missing {
+		exampleLines[envKey(line)] = line
+	}
+	content, err := os.ReadFile(".env")
+	switch {
+	case os.IsNotExist(err):
+		env.Status = DoctorWarning
+		env.Message = i18n.T("doctor.env.missing")
+		env.Hint = i18n.T("doctor.env.create")
+		env.fix = func() error {
+			return appendEnvLines(".env", sortedEnvLines(exampleLines))
+		}
+	case err != nil:
+		env.Status = DoctorError
+		env.Message = err.Error()
+	default:
+		if missing := missingEnvLines(exampleLines, envLines(content)); len(missing) > 0 {
+			env.Status = DoctorWarning
+			env.Message = i18n.T("doctor.env.vars", strings.Join(envKeys(missing), ", "))
+			env.Hint = i18n.T("doctor.env.fix")
+			env.fix = func() error {
+				return appendEnvLines(".env", missing)
+			}
+		} else {
+			env.Status = DoctorOK
+			env.Message = i18n.T("doctor.env.ok")
+		}
+	}
+
+	return []*DoctorCheck{example, env}
+}
+
+// checkGeneratedFiles compares the files cleango regenerates (GraphQL schema
+// and resolvers, mocks) with what the generators produce now, reporting
+// hand edits and drift
+func checkGeneratedFiles(config ProjectConfig, manifest *Manifest) []*DoctorCheck {
+	var checks []*DoctorCheck
+
+	if manifest.HasEntrypoint("graphql") {
+		check := &DoctorCheck{Name: "GraphQL"}
+		files, err := renderGraphQLSchema(config)
+		switch outdated := outdatedFiles(files); {
+		case err != nil:
+			check.Status = DoctorError
+			check.Message = err.Error()
+		case len(outdated) > 0:
+			check.Status = DoctorWarning
+			check.Message = i18n.T("doctor.graphql.outdated", strings.Join(filePaths(outdated), ", "))
+			check.Hint = i18n.T("doctor.graphql.fix")
+			check.fix = RefreshGraphQL
+		default:
+			check.Status = DoctorOK
+			check.Message = i18n.T("doctor.graphql.ok")
+		}
+		checks = append(checks, check)
+	}
+
+	if manifest.Mocks || FileExists(mocksDir) {
+		check := &DoctorCheck{Name: "Mocks"}
+		files, err := renderMocks(config.ModulePath, &MocksResult{})
+		var stale []string
+		if err == nil {
+			stale, err = staleMocks(files)
+		}
+		switch outdated := filePaths(outdatedFiles(files)); {
+		case err != nil:
+			check.Status = DoctorError
+			check.Message = err.Error()
+		case len(outdated) > 0 || len(stale) > 0:
+			check.Status = DoctorWarning
+			check.Message = i18n.T("doctor.mocks.outdated", strings.Join(append(outdated, stale...), ", "))
+			check.Hint = i18n.T("doctor.run", "cleango mocks")
+			check.fix = func() error {
+				_, err := GenerateMocks()
+				return err
+			}
+		default:
+			check.Status = DoctorOK
+			check.Message = i18n.T("doctor.mocks.ok")
+		}
+		checks = append(checks, check)
+	}
+
+	if FileExists(pristineDir) {
+		checks = append(checks, checkModifiedFiles())
+	}
+	return checks
+}
+
+// checkModifiedFiles compares the files with a pristine copy, those created
+// by 'cleango new' and 'cleango add', with the content they were generated
+// with, leaving out the lines the generators inserted since. Files removed
+// since are left out too.
+func checkModifiedFiles() *DoctorCheck {
+	check := &DoctorCheck{Name: i18n.T("doctor.modified")}
+	var recorded, modified []string
+	err := filepath.WalkDir(pristineDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(pristineDir, path)
+		if err != nil {
+			return err
+		}
+		if !FileExists(rel) {
+			return nil
+		}
+		recorded = append(recorded, rel)
+		edited, err := isEditedByHand(rel)
+		if err != nil {
+			return err
+		}
+		if edited {
+			modified = append(modified, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+
+	switch {
+	case err != nil:
+		check.Status = DoctorError
+		check.Message = err.Error()
+	case len(modified) > 0:
+		check.Status = DoctorWarning
+		check.Message = i18n.T("doctor.modified.files", strings.Join(modified, ", "))
+		check.Hint = i18n.T("doctor.modified.fix")
+	default:
+		check.Status = DoctorOK
+		check.Message = i18n.T("doctor.modified.ok", len(recorded))
+	}
+	return check
+}
+
+// filePaths returns the paths of files
+func filePaths(files []generatedFile) []string {
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = file.path
+	}
+	return paths
+}
+
+// envLines indexes the KEY=value lines of an env file by key
+func envLines(content []byte) map[string]string {
+	lines := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || !strings.Contains(line, "=") {
+			continue
+		}
+		lines[envKey(line)] = line
+	}
+	return lines
+}
+
+// envKey returns the variable name of a KEY=value line
+func envKey(line string) string {
+	key := strings.TrimSpace(strings.SplitN(line, "=", 2)[0])
+	return strings.TrimSpace(strings.TrimPrefix(key, "export "))
+}
+
+// missingEnvLines returns the lines of expected whose key is not in
+// current, ordered by key
+func missingEnvLines(expected, current map[string]string) []string {
+	missing := map[string]string{}
+	for key, line := range expected {
+		if _, ok := current[key]; !ok {
+			missing[key] = line
+		}
+	}
+	return sortedEnvLines(missing)
+}
+
+// sortedEnvLines returns the lines ordered by key
+func sortedEnvLines(lines map[string]string) []string {
+	keys := make([]string, 0, len(lines))
+	for key := range lines {
+		keys = append(keys, key)
+	}
+	sorted := make([]string, 0, len(lines))
+	for _, key := range uniqueSorted(keys) {
+		sorted = append(sorted, lines[key])
+	}
+	return sorted
+}
+
+// envKeys returns the variable names of lines
+func envKeys(lines []string) []string {
+	keys := make([]string, len(lines))
+	for i, line := range lines {
+		keys[i] = envKey(line)
+	}
+	return keys
+}
+
+// appendEnvLines appends lines to an env file, creating it if needed
+func appendEnvLines(path string, lines []string) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+		content = append(content, '\n')
+	}
+	for _, line := range lines {
+		content = append(content, line+"\n"...)
+	}
+	return WriteFile(path, content)
+}
+
+// runGo runs a go command in the current directory
+func runGo(args ...string) error {
+	journalModule()
+	cmd := exec.Command("go", args...)
+	output, err := cmd.CombinedOutput()
+	recordCommand(cmd, err)
+	if err != nil {
+		return fmt.Errorf("go %s: %w\n%s", strings.Join(args, " "), err, output)
+	}
+	return nil
+}
+
+// compareVersions compares dotted Go versions such as 1.22 and 1.23.4,
+// ignoring pre-release suffixes
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(leadingDigits(as[i]))
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(leadingDigits(bs[i]))
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// leadingDigits returns the numeric prefix of s
+func leadingDigits(s string) string {
+	end := 0
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	return s[:end]
+}
@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// TestDoctorReportsModifiedFiles edits a generated use case: doctor reports
+// it against its pristine copy and suggests how to bring templates back. The
+// route registered by 'add handler' in routes.go is not an edit by hand.
+func TestDoctorReportsModifiedFiles(t *testing.T) {
+	testProject(t)
+	if err := GenerateUsecase("CreateClient", "", false); err != nil {
+		t.Fatalf("add usecase: %v", err)
+	}
+	if err := GenerateModel("Client", nil, false); err != nil {
+		t.Fatalf("add model: %v", err)
+	}
+	if err := GenerateHandler("Client", "", false); err != nil {
+		t.Fatalf("add handler: %v", err)
+	}
+
+	modifiedCheck := func() *DoctorCheck {
+		t.Helper()
+		checks, err := Doctor()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, check := range checks {
+			if check.Name == i18n.T("doctor.modified") {
+				return check
+			}
+		}
+		t.Fatal("doctor does not check the generated files")
+		return nil
+	}
+
+	if check := modifiedCheck(); check.Status != DoctorOK {
+		t.Errorf("fresh project reports %s: %s", check.Status, check.Message)
+	}
+
+	usecase := "domain/usecases/create_client.go"
+	content, err := os.ReadFile(usecase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(usecase, append(content, "\n// Edited by hand\n"...), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	check := modifiedCheck()
+	if check.Status != DoctorWarning || !strings.Contains(check.Message, usecase) || strings.Contains(check.Message, "routes.go") {
+		t.Errorf("edited use case reports %s: %s", check.Status, check.Message)
+	}
+	if !strings.Contains(check.Hint, "cleango upgrade") || !strings.Contains(check.Hint, "--force") {
+		t.Errorf("hint does not suggest upgrade or remove --force: %s", check.Hint)
+	}
+}
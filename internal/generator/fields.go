@@ -0,0 +1,484 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// FieldSpec describes a model field given as "name:type[:rule,rule...]"
+type FieldSpec struct {
+	Name  string
+	Type  string
+	Rules []FieldRule
+
+	// JSON overrides the JSON key derived from Name
+	JSON string
+	// Location is "path" or "query" for fields filled from the URL instead
+	// of the JSON body
+	Location string
+}
+
+// FieldRule is a validation rule such as "required" or "max=100"
+type FieldRule struct {
+	Name string
+	Arg  string
+}
+
+// fieldTypes maps the types accepted in field specs to Go types
+var fieldTypes = map[string]string{
+	"string":  "string",
+	"int":     "int",
+	"int64":   "int64",
+	"float":   "float64",
+	"float64": "float64",
+	"bool":    "bool",
+	"time":    "time.Time",
+}
+
+// baseModelFields are generated for every model and never taken from specs
+var baseModelFields = map[string]bool{
+	"ID":        true,
+	"CreatedAt": true,
+	"UpdatedAt": true,
+}
+
+// isBaseModelField reports whether a Go field name clashes with a base field,
+// ignoring case so that "id" and "ID" collide
+func isBaseModelField(goName string) bool {
+	for name := range baseModelFields {
+		if strings.EqualFold(name, goName) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseFieldSpecs parses field specs such as "email:string:required,email"
+func ParseFieldSpecs(args []string) ([]FieldSpec, error) {
+	var specs []FieldSpec
+	seen := map[string]bool{}
+
+	for _, arg := range args {
+		parts := strings.SplitN(arg, ":", 3)
+		if len(parts) < 2 || parts[0] == "" {
+			return nil, i18n.Error("fields.invalid", arg)
+		}
+
+		spec := FieldSpec{Name: parts[0], Type: parts[1]}
+		if err := validateWords(i18n.T("kind.field"), spec.Name); err != nil {
+			return nil, err
+		}
+		if _, ok := fieldTypes[spec.Type]; !ok {
+			return nil, i18n.Error("fields.type", spec.Type, spec.Name, strings.Join(supportedFieldTypes(), ", "))
+		}
+		if isBaseModelField(spec.GoName()) || seen[spec.GoName()] {
+			return nil, i18n.Error("fields.duplicated", spec.Name)
+		}
+		seen[spec.GoName()] = true
+
+		if len(parts) == 3 && parts[2] != "" {
+			rules, err := parseFieldRules(spec, parts[2])
+			if err != nil {
+				return nil, err
+			}
+			spec.Rules = rules
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
+
+// parseFieldRules parses a comma separated rule list for spec
+func parseFieldRules(spec FieldSpec, list string) ([]FieldRule, error) {
+	var rules []FieldRule
+	for _, raw := range strings.Split(list, ",") {
+		rule := FieldRule{Name: raw}
+		if i := strings.Index(raw, "="); i >= 0 {
+			rule = FieldRule{Name: raw[:i], Arg: raw[i+1:]}
+		}
+
+		switch rule.Name {
+		case "required":
+		case "min", "max":
+			if spec.Type == "bool" || spec.Type == "time" {
+				return nil, i18n.Error("fields.rule.type", rule.Name, spec.Name, spec.Type)
+			}
+			var err error
+			if spec.Type == "float" || spec.Type == "float64" {
+				_, err = strconv.ParseFloat(rule.Arg, 64)
+			} else {
+				_, err = strconv.Atoi(rule.Arg)
+			}
+			if err != nil {
+				return nil, i18n.Error("fields.rule.number", rule.Name, spec.Name, spec.Type)
+			}
+		case "email", "oneof":
+			if spec.Type != "string" {
+				return nil, i18n.Error("fields.rule.string", rule.Name, spec.Name)
+			}
+			if rule.Name == "oneof" && rule.Arg == "" {
+				return nil, i18n.Error("fields.rule.oneof", spec.Name)
+			}
+		default:
+			return nil, i18n.Error("fields.rule.unknown", rule.Name, spec.Name)
+		}
+
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func supportedFieldTypes() []string {
+	types := make([]string, 0, len(fieldTypes))
+	for t := range fieldTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// GoName returns the exported Go identifier of the field. Names read back
+// from Go sources are already exported identifiers and are kept as is.
+func (f FieldSpec) GoName() string {
+	if token.IsIdentifier(f.Name) && token.IsExported(f.Name) {
+		return f.Name
+	}
+	return ToPascalCase(f.Name)
+}
+
+// JSONName returns the JSON key of the field. It derives from the name as
+// written, since adjacent initialisms lose their boundary in the Go name
+// (api_url is APIURL).
+func (f FieldSpec) JSONName() string {
+	if f.JSON != "" {
+		return f.JSON
+	}
+	return ToSnakeCase(f.Name)
+}
+
+// GoType returns the Go type of the field
+func (f FieldSpec) GoType() string {
+	return fieldTypes[f.Type]
+}
+
+// Tag returns the struct tag of the field, keeping its rules in a validate
+// key so later generators can recover them from the model source
+func (f FieldSpec) Tag() string {
+	tag := fmt.Sprintf("json:%q", f.JSONName())
+	if f.Location != "" {
+		tag = fmt.Sprintf("json:\"-\" %s:%q", f.Location, f.JSONName())
+	}
+	if len(f.Rules) > 0 {
+		rules := make([]string, len(f.Rules))
+		for i, rule := range f.Rules {
+			rules[i] = rule.Name
+			if rule.Arg != "" {
+				rules[i] += "=" + rule.Arg
+			}
+		}
+		tag += fmt.Sprintf(" validate:%q", strings.Join(rules, ","))
+	}
+	return "`" + tag + "`"
+}
+
+// renderStructFields renders the struct field lines for specs
+func renderStructFields(specs []FieldSpec) string {
+	var b strings.Builder
+	for _, spec := range specs {
+		fmt.Fprintf(&b, "\t%s %s %s\n", spec.GoName(), spec.GoType(), spec.Tag())
+	}
+	return b.String()
+}
+
+// renderValidation renders the body of a Validate method checking the rules
+// of specs on receiver, together with the standard imports it needs
+func renderValidation(receiver, subject string, specs []FieldSpec) (string, []string) {
+	var b strings.Builder
+	imports := map[string]bool{}
+
+	add := func(cond, field, message string) {
+		fmt.Fprintf(&b, "\tif %s {\n", cond)
+		fmt.Fprintf(&b, "\t\tfields = append(fields, domainerrors.FieldError{Field: %q, Message: %q})\n", field, message)
+		b.WriteString("\t}\n")
+	}
+
+	for _, spec := range specs {
+		value := receiver + "." + spec.GoName()
+		for _, rule := range spec.Rules {
+			switch rule.Name {
+			case "required":
+				switch spec.Type {
+				case "string":
+					imports["strings"] = true
+					add(fmt.Sprintf("strings.TrimSpace(%s) == \"\"", value), spec.JSONName(), "is required")
+				case "time":
+					add(value+".IsZero()", spec.JSONName(), "is required")
+				case "bool":
+				default:
+					add(value+" == 0", spec.JSONName(), "is required")
+				}
+			case "min", "max":
+				op, word := "<", "at least"
+				if rule.Name == "max" {
+					op, word = ">", "at most"
+				}
+				if spec.Type == "string" {
+					imports["unicode/utf8"] = true
+					add(fmt.Sprintf("utf8.RuneCountInString(%s) %s %s", value, op, rule.Arg), spec.JSONName(), fmt.Sprintf("must be %s %s characters", word, rule.Arg))
+				} else {
+					add(fmt.Sprintf("%s %s %s", value, op, rule.Arg), spec.JSONName(), fmt.Sprintf("must be %s %s", word, rule.Arg))
+				}
+			case "email":
+				imports["net/mail"] = true
+				fmt.Fprintf(&b, "\tif %s != \"\" {\n", value)
+				fmt.Fprintf(&b, "\t\tif _, err := mail.ParseAddress(%s); err != nil {\n", value)
+				fmt.Fprintf(&b, "\t\t\tfields = append(fields, domainerrors.FieldError{Field: %q, Message: %q})\n", spec.JSONName(), "must be a valid email address")
+				b.WriteString("\t\t}\n\t}\n")
+			case "oneof":
+				options := strings.Split(rule.Arg, "|")
+				conds := []string{value + " != \"\""}
+				for _, option := range options {
+					conds = append(conds, fmt.Sprintf("%s != %q", value, option))
+				}
+				add(strings.Join(conds, " && "), spec.JSONName(), "must be one of "+strings.Join(options, ", "))
+			}
+		}
+	}
+
+	if b.Len() == 0 {
+		return "\treturn nil\n", nil
+	}
+
+	var body strings.Builder
+	body.WriteString("\tvar fields []domainerrors.FieldError\n")
+	body.WriteString(b.String())
+	body.WriteString("\tif len(fields) > 0 {\n")
+	fmt.Fprintf(&body, "\t\treturn domainerrors.Validation(%q, fields...)\n", "invalid "+subject)
+	body.WriteString("\t}\n\treturn nil\n")
+
+	list := make([]string, 0, len(imports))
+	for imp := range imports {
+		list = append(list, imp)
+	}
+	return body.String(), list
+}
+
+// renderImports renders an import block with one group per argument,
+// separated by blank lines. Plain paths are quoted; specs that already carry
+// quotes (e.g. aliased imports) are written as is.
+func renderImports(groups ...[]string) string {
+	var rendered []string
+	for _, group := range groups {
+		group = uniqueSorted(group)
+		if len(group) == 0 {
+			continue
+		}
+
+		var b strings.Builder
+		for _, imp := range group {
+			if !strings.Contains(imp, "\"") {
+				imp = strconv.Quote(imp)
+			}
+			b.WriteString("\t" + imp + "\n")
+		}
+		rendered = append(rendered, b.String())
+	}
+
+	return "import (\n" + strings.Join(rendered, "\n") + ")\n"
+}
+
+func uniqueSorted(values []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// listModels returns the names of the models in domain/models, sorted
+func listModels() ([]string, error) {
+	entries, err := os.ReadDir("domain/models")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var models []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		model := ToPascalCase(strings.TrimSuffix(entry.Name(), ".go"))
+		if _, err := LoadModelFields(model); err != nil {
+			continue
+		}
+		models = append(models, model)
+	}
+
+	sort.Strings(models)
+	return models, nil
+}
+
+// LoadModelFields recovers the field specs of a generated model by parsing
+// domain/models. It returns os.ErrNotExist when the model file is missing.
+func LoadModelFields(model string) ([]FieldSpec, error) {
+	filename := filepath.Join("domain/models", ToSnakeCase(model)+".go")
+	if !FileExists(filename) {
+		return nil, i18n.Error("fields.model.missing", filename, os.ErrNotExist)
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), filename, nil, 0)
+	if err != nil {
+		return nil, i18n.Error("err.read", filename, err)
+	}
+
+	typeName := ToPascalCase(model)
+	var specs []FieldSpec
+	found := false
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.TypeSpec)
+		if !ok || spec.Name.Name != typeName {
+			return true
+		}
+		st, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return false
+		}
+		found = true
+		specs = structFieldSpecs(st, true)
+		return false
+	})
+
+	if !found {
+		return nil, i18n.Error("fields.struct.missing", typeName, filename)
+	}
+	return specs, nil
+}
+
+// LoadUsecaseFields recovers the input and output field specs of a use case
+// by parsing domain/usecases, whether the DTOs live in the use case file or
+// in a contract generated from an OpenAPI spec
+func LoadUsecaseFields(usecase string) (input, output []FieldSpec, err error) {
+	name := ToPascalCase(usecase)
+	entries, err := os.ReadDir("domain/usecases")
+	if err != nil {
+		return nil, nil, i18n.Error("err.read.usecases", err)
+	}
+
+	fset := token.NewFileSet()
+	structs := map[string]*ast.StructType{}
+	hasInterface := false
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		filename := filepath.Join("domain/usecases", entry.Name())
+		file, err := parser.ParseFile(fset, filename, nil, 0)
+		if err != nil {
+			return nil, nil, i18n.Error("err.read", filename, err)
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			switch t := spec.Type.(type) {
+			case *ast.StructType:
+				structs[spec.Name.Name] = t
+			case *ast.InterfaceType:
+				hasInterface = hasInterface || spec.Name.Name == name+"UseCase"
+			}
+			return false
+		})
+	}
+
+	in, inOK := structs[name+"Input"]
+	out, outOK := structs[name+"Output"]
+	if !hasInterface || !inOK || !outOK {
+		return nil, nil, i18n.Error("fields.usecase.missing", name, name, name, name, os.ErrNotExist)
+	}
+	return structFieldSpecs(in, false), structFieldSpecs(out, false), nil
+}
+
+// structFieldSpecs maps the exported scalar fields of a struct to field
+// specs, recovering JSON names and validation rules from the struct tags.
+// Base model fields are left out when skipBase is set.
+func structFieldSpecs(st *ast.StructType, skipBase bool) []FieldSpec {
+	var specs []FieldSpec
+	for _, field := range st.Fields.List {
+		specType, ok := specTypeOf(field.Type)
+		if !ok {
+			continue
+		}
+
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			if unquoted, err := strconv.Unquote(field.Tag.Value); err == nil {
+				tag = reflect.StructTag(unquoted)
+			}
+		}
+
+		for _, name := range field.Names {
+			if !name.IsExported() || (skipBase && baseModelFields[name.Name]) {
+				continue
+			}
+
+			fieldSpec := FieldSpec{Name: name.Name, Type: specType}
+			if jsonName := strings.Split(tag.Get("json"), ",")[0]; jsonName != "" && jsonName != "-" {
+				fieldSpec.JSON = jsonName
+			}
+			for _, location := range []string{"path", "query"} {
+				if param := tag.Get(location); param != "" {
+					fieldSpec.JSON = param
+					fieldSpec.Location = location
+				}
+			}
+			if rules := tag.Get("validate"); rules != "" {
+				if parsed, err := parseFieldRules(fieldSpec, rules); err == nil {
+					fieldSpec.Rules = parsed
+				}
+			}
+			specs = append(specs, fieldSpec)
+		}
+	}
+	return specs
+}
+
+// specTypeOf maps a Go type expression back to a field spec type
+func specTypeOf(expr ast.Expr) (string, bool) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "float64":
+			return "float", true
+		case "string", "int", "int64", "bool":
+			return t.Name, true
+		}
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok && pkg.Name == "time" && t.Sel.Name == "Time" {
+			return "time", true
+		}
+	}
+	return "", false
+}
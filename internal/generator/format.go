@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// stdPackages maps the names of the standard library packages generated code
+// uses to their import paths, for the imports formatGo adds
+var stdPackages = map[string]string{
+	"bytes":    "bytes",
+	"context":  "context",
+	"sql":      "database/sql",
+	"embed":    "embed",
+	"json":     "encoding/json",
+	"errors":   "errors",
+	"fmt":      "fmt",
+	"io":       "io",
+	"log":      "log",
+	"slog":     "log/slog",
+	"math":     "math",
+	"http":     "net/http",
+	"httptest": "net/http/httptest",
+	"os":       "os",
+	"signal":   "os/signal",
+	"regexp":   "regexp",
+	"sort":     "sort",
+	"strconv":  "strconv",
+	"strings":  "strings",
+	"sync":     "sync",
+	"syscall":  "syscall",
+	"testing":  "testing",
+	"time":     "time",
+	"utf8":     "unicode/utf8",
+}
+
+// formatGo formats generated Go source as goimports does: imports no code
+// refers to are removed, missing standard library imports are added to the
+// first import group and the result is gofmt'ed. Blank and dot imports are
+// kept as they are.
+func formatGo(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	used := referencedPackages(file)
+
+	// Edits are applied from the end of the source, so offsets stay valid
+	type edit struct {
+		start, end int
+		text       string
+	}
+	var edits []edit
+	imported := map[string]bool{}
+	insertAt := -1
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+
+		var unused []ast.Spec
+		for _, spec := range gen.Specs {
+			name := specName(spec.(*ast.ImportSpec))
+			if name == "_" || name == "." || used[name] {
+				imported[name] = true
+				continue
+			}
+			unused = append(unused, spec)
+		}
+		if len(unused) == len(gen.Specs) {
+			start, end := lineRange(src, fset.Position(gen.Pos()).Offset, fset.Position(gen.End()).Offset)
+			edits = append(edits, edit{start, end, ""})
+			continue
+		}
+		for _, spec := range unused {
+			start, end := lineRange(src, fset.Position(spec.Pos()).Offset, fset.Position(spec.End()).Offset)
+			edits = append(edits, edit{start, end, ""})
+		}
+		if insertAt < 0 && gen.Lparen.IsValid() {
+			insertAt = fset.Position(gen.Lparen).Offset + 1
+		}
+	}
+
+	var missing []string
+	for name := range used {
+		if path, ok := stdPackages[name]; ok && !imported[name] {
+			missing = append(missing, strconv.Quote(path))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		if insertAt >= 0 {
+			edits = append(edits, edit{insertAt, insertAt, "\n\t" + strings.Join(missing, "\n\t")})
+		} else {
+			end := fset.Position(file.Name.End()).Offset
+			edits = append(edits, edit{end, end, "\n\nimport (\n\t" + strings.Join(missing, "\n\t") + "\n)"})
+		}
+	}
+
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	out := append([]byte(nil), src...)
+	for _, e := range edits {
+		out = append(out[:e.start], append([]byte(e.text), out[e.end:]...)...)
+	}
+	return format.Source(out)
+}
+
+// referencedPackages returns the names used as the package of a selector
+// that no declaration of the file resolves, as imported packages are
+func referencedPackages(file *ast.File) map[string]bool {
+	used := map[string]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok && id.Obj == nil {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+	return used
+}
+
+// specName returns the name an import is referred to by: its alias, or the
+// name assumed from its path
+func specName(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	path, err := strconv.Unquote(spec.Path.Value)
+	if err != nil {
+		return ""
+	}
+	return importName(path)
+}
+
+// lineRange widens the range from start to end to the whole lines holding it,
+// trailing comment and line break included
+func lineRange(src []byte, start, end int) (int, int) {
+	for start > 0 && src[start-1] != '\n' {
+		start--
+	}
+	for end < len(src) && src[end] != '\n' {
+		end++
+	}
+	if end < len(src) {
+		end++
+	}
+	return start, end
+}
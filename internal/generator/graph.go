@@ -0,0 +1,14 @@
+package generator
+
+import (
+	"github.com/YeridStick/cleango/internal/graph"
+)
+
+// Graph builds the component graph of the project in the current directory
+func Graph() (*graph.Graph, error) {
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+	return graph.Build(graph.Options{Dir: ".", ModulePath: config.ModulePath})
+}
@@ -0,0 +1,390 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// graphqlDir is the package holding the GraphQL schema and resolvers
+const graphqlDir = "infrastructure/entrypoints/graphql"
+
+// graphqlModule is the GraphQL server library used by generated projects
+const graphqlModule = "github.com/graph-gophers/graphql-go"
+
+// queryPrefixes mark use cases exposed as queries; the rest are mutations
+var queryPrefixes = []string{"Get", "List", "Find", "Search", "Count"}
+
+// gqlTypes maps field spec types to GraphQL types. GraphQL integers are 32
+// bits, so int64 fields are exposed as Float.
+var gqlTypes = map[string]string{
+	"string": "String",
+	"int":    "Int",
+	"int64":  "Float",
+	"float":  "Float",
+	"bool":   "Boolean",
+	"time":   "String",
+}
+
+// gqlGoTypes maps field spec types to the Go types graphql-go binds to
+var gqlGoTypes = map[string]string{
+	"string": "string",
+	"int":    "int32",
+	"int64":  "float64",
+	"float":  "float64",
+	"bool":   "bool",
+	"time":   "string",
+}
+
+// gqlField is a schema field together with its Go binding
+type gqlField struct {
+	Name       string
+	Type       string
+	GoName     string
+	ArgType    string
+	Assign     string
+	ResultType string
+	Resolve    string
+}
+
+// gqlModel is a domain model exposed as a schema type
+type gqlModel struct {
+	Name   string
+	Fields []gqlField
+}
+
+// gqlOperation is a use case exposed as a query or mutation
+type gqlOperation struct {
+	Name     string
+	Field    string
+	Mutation bool
+	NeedsErr bool
+	Input    []gqlField
+	Output   []gqlField
+}
+
+// GenerateGraphQL adds a GraphQL endpoint exposing the domain models and use
+// cases, mounts it on the HTTP router and records it in the manifest so that
+// later 'add' commands keep the schema up to date
+func GenerateGraphQL() (_ []string, err error) {
+	defer beginTransaction().end(&err)
+
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := EnsureDir(graphqlDir); err != nil {
+		return nil, i18n.Error("err.mkdir", graphqlDir, err)
+	}
+
+	var files []string
+	for _, file := range []struct {
+		path     string
+		template string
+	}{
+		{filepath.Join(graphqlDir, "server.go"), "graphqlServer"},
+		{filepath.Join(graphqlDir, "errors.go"), "graphqlErrors"},
+	} {
+		if FileExists(file.path) {
+			continue
+		}
+		content, err := renderGoTemplate(file.template, config)
+		if err != nil {
+			return nil, err
+		}
+		if err := WriteFile(file.path, content); err != nil {
+			return nil, i18n.Error("err.create", file.path, err)
+		}
+		files = append(files, file.path)
+	}
+
+	generated, err := writeGraphQLSchema(config)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, generated...)
+
+	if err := generateHTTPSupportFiles(config, false); err != nil {
+		return nil, err
+	}
+	if err := mountGraphQL(config); err != nil {
+		return nil, err
+	}
+	if err := registerEntrypoint("graphql"); err != nil {
+		return nil, err
+	}
+
+	installDependencies([]string{graphqlModule})
+	return files, nil
+}
+
+// RefreshGraphQL regenerates the GraphQL schema and resolvers when the
+// project manifest lists the graphql entrypoint
+func RefreshGraphQL() error {
+	manifest, err := LoadManifest()
+	if err != nil || !manifest.HasEntrypoint("graphql") {
+		return err
+	}
+
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return err
+	}
+	_, err = writeGraphQLSchema(config)
+	return err
+}
+
+// mountGraphQL serves the endpoint at /graphql from routes.go
+func mountGraphQL(config ProjectConfig) error {
+	routesPath := filepath.Join("infrastructure/entrypoints/http", "routes.go")
+
+	var line string
+	switch config.Framework {
+	case "chi":
+		line = `r.Handle("/graphql", graphqlentry.Handler())`
+	case "gin":
+		line = `r.POST("/graphql", gin.WrapH(graphqlentry.Handler()))`
+	case "fiber":
+		line = `r.Post("/graphql", adaptor.HTTPHandler(graphqlentry.Handler()))`
+		if err := AddImport(routesPath, "", "github.com/gofiber/fiber/v2/middleware/adaptor"); err != nil {
+			return err
+		}
+	default:
+		line = `r.Handle("POST /graphql", graphqlentry.Handler())`
+	}
+
+	if err := InsertBeforeMarker(routesPath, routesMarker, line); err != nil {
+		return err
+	}
+	return AddImport(routesPath, "graphqlentry", config.ModulePath+"/"+graphqlDir)
+}
+
+// writeGraphQLSchema renders schema.graphql and resolvers.go from the
+// current models and use cases, returning the files it changed
+func writeGraphQLSchema(config ProjectConfig) ([]string, error) {
+	files, err := renderGraphQLSchema(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, file := range outdatedFiles(files) {
+		if err := WriteFile(file.path, file.content); err != nil {
+			return nil, err
+		}
+		changed = append(changed, file.path)
+	}
+	return changed, nil
+}
+
+// renderGraphQLSchema renders schema.graphql and resolvers.go without
+// writing them
+func renderGraphQLSchema(config ProjectConfig) ([]generatedFile, error) {
+	models, err := graphqlModels()
+	if err != nil {
+		return nil, err
+	}
+	operations, err := graphqlOperations()
+	if err != nil {
+		return nil, err
+	}
+
+	hasMutations := false
+	usesTime := false
+	for _, op := range operations {
+		hasMutations = hasMutations || op.Mutation
+		for _, field := range op.Output {
+			usesTime = usesTime || strings.Contains(field.Resolve, "time.RFC3339")
+		}
+	}
+	if len(models) > 0 {
+		// Every model has CreatedAt and UpdatedAt
+		usesTime = true
+	}
+
+	var std, external, local []string
+	if len(operations) > 0 {
+		std = append(std, "context")
+		local = append(local, config.ModulePath+"/domain/usecases")
+	}
+	if usesTime {
+		std = append(std, "time")
+	}
+	if len(models) > 0 {
+		external = append(external, fmt.Sprintf("graphqlgo %q", graphqlModule))
+		local = append(local, config.ModulePath+"/domain/models")
+	}
+
+	data := map[string]interface{}{
+		"Models":       models,
+		"Operations":   operations,
+		"HasMutations": hasMutations,
+		"Imports":      renderImports(std, external, local),
+	}
+
+	schema, err := renderTemplate("graphqlSchema", data)
+	if err != nil {
+		return nil, err
+	}
+	resolvers, err := renderGoTemplate("graphqlResolvers", data)
+	if err != nil {
+		return nil, err
+	}
+
+	return []generatedFile{
+		{filepath.Join(graphqlDir, "schema.graphql"), schema},
+		{filepath.Join(graphqlDir, "resolvers.go"), resolvers},
+	}, nil
+}
+
+// graphqlModels maps every model in domain/models to a schema type
+func graphqlModels() ([]gqlModel, error) {
+	entries, err := os.ReadDir("domain/models")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var models []gqlModel
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".go")
+		specs, err := LoadModelFields(name)
+		if err != nil {
+			continue
+		}
+
+		model := gqlModel{
+			Name: ToPascalCase(name),
+			Fields: []gqlField{
+				{Name: "id", Type: "ID!", GoName: "ID", ResultType: "graphqlgo.ID", Resolve: "graphqlgo.ID(r.m.ID)"},
+			},
+		}
+		for _, base := range []string{"CreatedAt", "UpdatedAt"} {
+			model.Fields = append(model.Fields, gqlOutputField(FieldSpec{Name: base, Type: "time"}, "r.m"))
+		}
+		for _, spec := range specs {
+			model.Fields = append(model.Fields, gqlOutputField(spec, "r.m"))
+		}
+		models = append(models, model)
+	}
+
+	sort.Slice(models, func(i, j int) bool { return models[i].Name < models[j].Name })
+	return models, nil
+}
+
+// graphqlOperations maps every use case to a query or mutation
+func graphqlOperations() ([]gqlOperation, error) {
+	usecases, err := listUsecases()
+	if err != nil {
+		return nil, err
+	}
+
+	operations := make([]gqlOperation, 0, len(usecases))
+	for _, usecase := range usecases {
+		input, output, err := LoadUsecaseFields(usecase)
+		if err != nil {
+			return nil, err
+		}
+
+		op := gqlOperation{
+			Name:     usecase,
+			Field:    ToCamelCase(usecase),
+			Mutation: true,
+		}
+		for _, prefix := range queryPrefixes {
+			if strings.HasPrefix(usecase, prefix) {
+				op.Mutation = false
+			}
+		}
+
+		failure := "nil"
+		if len(output) == 0 {
+			failure = "false"
+		}
+		for _, spec := range input {
+			field := gqlInputField(spec, failure)
+			op.NeedsErr = op.NeedsErr || spec.Type == "time"
+			op.Input = append(op.Input, field)
+		}
+		for _, spec := range output {
+			op.Output = append(op.Output, gqlOutputField(spec, "r.out"))
+		}
+		operations = append(operations, op)
+	}
+	return operations, nil
+}
+
+// gqlInputField binds an input field, converting the GraphQL argument into
+// the use case input. failure is the zero result returned on parse errors.
+func gqlInputField(spec FieldSpec, failure string) gqlField {
+	required := false
+	for _, rule := range spec.Rules {
+		required = required || rule.Name == "required"
+	}
+
+	goName := spec.GoName()
+	field := gqlField{
+		Name:    ToCamelCase(goName),
+		Type:    gqlTypes[spec.Type],
+		GoName:  goName,
+		ArgType: gqlGoTypes[spec.Type],
+	}
+
+	value := "args.Input." + goName
+	if required {
+		field.Type += "!"
+	} else {
+		field.ArgType = "*" + field.ArgType
+		value = "*v"
+	}
+
+	var assign string
+	switch spec.Type {
+	case "time":
+		assign = fmt.Sprintf("if input.%s, err = parseTime(%q, %s); err != nil {\n\treturn %s, toGraphQLError(err)\n}", goName, field.Name, value, failure)
+	case "int":
+		assign = fmt.Sprintf("input.%s = int(%s)", goName, value)
+	case "int64":
+		assign = fmt.Sprintf("input.%s = int64(%s)", goName, value)
+	default:
+		assign = fmt.Sprintf("input.%s = %s", goName, value)
+	}
+	if !required {
+		assign = fmt.Sprintf("if v := args.Input.%s; v != nil {\n%s\n}", goName, assign)
+	}
+	field.Assign = assign
+	return field
+}
+
+// gqlOutputField binds a field resolved from the struct held by receiver
+func gqlOutputField(spec FieldSpec, receiver string) gqlField {
+	goName := spec.GoName()
+	value := receiver + "." + goName
+
+	field := gqlField{
+		Name:       ToCamelCase(goName),
+		Type:       gqlTypes[spec.Type] + "!",
+		GoName:     goName,
+		ResultType: gqlGoTypes[spec.Type],
+		Resolve:    value,
+	}
+	switch spec.Type {
+	case "time":
+		field.Resolve = value + ".Format(time.RFC3339)"
+	case "int":
+		field.Resolve = "int32(" + value + ")"
+	case "int64":
+		field.Resolve = "float64(" + value + ")"
+	}
+	return field
+}
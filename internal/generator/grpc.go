@@ -0,0 +1,424 @@
+package generator
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// grpcDir is the package holding the gRPC server and services
+const grpcDir = "infrastructure/entrypoints/grpc"
+
+// servicesMarker marks where generated services are registered
+const servicesMarker = "// cleango:services"
+
+// serversMarker marks where main adds entrypoints besides HTTP
+const serversMarker = "// cleango:servers"
+
+// grpcDependencies are the modules required by the generated gRPC code
+var grpcDependencies = []string{
+	"google.golang.org/grpc",
+	"google.golang.org/protobuf",
+}
+
+// protocPlugins are the binaries protoc needs to generate Go code
+var protocPlugins = map[string]string{
+	"protoc-gen-go":      "go install google.golang.org/protobuf/cmd/protoc-gen-go@latest",
+	"protoc-gen-go-grpc": "go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@latest",
+}
+
+// GRPCResult reports the outcome of GenerateGRPC
+type GRPCResult struct {
+	Files    []string
+	Warnings []string
+	// Protoc describes the outcome of the Go code generation, which only
+	// succeeded when Generated is set
+	Protoc    string
+	Generated bool
+}
+
+// grpcMethod is an RPC backed by a use case
+type grpcMethod struct {
+	Name     string
+	Field    string
+	Request  []protoField
+	Response []protoField
+}
+
+// protoField is a use case field mapped to a protobuf message field
+type protoField struct {
+	Name        string
+	Type        string
+	Number      int
+	GoName      string
+	ProtoGoName string
+	FromProto   string
+	ToProto     string
+}
+
+// protoTypes maps field spec types to protobuf scalar types
+var protoTypes = map[string]string{
+	"string": "string",
+	"int":    "int64",
+	"int64":  "int64",
+	"float":  "double",
+	"bool":   "bool",
+	"time":   "google.protobuf.Timestamp",
+}
+
+// GenerateGRPC generates a gRPC service whose RPCs call the given use cases.
+// With no use cases, every use case whose name contains the service name is
+// exposed. The first call also creates the gRPC server and starts it from
+// main next to the HTTP server.
+func GenerateGRPC(name string, usecases []string) (_ *GRPCResult, err error) {
+	defer beginTransaction().end(&err)
+
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateName(i18n.T("kind.service"), name); err != nil {
+		return nil, err
+	}
+	name = ToPascalCase(name)
+
+	if len(usecases) == 0 {
+		if usecases, err = matchingUsecases(name); err != nil {
+			return nil, err
+		}
+		if len(usecases) == 0 {
+			return nil, i18n.Error("grpc.no_usecases", name)
+		}
+	}
+
+	methods := make([]grpcMethod, 0, len(usecases))
+	usesTimestamp := false
+	for _, usecase := range usecases {
+		input, output, err := LoadUsecaseFields(usecase)
+		if err != nil {
+			return nil, err
+		}
+		method := grpcMethod{
+			Name:     ToPascalCase(usecase),
+			Field:    ToCamelCase(usecase),
+			Request:  protoFields(input),
+			Response: protoFields(output),
+		}
+		for _, field := range append(append([]protoField{}, method.Request...), method.Response...) {
+			usesTimestamp = usesTimestamp || strings.HasPrefix(field.Type, "google.")
+		}
+		methods = append(methods, method)
+	}
+
+	snake := ToSnakeCase(name)
+	goPackage := strings.ReplaceAll(snake, "_", "") + "pb"
+	service := name
+	if !strings.HasSuffix(service, "Service") {
+		service += "Service"
+	}
+	protoFile := snake + ".proto"
+
+	data := map[string]interface{}{
+		"Name":          name,
+		"Service":       service,
+		"ModulePath":    config.ModulePath,
+		"ProtoPackage":  strings.ReplaceAll(snake, "_", "") + ".v1",
+		"ProtoFile":     protoFile,
+		"GoPackage":     goPackage,
+		"GoPackagePath": config.ModulePath + "/gen/" + goPackage,
+		"UsesTimestamp": usesTimestamp,
+		"Methods":       methods,
+	}
+
+	protoPath := filepath.Join("proto", protoFile)
+	serverPath := filepath.Join(grpcDir, snake+"_server.go")
+
+	result := &GRPCResult{}
+
+	created, err := generateGRPCSupportFiles(config)
+	if err != nil {
+		return nil, err
+	}
+	result.Files = append(result.Files, created...)
+
+	proto, err := renderTemplate("proto", data)
+	if err != nil {
+		return nil, err
+	}
+	created, err = writeFiles([]generatedFile{{protoPath, proto}})
+	if err != nil {
+		return nil, err
+	}
+	result.Files = append(result.Files, created...)
+
+	var external []string
+	if usesTimestamp {
+		external = append(external, "google.golang.org/protobuf/types/known/timestamppb")
+	}
+	data["Imports"] = renderImports([]string{"context"}, external, []string{
+		config.ModulePath + "/domain/usecases",
+		config.ModulePath + "/gen/" + goPackage,
+	})
+
+	server, err := renderGoTemplate("grpcService", data)
+	if err != nil {
+		return nil, err
+	}
+	created, err = writeFiles([]generatedFile{{serverPath, server}})
+	if err != nil {
+		return nil, err
+	}
+	result.Files = append(result.Files, created...)
+
+	servicesPath := filepath.Join(grpcDir, "services.go")
+	registration := fmt.Sprintf("%s.Register%sServer(srv, New%sServer())", goPackage, service, name)
+	if err := InsertBeforeMarker(servicesPath, servicesMarker, registration); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("agrega %s a RegisterServices: %v", registration, err))
+	} else if err := AddImport(servicesPath, "", config.ModulePath+"/gen/"+goPackage); err != nil {
+		return nil, err
+	}
+
+	if err := registerEntrypoint("grpc"); err != nil {
+		return nil, err
+	}
+
+	installDependencies(grpcDependencies)
+	result.Protoc, result.Generated = runProtoc(config.ModulePath, protoPath)
+
+	return result, nil
+}
+
+// matchingUsecases lists the use cases whose name contains the service name
+func matchingUsecases(name string) ([]string, error) {
+	usecases, err := listUsecases()
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []string
+	for _, usecase := range usecases {
+		if strings.Contains(usecase, name) {
+			matching = append(matching, usecase)
+		}
+	}
+	return matching, nil
+}
+
+// listUsecases returns the sorted names of the use cases in domain/usecases
+// that declare their interface, input and output
+func listUsecases() ([]string, error) {
+	entries, err := os.ReadDir("domain/usecases")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, i18n.Error("err.read.usecases", err)
+	}
+
+	// Contracts generated from OpenAPI share the use case with its stub
+	seen := map[string]bool{}
+	var usecases []string
+	for _, entry := range entries {
+		base := strings.TrimSuffix(strings.TrimSuffix(entry.Name(), ".go"), "_contract")
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") || seen[base] {
+			continue
+		}
+		seen[base] = true
+
+		usecase := ToPascalCase(base)
+		if _, _, err := LoadUsecaseFields(usecase); err != nil {
+			continue
+		}
+		usecases = append(usecases, usecase)
+	}
+
+	sort.Strings(usecases)
+	return usecases, nil
+}
+
+// protoFields numbers the fields of a use case DTO and builds the
+// conversions between their Go and protobuf types
+func protoFields(specs []FieldSpec) []protoField {
+	fields := make([]protoField, 0, len(specs))
+	for i, spec := range specs {
+		name := ToSnakeCase(spec.GoName())
+		field := protoField{
+			Name:        name,
+			Type:        protoTypes[spec.Type],
+			Number:      i + 1,
+			GoName:      spec.GoName(),
+			ProtoGoName: protoGoName(name),
+		}
+
+		getter := "req.Get" + field.ProtoGoName + "()"
+		value := "out." + field.GoName
+		switch spec.Type {
+		case "int":
+			field.FromProto = "int(" + getter + ")"
+			field.ToProto = "int64(" + value + ")"
+		case "time":
+			field.FromProto = getter + ".AsTime()"
+			field.ToProto = "timestamppb.New(" + value + ")"
+		default:
+			field.FromProto = getter
+			field.ToProto = value
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// protoGoName returns the Go name protoc-gen-go gives a snake_case field
+func protoGoName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper && r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		upper = r >= '0' && r <= '9'
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// generateGRPCSupportFiles creates the gRPC server, its service registry and
+// error translation, adds the gRPC port to the configuration and starts the
+// server from main. It returns the files it created.
+func generateGRPCSupportFiles(config ProjectConfig) ([]string, error) {
+	serverPath := filepath.Join(grpcDir, "server.go")
+	if FileExists(serverPath) {
+		return nil, nil
+	}
+
+	if err := EnsureDir(grpcDir); err != nil {
+		return nil, i18n.Error("err.mkdir", grpcDir, err)
+	}
+
+	files := []struct {
+		path     string
+		template string
+	}{
+		{serverPath, "grpcServer"},
+		{filepath.Join(grpcDir, "services.go"), "grpcServices"},
+		{filepath.Join(grpcDir, "errors.go"), "grpcErrors"},
+	}
+
+	var created []string
+	for _, file := range files {
+		content, err := renderGoTemplate(file.template, config)
+		if err != nil {
+			return nil, err
+		}
+		if err := WriteFile(file.path, content); err != nil {
+			return nil, i18n.Error("err.create", file.path, err)
+		}
+		created = append(created, file.path)
+	}
+
+	if err := addGRPCConfig(); err != nil {
+		return nil, err
+	}
+
+	mainPath := filepath.Join("cmd/api/main.go")
+	if err := InsertBeforeMarker(mainPath, serversMarker, `servers = append(servers, grpcentry.NewServer(":"+cfg.GRPCPort))`); err != nil {
+		return nil, i18n.Error("grpc.server", mainPath, err)
+	}
+	if err := AddImport(mainPath, "grpcentry", config.ModulePath+"/"+grpcDir); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// addGRPCConfig adds GRPC_PORT to the configuration and .env.example
+func addGRPCConfig() error {
+	configPath := filepath.Join("config/config.go")
+	if err := InsertAfterLine(configPath, "HTTPPort string", "GRPCPort", "GRPCPort string"); err != nil {
+		return err
+	}
+	if err := InsertAfterLine(configPath, "HTTPPort:", "GRPCPort:", `GRPCPort: getEnv("GRPC_PORT", "9090"),`); err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+	formatted, err := format.Source(content)
+	if err != nil {
+		return i18n.Error("err.format", configPath, err)
+	}
+	if err := WriteFile(configPath, formatted); err != nil {
+		return err
+	}
+
+	if FileExists(".env.example") {
+		return InsertAfterLine(".env.example", "APP_PORT=", "GRPC_PORT=", "GRPC_PORT=9090")
+	}
+	return nil
+}
+
+// installDependencies adds modules to go.mod at their pinned version,
+// warning about the ones that cannot be fetched
+func installDependencies(deps []string) {
+	fmt.Fprintln(Output, i18n.T("deps.installing"))
+	journalModule()
+	for _, dep := range pinnedModules(deps) {
+		fmt.Fprintf(Output, "   - %s\n", dep)
+		cmd := exec.Command("go", "get", dep)
+		cmd.Stdout = Output
+		cmd.Stderr = os.Stderr
+		if err := runCommand(cmd); err != nil {
+			warning := i18n.T("deps.install_failed", dep, err)
+			fmt.Fprintln(Output, i18n.T("warning", warning))
+			recordWarning(warning)
+		}
+	}
+}
+
+// runProtoc generates the Go code of a .proto file when protoc and its Go
+// plugins are installed, and otherwise explains how to do it
+func runProtoc(modulePath, protoPath string) (string, bool) {
+	var missing []string
+	if _, err := exec.LookPath("protoc"); err != nil {
+		missing = append(missing, "  • protoc: https://protobuf.dev/installation/")
+	}
+	plugins := make([]string, 0, len(protocPlugins))
+	for plugin := range protocPlugins {
+		plugins = append(plugins, plugin)
+	}
+	sort.Strings(plugins)
+	for _, plugin := range plugins {
+		if _, err := exec.LookPath(plugin); err != nil {
+			missing = append(missing, fmt.Sprintf("  • %s: %s", plugin, protocPlugins[plugin]))
+		}
+	}
+
+	if len(missing) > 0 {
+		return i18n.T("grpc.protoc.missing",
+			protoPath, strings.Join(missing, "\n"), grpcDir), false
+	}
+
+	cmd := exec.Command("protoc", "-I", "proto",
+		"--go_out=.", "--go_opt=module="+modulePath,
+		"--go-grpc_out=.", "--go-grpc_opt=module="+modulePath,
+		protoPath)
+	output, err := cmd.CombinedOutput()
+	recordCommand(cmd, err)
+	if err != nil {
+		return i18n.T("grpc.protoc.failed", protoPath, err, output), false
+	}
+	return i18n.T("grpc.protoc.done", protoPath), true
+}
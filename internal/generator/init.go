@@ -0,0 +1,185 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// InitResult lists what 'cleango init' added to the repository. The existing
+// files it found are recorded as conflicts in the report.
+type InitResult struct {
+	// Dirs are the directories of the layout that were missing
+	Dirs []string
+	// Created files did not exist
+	Created []string
+	// Manifest reports whether the manifest was written
+	Manifest bool
+}
+
+// DetectProject infers the configuration of the Go module in the current
+// directory, to add the Clean Architecture layout to it: the module path
+// comes from go.mod and the framework, database, Redis, Kafka and logger
+// from the packages its code imports. go.mod requirements fill in for
+// repositories with no code yet, and an existing manifest prevails.
+func DetectProject() (ProjectConfig, error) {
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return ProjectConfig{}, err
+	}
+	if FileExists(ManifestFile) {
+		return config, nil
+	}
+
+	imports, err := repositoryImports(".")
+	if err != nil {
+		return ProjectConfig{}, err
+	}
+	if framework := mostImported(imports, Frameworks, frameworkModules); framework != "" {
+		config.Framework = framework
+	}
+	if database := mostImported(imports, Databases, databaseModules); database != "" {
+		config.Database = database
+	}
+	config.UseRedis = config.UseRedis || imports.provides("github.com/redis/go-redis/v9") > 0
+	config.UseKafka = config.UseKafka || imports.provides("github.com/segmentio/kafka-go") > 0
+	config.Logger = "zap"
+	if imports.provides("log/slog") > imports.provides("go.uber.org/zap") {
+		config.Logger = "slog"
+	}
+	config.Lang = i18n.Lang()
+	return config, nil
+}
+
+// importCounts maps each imported package to the number of files importing it
+type importCounts map[string]int
+
+// provides returns the number of imports of module and its packages
+func (c importCounts) provides(module string) int {
+	count := 0
+	for pkg, files := range c {
+		if pkg == module || strings.HasPrefix(pkg, module+"/") {
+			count += files
+		}
+	}
+	return count
+}
+
+// mostImported returns the option, in the order of options, whose module is
+// imported by the most files, or "" when none is
+func mostImported(imports importCounts, options []string, modules map[string]string) string {
+	best, bestCount := "", 0
+	for _, option := range options {
+		module, ok := modules[option]
+		if !ok {
+			continue
+		}
+		if count := imports.provides(module); count > bestCount {
+			best, bestCount = option, count
+		}
+	}
+	return best
+}
+
+// repositoryImports counts the imports of the Go files under root, leaving
+// out vendored, hidden and testdata directories
+func repositoryImports(root string) (importCounts, error) {
+	imports := importCounts{}
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+		if err != nil {
+			// Broken files do not stop the detection
+			return nil
+		}
+		for _, spec := range file.Imports {
+			if pkg, err := strconv.Unquote(spec.Path.Value); err == nil {
+				imports[pkg]++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, i18n.Error("init.scan", err)
+	}
+	return imports, nil
+}
+
+// InitProject adds the Clean Architecture layout to the Go module in the
+// current directory: it creates the missing directories and files of
+// 'cleango new', requires the dependencies go.mod lacks and writes the
+// manifest. Existing files with other content are handled by the conflict
+// policy; an existing manifest is kept. On failure, everything it changed is
+// rolled back.
+func InitProject(config ProjectConfig, deps DependencyOptions) (_ *InitResult, err error) {
+	defer beginTransaction().end(&err)
+	defer generateProject(config)()
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if err := Conflicts.Validate(); err != nil {
+		return nil, err
+	}
+	if !FileExists("go.mod") {
+		return nil, codedError(CodeNotAProject, "err.no_gomod")
+	}
+	files, err := renderProjectFiles(config)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &InitResult{}
+	for _, dir := range projectDirs {
+		if FileExists(dir) {
+			continue
+		}
+		if err := EnsureDir(dir); err != nil {
+			return nil, i18n.Error("err.mkdir", dir, err)
+		}
+		result.Dirs = append(result.Dirs, dir)
+	}
+
+	if err := requireDependencies(config); err != nil {
+		return nil, i18n.Error("project.require", err)
+	}
+
+	if !FileExists(ManifestFile) {
+		manifest := NewManifest(config)
+		for entrypoint, dir := range entrypointDirs {
+			if FileExists(dir) {
+				manifest.AddEntrypoint(entrypoint)
+			}
+		}
+		if err := manifest.Save(); err != nil {
+			return nil, i18n.Error("err.create", ManifestFile, err)
+		}
+		result.Manifest = true
+	}
+
+	// Only the files written from the templates get a pristine copy: the
+	// others are the team's, which 'cleango upgrade' must not merge into
+	if result.Created, err = writeFiles(files); err != nil {
+		return nil, err
+	}
+
+	return result, resolveDependencies(deps)
+}
@@ -0,0 +1,24 @@
+package generator
+
+import (
+	"github.com/YeridStick/cleango/internal/lint"
+)
+
+// Lint checks the imports of the project in the current directory against
+// the dependency rules, customised by the lint section of the manifest
+func Lint() (*lint.Report, error) {
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := LoadManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := lint.Options{Dir: ".", ModulePath: config.ModulePath}
+	if manifest.Lint != nil {
+		opts.Config = *manifest.Lint
+	}
+	return lint.Run(opts)
+}
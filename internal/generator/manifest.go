@@ -0,0 +1,144 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"sort"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/YeridStick/cleango/internal/lint"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile is the project manifest written at the project root
+const ManifestFile = "cleango.yaml"
+
+// Manifest records how a project was generated, so later commands know
+// which entrypoints to extend
+type Manifest struct {
+	Version     int      `yaml:"version"`
+	Name        string   `yaml:"name"`
+	Module      string   `yaml:"module"`
+	Framework   string   `yaml:"framework"`
+	Database    string   `yaml:"database"`
+	Redis       bool     `yaml:"redis,omitempty"`
+	Kafka       bool     `yaml:"kafka,omitempty"`
+	Logger      string   `yaml:"logger,omitempty"`
+	Lang        string   `yaml:"lang,omitempty"`
+	Entrypoints []string `yaml:"entrypoints"`
+	Mocks       bool     `yaml:"mocks,omitempty"`
+
+	// Pack records the template pack the project was created with
+	Pack *PackRef `yaml:"pack,omitempty"`
+
+	// Lint customises the rules checked by 'cleango lint'
+	Lint *lint.Config `yaml:"lint,omitempty"`
+}
+
+// entrypointDirs maps each entrypoint to the directory it is generated in
+var entrypointDirs = map[string]string{
+	"http":    "infrastructure/entrypoints/http",
+	"grpc":    grpcDir,
+	"graphql": graphqlDir,
+}
+
+// NewManifest creates the manifest of a new project
+func NewManifest(config ProjectConfig) *Manifest {
+	return &Manifest{
+		Version:     1,
+		Name:        config.Name,
+		Module:      config.ModulePath,
+		Framework:   config.Framework,
+		Database:    config.Database,
+		Redis:       config.UseRedis,
+		Kafka:       config.UseKafka,
+		Logger:      config.Logger,
+		Lang:        config.Lang,
+		Entrypoints: []string{"http"},
+		Pack:        config.Pack,
+	}
+}
+
+// LoadManifest reads the manifest of the project in the current directory.
+// Projects created before the manifest existed get one inferred from go.mod
+// and the entrypoint directories present.
+func LoadManifest() (*Manifest, error) {
+	content, err := os.ReadFile(ManifestFile)
+	if os.IsNotExist(err) {
+		config, err := LoadProjectConfig()
+		if err != nil {
+			return nil, err
+		}
+		manifest := NewManifest(config)
+		manifest.Entrypoints = nil
+		for entrypoint, dir := range entrypointDirs {
+			if FileExists(dir) {
+				manifest.AddEntrypoint(entrypoint)
+			}
+		}
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := yaml.Unmarshal(content, &manifest); err != nil {
+		return nil, i18n.Error("err.read", ManifestFile, err)
+	}
+	return &manifest, nil
+}
+
+// Save writes the manifest to the project root
+func (m *Manifest) Save() error {
+	content, err := m.encode()
+	if err != nil {
+		return err
+	}
+	return WriteFile(ManifestFile, content)
+}
+
+// encode returns the content of the manifest file
+func (m *Manifest) encode() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(i18n.Translate(m.Lang, "manifest.header") + "\n")
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(m); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// HasEntrypoint reports whether the project exposes the given entrypoint
+func (m *Manifest) HasEntrypoint(name string) bool {
+	for _, entrypoint := range m.Entrypoints {
+		if entrypoint == name {
+			return true
+		}
+	}
+	return false
+}
+
+// AddEntrypoint records an entrypoint, keeping the list sorted
+func (m *Manifest) AddEntrypoint(name string) {
+	if m.HasEntrypoint(name) {
+		return
+	}
+	m.Entrypoints = append(m.Entrypoints, name)
+	sort.Strings(m.Entrypoints)
+}
+
+// registerEntrypoint records an entrypoint in the project manifest
+func registerEntrypoint(name string) error {
+	manifest, err := LoadManifest()
+	if err != nil {
+		return err
+	}
+	manifest.AddEntrypoint(name)
+	return manifest.Save()
+}
@@ -0,0 +1,499 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// mocksDir is the package holding the generated mocks
+const mocksDir = "mocks"
+
+// mockHeader marks the files written by GenerateMocks, so stale ones can be
+// removed when their interface disappears
+const mockHeader = "// Code generated by cleango mocks. DO NOT EDIT."
+
+// mockSourceDirs are the layers whose interfaces are mocked: domain ports,
+// use cases and adapter repositories
+var mockSourceDirs = []string{"domain", "infrastructure/adapters"}
+
+// MocksResult lists the files touched by GenerateMocks
+type MocksResult struct {
+	Written  []string
+	Removed  []string
+	Warnings []string
+}
+
+// mockInterface is an interface to mock
+type mockInterface struct {
+	Name    string
+	Source  string
+	Methods []mockMethod
+	imports map[string]string
+	// importPath is the package declaring the interface
+	importPath string
+}
+
+// mockMethod is a method of a mocked interface
+type mockMethod struct {
+	Name         string
+	Params       string
+	Results      string
+	NamedResults string
+	HasResults   bool
+	Args         []mockArg
+	Forward      string
+}
+
+// mockArg is a parameter recorded by the mock
+type mockArg struct {
+	Name  string
+	Field string
+	Type  string
+}
+
+// GenerateMocks writes a hand-rolled mock into the mocks package for every
+// exported interface of the domain and adapter layers, and removes the mocks
+// of interfaces that no longer exist
+func GenerateMocks() (_ *MocksResult, err error) {
+	defer beginTransaction().end(&err)
+
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MocksResult{}
+	files, err := renderMocks(config.ModulePath, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := EnsureDir(mocksDir); err != nil {
+		return nil, i18n.Error("err.mkdir", mocksDir, err)
+	}
+	for _, file := range outdatedFiles(files) {
+		if err := WriteFile(file.path, file.content); err != nil {
+			return nil, err
+		}
+		result.Written = append(result.Written, file.path)
+	}
+
+	stale, err := staleMocks(files)
+	if err != nil {
+		return nil, err
+	}
+	for _, filename := range stale {
+		if err := RemoveFile(filename); err != nil {
+			return nil, err
+		}
+		result.Removed = append(result.Removed, filename)
+	}
+
+	return result, nil
+}
+
+// renderMocks renders the mocks of the project interfaces without writing
+// them. Interfaces that cannot be mocked are reported in result.Warnings.
+func renderMocks(modulePath string, result *MocksResult) ([]generatedFile, error) {
+	interfaces, err := mockInterfaces(modulePath, result)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]generatedFile, 0, len(interfaces))
+	for _, iface := range interfaces {
+		content, err := renderMock(iface)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, generatedFile{
+			path:    filepath.Join(mocksDir, ToSnakeCase(iface.Name)+".go"),
+			content: content,
+		})
+	}
+	return files, nil
+}
+
+// mockInterfaces returns the mockable interfaces of the project, named as
+// their mocks
+func mockInterfaces(modulePath string, result *MocksResult) ([]*mockInterface, error) {
+	var interfaces []*mockInterface
+	for _, dir := range mockSourceDirs {
+		found, err := collectInterfaces(modulePath, dir, result)
+		if err != nil {
+			return nil, err
+		}
+		interfaces = append(interfaces, found...)
+	}
+
+	// Interfaces with the same name in different packages get the package
+	// name as prefix
+	counts := map[string]int{}
+	for _, iface := range interfaces {
+		counts[iface.Name]++
+	}
+	for _, iface := range interfaces {
+		if counts[iface.Name] > 1 {
+			iface.Name = ToPascalCase(strings.SplitN(iface.Source, ".", 2)[0]) + iface.Name
+		}
+	}
+	return interfaces, nil
+}
+
+// staleMocks lists the generated mocks in the mocks package that are not
+// among files
+func staleMocks(files []generatedFile) ([]string, error) {
+	keep := map[string]bool{}
+	for _, file := range files {
+		keep[file.path] = true
+	}
+
+	entries, err := os.ReadDir(mocksDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []string
+	for _, entry := range entries {
+		filename := filepath.Join(mocksDir, entry.Name())
+		if entry.IsDir() || keep[filename] || !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+		content, err := os.ReadFile(filename)
+		if err != nil || !bytes.HasPrefix(content, []byte(mockHeader)) {
+			continue
+		}
+		stale = append(stale, filename)
+	}
+	return stale, nil
+}
+
+// RefreshMocks regenerates the mocks when the project manifest enables them
+func RefreshMocks() (*MocksResult, error) {
+	manifest, err := LoadManifest()
+	if err != nil || !manifest.Mocks {
+		return nil, err
+	}
+	return GenerateMocks()
+}
+
+// EnableMocks records in the manifest that 'add' commands keep mocks in sync
+func EnableMocks() error {
+	manifest, err := LoadManifest()
+	if err != nil {
+		return err
+	}
+	manifest.Mocks = true
+	return manifest.Save()
+}
+
+// collectInterfaces parses the packages under root and returns their
+// mockable interfaces
+func collectInterfaces(modulePath, root string, result *MocksResult) ([]*mockInterface, error) {
+	var interfaces []*mockInterface
+
+	err := filepath.WalkDir(root, func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+
+		fset := token.NewFileSet()
+		for _, f := range files {
+			if f.IsDir() || !strings.HasSuffix(f.Name(), ".go") || strings.HasSuffix(f.Name(), "_test.go") {
+				continue
+			}
+			filename := filepath.Join(dir, f.Name())
+			file, err := parser.ParseFile(fset, filename, nil, 0)
+			if err != nil {
+				return i18n.Error("err.read", filename, err)
+			}
+
+			importPath := modulePath + "/" + filepath.ToSlash(dir)
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					it, ok := ts.Type.(*ast.InterfaceType)
+					if !ok || !ts.Name.IsExported() {
+						continue
+					}
+					iface, reason := newMockInterface(file, importPath, ts, it)
+					if reason != "" {
+						result.Warnings = append(result.Warnings, i18n.T("mocks.skipped", file.Name.Name, ts.Name.Name, reason))
+						continue
+					}
+					interfaces = append(interfaces, iface)
+				}
+			}
+		}
+		return nil
+	})
+
+	return interfaces, err
+}
+
+// newMockInterface describes an interface, or explains why it cannot be mocked
+func newMockInterface(file *ast.File, importPath string, ts *ast.TypeSpec, it *ast.InterfaceType) (*mockInterface, string) {
+	if ts.TypeParams != nil {
+		return nil, i18n.T("mocks.generic")
+	}
+
+	pkg := file.Name.Name
+	iface := &mockInterface{
+		Name:       ts.Name.Name,
+		Source:     pkg + "." + ts.Name.Name,
+		imports:    map[string]string{"sync": ""},
+		importPath: importPath,
+	}
+	iface.imports[importPath] = importAlias(pkg, importPath)
+
+	for _, field := range it.Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) == 0 {
+			return nil, i18n.T("mocks.embeds")
+		}
+		if !field.Names[0].IsExported() {
+			return nil, i18n.T("mocks.unexported")
+		}
+
+		method := mockMethod{Name: field.Names[0].Name}
+		var params, forward []string
+		if fn.Params != nil {
+			index := 0
+			for _, param := range fn.Params.List {
+				names := param.Names
+				if len(names) == 0 {
+					names = []*ast.Ident{nil}
+				}
+				for _, name := range names {
+					argName := fmt.Sprintf("p%d", index)
+					if name != nil && name.Name != "_" && name.Name != "m" {
+						argName = name.Name
+					}
+					index++
+
+					typ := qualifyType(param.Type, pkg)
+					typeText, err := typeString(typ)
+					if err != nil {
+						return nil, err.Error()
+					}
+					iface.addImports(file, param.Type)
+
+					fieldType := typeText
+					forwardArg := argName
+					if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+						elt, _ := typeString(ellipsis.Elt)
+						fieldType = "[]" + elt
+						forwardArg += "..."
+					}
+
+					params = append(params, argName+" "+typeText)
+					forward = append(forward, forwardArg)
+					method.Args = append(method.Args, mockArg{
+						Name:  argName,
+						Field: ToPascalCase(argName),
+						Type:  fieldType,
+					})
+				}
+			}
+		}
+
+		var results, named []string
+		if fn.Results != nil {
+			index := 0
+			for _, result := range fn.Results.List {
+				count := len(result.Names)
+				if count == 0 {
+					count = 1
+				}
+				typeText, err := typeString(qualifyType(result.Type, pkg))
+				if err != nil {
+					return nil, err.Error()
+				}
+				iface.addImports(file, result.Type)
+				for i := 0; i < count; i++ {
+					results = append(results, typeText)
+					named = append(named, fmt.Sprintf("r%d %s", index, typeText))
+					index++
+				}
+			}
+		}
+
+		method.Params = strings.Join(params, ", ")
+		method.Forward = strings.Join(forward, ", ")
+		method.HasResults = len(results) > 0
+		switch len(results) {
+		case 0:
+		case 1:
+			method.Results = results[0]
+		default:
+			method.Results = "(" + strings.Join(results, ", ") + ")"
+		}
+		if len(named) > 0 {
+			method.NamedResults = "(" + strings.Join(named, ", ") + ")"
+		}
+		iface.Methods = append(iface.Methods, method)
+	}
+
+	return iface, ""
+}
+
+// addImports records the imports of file used by the selectors in expr
+func (m *mockInterface) addImports(file *ast.File, expr ast.Expr) {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		for _, spec := range file.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			name := importName(importPath)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			if name == ident.Name {
+				m.imports[importPath] = importAlias(name, importPath)
+			}
+		}
+		return false
+	})
+}
+
+// importName guesses the package name of an import path as goimports does:
+// its last element, skipping major version suffixes and a "go-" prefix, up
+// to the first character that cannot be in an identifier ("kafka-go" is
+// kafka, "yaml.v3" is yaml)
+func importName(importPath string) string {
+	elements := strings.Split(importPath, "/")
+	name := elements[len(elements)-1]
+	if len(elements) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = elements[len(elements)-2]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	if i := strings.IndexFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// importAlias returns the alias needed to import importPath as name
+func importAlias(name, importPath string) string {
+	if path.Base(importPath) == name {
+		return ""
+	}
+	return name
+}
+
+// predeclared lists the predeclared identifiers that may appear in types
+var predeclared = map[string]bool{
+	"any": true, "bool": true, "byte": true, "comparable": true, "complex64": true,
+	"complex128": true, "error": true, "float32": true, "float64": true, "int": true,
+	"int8": true, "int16": true, "int32": true, "int64": true, "rune": true,
+	"string": true, "uint": true, "uint8": true, "uint16": true, "uint32": true,
+	"uint64": true, "uintptr": true,
+}
+
+// qualifyType rewrites the types declared in the interface's own package as
+// selectors on that package
+func qualifyType(expr ast.Expr, pkg string) ast.Expr {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if predeclared[t.Name] || !t.IsExported() {
+			return t
+		}
+		return &ast.SelectorExpr{X: ast.NewIdent(pkg), Sel: ast.NewIdent(t.Name)}
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualifyType(t.X, pkg)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: t.Len, Elt: qualifyType(t.Elt, pkg)}
+	case *ast.MapType:
+		return &ast.MapType{Key: qualifyType(t.Key, pkg), Value: qualifyType(t.Value, pkg)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: t.Dir, Value: qualifyType(t.Value, pkg)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: qualifyType(t.Elt, pkg)}
+	case *ast.FuncType:
+		return &ast.FuncType{Params: qualifyFields(t.Params, pkg), Results: qualifyFields(t.Results, pkg)}
+	}
+	return expr
+}
+
+func qualifyFields(list *ast.FieldList, pkg string) *ast.FieldList {
+	if list == nil {
+		return nil
+	}
+	qualified := &ast.FieldList{}
+	for _, field := range list.List {
+		qualified.List = append(qualified.List, &ast.Field{Names: field.Names, Type: qualifyType(field.Type, pkg)})
+	}
+	return qualified
+}
+
+// typeString prints a type expression
+func typeString(expr ast.Expr) (string, error) {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, token.NewFileSet(), expr); err != nil {
+		return "", i18n.Error("mocks.type", err)
+	}
+	return buf.String(), nil
+}
+
+// renderMock renders the mock of an interface
+func renderMock(iface *mockInterface) ([]byte, error) {
+	var std, external []string
+	for importPath, alias := range iface.imports {
+		spec := importPath
+		if alias != "" {
+			spec = fmt.Sprintf("%s %q", alias, importPath)
+		}
+		if strings.Contains(strings.SplitN(importPath, "/", 2)[0], ".") {
+			external = append(external, spec)
+		} else {
+			std = append(std, spec)
+		}
+	}
+
+	return renderGoTemplate("mock", map[string]interface{}{
+		"Header":  mockHeader,
+		"Name":    iface.Name,
+		"Source":  iface.Source,
+		"Methods": iface.Methods,
+		"Imports": renderImports(uniqueSorted(std), uniqueSorted(external)),
+	})
+}
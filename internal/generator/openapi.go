@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/YeridStick/cleango/internal/openapi"
+)
+
+// OpenAPIOptions controls the generation of the OpenAPI spec
+type OpenAPIOptions struct {
+	Output  string
+	Title   string
+	Version string
+	Serve   bool
+	Check   bool
+}
+
+// GenerateOpenAPI writes the OpenAPI spec of the project in the current
+// directory and returns the files it wrote. With Check set nothing is
+// written and an error is returned when the files on disk are outdated.
+func GenerateOpenAPI(opts OpenAPIOptions) (_ []string, err error) {
+	defer beginTransaction().end(&err)
+
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Title == "" {
+		opts.Title = config.Name
+	}
+	if opts.Version == "" {
+		opts.Version = "1.0.0"
+	}
+
+	doc, err := openapi.Generate(openapi.Options{
+		Dir:        ".",
+		ModulePath: config.ModulePath,
+		Title:      opts.Title,
+		Version:    opts.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := openapi.Marshal(doc)
+	if err != nil {
+		return nil, i18n.Error("openapi.marshal", err)
+	}
+
+	outputs := []string{opts.Output}
+	docsFile := filepath.Join(openapi.HTTPDir, "docs.go")
+	if served := filepath.Join(openapi.HTTPDir, "openapi.yaml"); (opts.Serve || FileExists(docsFile)) && filepath.Clean(opts.Output) != served {
+		// The served copy lives next to docs.go so it can be embedded
+		outputs = append(outputs, served)
+	}
+
+	if opts.Check {
+		for _, output := range outputs {
+			current, err := os.ReadFile(output)
+			if err != nil || !bytes.Equal(current, spec) {
+				return nil, codedError(CodeCheckFailed, "openapi.outdated", output)
+			}
+		}
+		return nil, nil
+	}
+
+	for _, output := range outputs {
+		if err := EnsureDir(filepath.Dir(output)); err != nil {
+			return nil, err
+		}
+		if err := WriteFile(output, spec); err != nil {
+			return nil, i18n.Error("err.write", output, err)
+		}
+	}
+
+	if opts.Serve && !FileExists(docsFile) {
+		content, err := renderGoTemplate("httpDocs", config)
+		if err != nil {
+			return nil, err
+		}
+		if err := WriteFile(docsFile, content); err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, docsFile)
+
+		if err := InsertBeforeMarker(filepath.Join(openapi.HTTPDir, "routes.go"), routesMarker, "RegisterDocs(r)"); err != nil {
+			return outputs, i18n.Error("openapi.docs.route", err)
+		}
+	}
+
+	return outputs, nil
+}
+
+// RefreshOpenAPI regenerates openapi.yaml, and the copy served next to
+// docs.go, when the project has them. The title and version of the spec are
+// kept.
+func RefreshOpenAPI() error {
+	spec := "openapi.yaml"
+	if !FileExists(spec) {
+		spec = filepath.Join(openapi.HTTPDir, "openapi.yaml")
+		if !FileExists(spec) {
+			return nil
+		}
+	}
+
+	opts := OpenAPIOptions{Output: spec}
+	if doc, err := openapi.Load(spec); err == nil {
+		opts.Title, opts.Version = doc.Info.Title, doc.Info.Version
+	}
+	_, err := GenerateOpenAPI(opts)
+	return err
+}
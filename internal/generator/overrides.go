@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// ProjectTemplatesDir holds the template overrides of a project
+const ProjectTemplatesDir = ".cleango/templates"
+
+// templateExt is the extension of template override files
+const templateExt = ".tmpl"
+
+// Kinds of template source reported by ResolveTemplate. They are stable ids,
+// the same in every language.
+const (
+	TemplateSourceProject = "project"
+	TemplateSourceUser    = "user"
+	TemplateSourceBuiltin = "builtin"
+)
+
+// TemplateSource is where a template is loaded from
+type TemplateSource struct {
+	// Kind is TemplateSourceProject, TemplateSourcePack, TemplateSourceUser
+	// or TemplateSourceBuiltin
+	Kind string
+	// Pack is the name of the pack of pack templates
+	Pack string
+	// Path is the file of overrides and pack templates
+	Path string
+}
+
+// TemplateNames returns the names of the built-in templates, sorted
+func TemplateNames() []string {
+	names := make([]string, 0, len(builtinTemplates))
+	for name := range builtinTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UserTemplatesDir returns the directory of the user's template overrides:
+// $CLEANGO_TEMPLATES, or cleango/templates under the user configuration
+// directory (~/.config on Linux)
+func UserTemplatesDir() (string, error) {
+	if dir := os.Getenv("CLEANGO_TEMPLATES"); dir != "" {
+		return dir, nil
+	}
+	config, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(config, "cleango", "templates"), nil
+}
+
+// ResolveTemplate returns the text of the template name and where it comes
+// from: the project overrides in .cleango/templates, then the project's pack,
+// then the user's overrides, then the embedded default
+func ResolveTemplate(name string) (text string, source TemplateSource, err error) {
+	builtin, ok := builtinTemplates[name]
+	if !ok {
+		return "", TemplateSource{}, i18n.Error("templates.unknown", name)
+	}
+
+	candidates := []TemplateSource{{Kind: TemplateSourceProject, Path: filepath.Join(ProjectTemplatesDir, name+templateExt)}}
+	pack, config, err := activePack()
+	if err != nil {
+		return "", TemplateSource{}, err
+	}
+	if pack != nil {
+		if path, ok := pack.template(name, config); ok {
+			candidates = append(candidates, TemplateSource{Kind: TemplateSourcePack, Pack: pack.Name, Path: path})
+		}
+	}
+	if userDir, err := UserTemplatesDir(); err == nil {
+		candidates = append(candidates, TemplateSource{Kind: TemplateSourceUser, Path: filepath.Join(userDir, name+templateExt)})
+	}
+
+	for _, c := range candidates {
+		content, err := os.ReadFile(c.Path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", TemplateSource{}, i18n.Error("templates.read", c.Path, err)
+		}
+		return string(content), c, nil
+	}
+	return builtin, TemplateSource{Kind: TemplateSourceBuiltin}, nil
+}
+
+// loadTemplate returns the text of the template name, overrides first
+func loadTemplate(name string) (string, error) {
+	text, _, err := ResolveTemplate(name)
+	return text, err
+}
+
+// EjectTemplate copies the embedded default of the template name to the
+// project overrides, or to the user's when user is set, and returns the path
+// written. Existing overrides are kept unless force is set.
+func EjectTemplate(name string, user, force bool) (string, error) {
+	builtin, ok := builtinTemplates[name]
+	if !ok {
+		return "", i18n.Error("templates.unknown.list", name, strings.Join(TemplateNames(), ", "))
+	}
+
+	dir := ProjectTemplatesDir
+	if user {
+		var err error
+		if dir, err = UserTemplatesDir(); err != nil {
+			return "", err
+		}
+	} else if !FileExists("go.mod") {
+		return "", i18n.Error("templates.no_gomod")
+	}
+
+	path := filepath.Join(dir, name+templateExt)
+	if FileExists(path) && !force {
+		return "", codedError(CodeAlreadyExists, "templates.exists", path)
+	}
+	if err := EnsureDir(dir); err != nil {
+		return "", err
+	}
+	return path, WriteFile(path, []byte(builtin))
+}
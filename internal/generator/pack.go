@@ -0,0 +1,685 @@
+package generator
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// PackManifestFile describes a template pack, at the root of the pack
+const PackManifestFile = "pack.yaml"
+
+// TemplateSourcePack is the kind of source of pack templates
+const TemplateSourcePack = "pack"
+
+var (
+	packNamePattern    = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	packVersionPattern = regexp.MustCompile(`^v?[0-9]+\.[0-9]+\.[0-9]+([-+][0-9A-Za-z.-]+)?$`)
+	packVarPattern     = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Pack is a template pack: a company flavour of cleango bundling default
+// options, variables asked at creation, template overrides and extra files
+type Pack struct {
+	Name        string         `yaml:"name"`
+	Version     string         `yaml:"version"`
+	Description string         `yaml:"description,omitempty"`
+	Defaults    PackDefaults   `yaml:"defaults,omitempty"`
+	Variables   []PackVariable `yaml:"variables,omitempty"`
+	Templates   []PackTemplate `yaml:"templates,omitempty"`
+	Files       []PackFile     `yaml:"files,omitempty"`
+
+	// dir is where the pack is stored
+	dir string
+}
+
+// PackDefaults are the options 'cleango new --pack' uses unless the flags
+// say otherwise
+type PackDefaults struct {
+	ModulePrefix string `yaml:"modulePrefix,omitempty"`
+	Framework    string `yaml:"framework,omitempty"`
+	Database     string `yaml:"database,omitempty"`
+	Logger       string `yaml:"logger,omitempty"`
+	Redis        *bool  `yaml:"redis,omitempty"`
+	Kafka        *bool  `yaml:"kafka,omitempty"`
+}
+
+// PackVariable is a value asked when creating a project, available to the
+// templates as {{packVar "name"}}
+type PackVariable struct {
+	Name     string `yaml:"name"`
+	Prompt   string `yaml:"prompt,omitempty"`
+	Default  string `yaml:"default,omitempty"`
+	Required bool   `yaml:"required,omitempty"`
+	Pattern  string `yaml:"pattern,omitempty"`
+}
+
+// PackTemplate replaces a built-in template, for the projects matching When
+type PackTemplate struct {
+	Name string        `yaml:"name"`
+	File string        `yaml:"file,omitempty"`
+	When PackCondition `yaml:"when,omitempty"`
+}
+
+// PackFile is an extra file generated with the project, for the projects
+// matching When
+type PackFile struct {
+	Path     string        `yaml:"path"`
+	Template string        `yaml:"template"`
+	When     PackCondition `yaml:"when,omitempty"`
+}
+
+// PackCondition restricts a template or file to some project options. Empty
+// fields match every project.
+type PackCondition struct {
+	Framework []string `yaml:"framework,omitempty"`
+	Database  []string `yaml:"database,omitempty"`
+	Redis     *bool    `yaml:"redis,omitempty"`
+	Kafka     *bool    `yaml:"kafka,omitempty"`
+}
+
+// PackRef records in the manifest the pack a project uses and the values
+// given to its variables
+type PackRef struct {
+	Name    string            `yaml:"name" json:"name"`
+	Version string            `yaml:"version" json:"version"`
+	Vars    map[string]string `yaml:"vars,omitempty" json:"vars,omitempty"`
+}
+
+// Matches reports whether the condition holds for config
+func (c PackCondition) Matches(config ProjectConfig) bool {
+	if len(c.Framework) > 0 && !contains(c.Framework, config.Framework) {
+		return false
+	}
+	if len(c.Database) > 0 && !contains(c.Database, config.Database) {
+		return false
+	}
+	if c.Redis != nil && *c.Redis != config.UseRedis {
+		return false
+	}
+	if c.Kafka != nil && *c.Kafka != config.UseKafka {
+		return false
+	}
+	return true
+}
+
+// PacksDir returns the directory of the installed packs: $CLEANGO_PACKS, or
+// cleango/packs under the user configuration directory
+func PacksDir() (string, error) {
+	if dir := os.Getenv("CLEANGO_PACKS"); dir != "" {
+		return dir, nil
+	}
+	config, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(config, "cleango", "packs"), nil
+}
+
+// LoadPack returns the installed pack spec, a name optionally followed by
+// @version to require that version. The name is validated as on install, so
+// that it cannot lead out of the packs directory.
+func LoadPack(spec string) (*Pack, error) {
+	name, version, _ := strings.Cut(spec, "@")
+	if !packNamePattern.MatchString(name) {
+		return nil, i18n.Error("pack.invalid.name", name)
+	}
+	dir, err := PacksDir()
+	if err != nil {
+		return nil, err
+	}
+
+	pack, err := readPack(filepath.Join(dir, name))
+	if os.IsNotExist(err) {
+		return nil, i18n.Error("pack.not_installed", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if version != "" && compareVersions(strings.TrimPrefix(version, "v"), strings.TrimPrefix(pack.Version, "v")) != 0 {
+		return nil, i18n.Error("pack.version.other", pack.Version, name, version)
+	}
+	return pack, nil
+}
+
+// InstalledPacks returns the installed packs sorted by name
+func InstalledPacks() ([]*Pack, error) {
+	dir, err := PacksDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var packs []*Pack
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		pack, err := readPack(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		packs = append(packs, pack)
+	}
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	return packs, nil
+}
+
+// InstallPack validates the pack at source, a directory or a .tar.gz
+// archive, and copies it to the packs directory. It returns the pack and the
+// version it replaced, if any. Installing the same or an older version than
+// the installed one requires force.
+func InstallPack(source string, force bool) (pack *Pack, previous string, err error) {
+	root := source
+	if strings.HasSuffix(source, ".tar.gz") || strings.HasSuffix(source, ".tgz") {
+		tmp, err := os.MkdirTemp("", "cleango-pack-")
+		if err != nil {
+			return nil, "", err
+		}
+		defer os.RemoveAll(tmp)
+		if root, err = extractPack(source, tmp); err != nil {
+			return nil, "", i18n.Error("pack.extract", source, err)
+		}
+	}
+
+	pack, err = readPack(root)
+	if os.IsNotExist(err) {
+		return nil, "", i18n.Error("pack.no_manifest", source, PackManifestFile)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if err := pack.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	dir, err := PacksDir()
+	if err != nil {
+		return nil, "", err
+	}
+	target := filepath.Join(dir, pack.Name)
+	if installed, err := readPack(target); err == nil {
+		previous = installed.Version
+		switch order := compareVersions(strings.TrimPrefix(pack.Version, "v"), strings.TrimPrefix(installed.Version, "v")); {
+		case order == 0 && !force:
+			return nil, "", i18n.Error("pack.version.installed", installed.Version, pack.Name)
+		case order < 0 && !force:
+			return nil, "", i18n.Error("pack.version.newer", installed.Version, pack.Name, pack.Version)
+		}
+	}
+
+	// Copy next to the installed version and swap, so a failed copy keeps it
+	staging := filepath.Join(dir, "."+pack.Name+".tmp")
+	if err := os.RemoveAll(staging); err != nil {
+		return nil, "", err
+	}
+	if err := copyTree(root, staging); err != nil {
+		os.RemoveAll(staging)
+		return nil, "", i18n.Error("pack.copy", err)
+	}
+	if err := os.RemoveAll(target); err != nil {
+		return nil, "", err
+	}
+	if err := os.Rename(staging, target); err != nil {
+		return nil, "", err
+	}
+	pack.dir = target
+	return pack, previous, nil
+}
+
+// RemovePack uninstalls the pack name
+func RemovePack(name string) error {
+	pack, err := LoadPack(name)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(pack.dir)
+}
+
+// readPack reads the manifest of the pack stored in dir
+func readPack(dir string) (*Pack, error) {
+	content, err := os.ReadFile(filepath.Join(dir, PackManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var pack Pack
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	if err := dec.Decode(&pack); err != nil {
+		return nil, i18n.Error("err.read", filepath.Join(dir, PackManifestFile), err)
+	}
+	pack.dir = dir
+	return &pack, nil
+}
+
+// Validate checks the manifest of the pack and parses every template it
+// ships, reporting all the problems found
+func (p *Pack) Validate() error {
+	var problems []string
+	add := func(key string, args ...interface{}) {
+		problems = append(problems, i18n.T(key, args...))
+	}
+
+	if !packNamePattern.MatchString(p.Name) {
+		add("pack.invalid.name", p.Name)
+	}
+	if !packVersionPattern.MatchString(p.Version) {
+		add("pack.invalid.version", p.Version)
+	}
+	if p.Defaults.ModulePrefix != "" {
+		if err := ValidateModulePath(p.Defaults.ModulePrefix); err != nil {
+			add("pack.invalid.at", "defaults.modulePrefix", err)
+		}
+	}
+	if p.Defaults.Framework != "" {
+		if err := ValidateOption("defaults.framework", p.Defaults.Framework, Frameworks); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if p.Defaults.Database != "" {
+		if err := ValidateOption("defaults.database", p.Defaults.Database, Databases); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if p.Defaults.Logger != "" {
+		if err := ValidateOption("defaults.logger", p.Defaults.Logger, Loggers); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, variable := range p.Variables {
+		if !packVarPattern.MatchString(variable.Name) {
+			add("pack.invalid.var_name", variable.Name)
+		}
+		if seen[variable.Name] {
+			add("pack.invalid.var_twice", variable.Name)
+		}
+		seen[variable.Name] = true
+		if variable.Pattern == "" {
+			continue
+		}
+		pattern, err := regexp.Compile(variable.Pattern)
+		if err != nil {
+			add("pack.invalid.pattern", variable.Name, err)
+		} else if variable.Default != "" && !pattern.MatchString(variable.Default) {
+			add("pack.invalid.default", variable.Name, variable.Default, variable.Pattern)
+		}
+	}
+
+	for _, tmpl := range p.Templates {
+		if _, ok := builtinTemplates[tmpl.Name]; !ok {
+			add("pack.invalid.template", tmpl.Name)
+			continue
+		}
+		p.validateCondition(add, "templates "+tmpl.Name, tmpl.When)
+		if err := p.checkTemplate(tmpl.file()); err != nil {
+			add("pack.invalid.at", "templates "+tmpl.Name, err)
+		}
+	}
+
+	for _, file := range p.Files {
+		clean := filepath.ToSlash(filepath.Clean(file.Path))
+		if !filepath.IsLocal(file.Path) || clean == ManifestFile || strings.HasPrefix(clean, ".cleango/") {
+			add("pack.invalid.path", file.Path)
+		}
+		p.validateCondition(add, "files "+file.Path, file.When)
+		if err := p.checkTemplate(file.Template); err != nil {
+			add("pack.invalid.at", "files "+file.Path, err)
+		}
+	}
+
+	if len(problems) > 0 {
+		return i18n.Error("pack.invalid", p.Name, strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// validateCondition reports unknown options in a when clause
+func (p *Pack) validateCondition(add func(string, ...interface{}), where string, when PackCondition) {
+	for _, framework := range when.Framework {
+		if err := ValidateOption("framework", framework, Frameworks); err != nil {
+			add("pack.invalid.at", where, err)
+		}
+	}
+	for _, database := range when.Database {
+		if err := ValidateOption(i18n.T("option.database"), database, Databases); err != nil {
+			add("pack.invalid.at", where, err)
+		}
+	}
+}
+
+// checkTemplate verifies that the template file exists inside the pack and
+// parses
+func (p *Pack) checkTemplate(file string) error {
+	if file == "" {
+		return i18n.Error("pack.template.missing")
+	}
+	if !filepath.IsLocal(file) {
+		return i18n.Error("pack.template.outside", file)
+	}
+	content, err := os.ReadFile(filepath.Join(p.dir, file))
+	if err != nil {
+		return i18n.Error("pack.template.read", file)
+	}
+	if _, err := template.New(file).Funcs(templateFuncs()).Parse(string(content)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// file returns the path of the template inside the pack, by default
+// templates/<name>.tmpl
+func (t PackTemplate) file() string {
+	if t.File != "" {
+		return t.File
+	}
+	return filepath.Join("templates", t.Name+templateExt)
+}
+
+// Vars returns the values of the pack variables: given, or else their
+// default. It fails on unknown variables, missing required ones and values
+// not matching their pattern.
+func (p *Pack) Vars(given map[string]string) (map[string]string, error) {
+	for name := range given {
+		if p.variable(name) == nil {
+			return nil, i18n.Error("pack.var.undeclared", p.Name, name)
+		}
+	}
+
+	vars := map[string]string{}
+	for _, variable := range p.Variables {
+		value, ok := given[variable.Name]
+		if !ok {
+			value = variable.Default
+		}
+		if err := variable.Check(value); err != nil {
+			return nil, err
+		}
+		vars[variable.Name] = value
+	}
+	return vars, nil
+}
+
+// variable returns the variable name declared by the pack, or nil
+func (p *Pack) variable(name string) *PackVariable {
+	for i := range p.Variables {
+		if p.Variables[i].Name == name {
+			return &p.Variables[i]
+		}
+	}
+	return nil
+}
+
+// Check validates a value of the variable
+func (v PackVariable) Check(value string) error {
+	if value == "" {
+		if v.Required {
+			return i18n.Error("pack.var.required", v.Name)
+		}
+		return nil
+	}
+	if v.Pattern != "" && !regexp.MustCompile(v.Pattern).MatchString(value) {
+		return i18n.Error("pack.var.pattern", v.Name, v.Pattern)
+	}
+	return nil
+}
+
+// Ref returns the manifest record of a project created with the pack
+func (p *Pack) Ref(vars map[string]string) *PackRef {
+	if len(vars) == 0 {
+		vars = nil
+	}
+	return &PackRef{Name: p.Name, Version: p.Version, Vars: vars}
+}
+
+// template returns the path of the pack override of the template name for
+// config, if any
+func (p *Pack) template(name string, config ProjectConfig) (string, bool) {
+	for _, tmpl := range p.Templates {
+		if tmpl.Name == name && tmpl.When.Matches(config) {
+			return filepath.Join(p.dir, tmpl.file()), true
+		}
+	}
+	return "", false
+}
+
+// generatingProject is the configuration of the project 'cleango new' or
+// 'cleango init' is generating, whose manifest is written only after its
+// files are rendered
+var generatingProject *ProjectConfig
+
+// generateProject makes activePack take the pack of config rather than the
+// manifest's until the returned function is called:
+//
+//	defer generateProject(config)()
+func generateProject(config ProjectConfig) func() {
+	generatingProject = &config
+	return func() { generatingProject = nil }
+}
+
+// packVersionWarned records the projects already warned that the installed
+// version of their pack differs from theirs, as each template resolves it
+var packVersionWarned = map[string]bool{}
+
+// activePack returns the pack of the project being generated or else of the
+// project in the current directory, with its configuration, or nil when it
+// uses none. A project generated with another version of its pack than the
+// installed one is warned to upgrade, which is what applies the installed one.
+func activePack() (*Pack, ProjectConfig, error) {
+	if generatingProject != nil {
+		config := *generatingProject
+		if config.Pack == nil {
+			return nil, config, nil
+		}
+		pack, err := LoadPack(config.Pack.Name)
+		if err != nil {
+			return nil, config, i18n.Error("pack.project", config.Pack.Name, config.Pack.Version, err)
+		}
+		return pack, config, nil
+	}
+
+	content, err := os.ReadFile(ManifestFile)
+	if err != nil {
+		return nil, ProjectConfig{}, nil
+	}
+	var manifest Manifest
+	if err := yaml.Unmarshal(content, &manifest); err != nil || manifest.Pack == nil {
+		return nil, ProjectConfig{}, nil
+	}
+
+	pack, err := LoadPack(manifest.Pack.Name)
+	if err != nil {
+		return nil, ProjectConfig{}, i18n.Error("pack.project", manifest.Pack.Name, manifest.Pack.Version, err)
+	}
+	if pack.Version != manifest.Pack.Version {
+		warnPackVersion(pack, manifest.Pack.Version)
+	}
+	config := ProjectConfig{
+		Name:       manifest.Name,
+		ModulePath: manifest.Module,
+		Framework:  manifest.Framework,
+		Database:   manifest.Database,
+		UseRedis:   manifest.Redis,
+		UseKafka:   manifest.Kafka,
+		Logger:     manifest.Logger,
+		Pack:       manifest.Pack,
+	}
+	return pack, config, nil
+}
+
+// warnPackVersion warns once per project that the installed version of pack
+// is not the version the project was generated with
+func warnPackVersion(pack *Pack, version string) {
+	cwd, _ := os.Getwd()
+	if packVersionWarned[cwd] {
+		return
+	}
+	packVersionWarned[cwd] = true
+	warning := i18n.T("pack.version.project", pack.Version, pack.Name, version)
+	fmt.Fprintln(Output, i18n.T("warning", warning))
+	recordWarning(warning)
+}
+
+// packVar returns a variable of the project's pack, for the templates
+func packVar(name string) (string, error) {
+	_, config, err := activePack()
+	if err != nil {
+		return "", err
+	}
+	if config.Pack != nil {
+		if value, ok := config.Pack.Vars[name]; ok {
+			return value, nil
+		}
+	}
+	return "", i18n.Error("pack.var.undefined", name)
+}
+
+// renderPackFiles renders the extra files of the project's pack
+func renderPackFiles(config ProjectConfig) ([]generatedFile, error) {
+	if config.Pack == nil {
+		return nil, nil
+	}
+	pack, err := LoadPack(config.Pack.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []generatedFile
+	for _, file := range pack.Files {
+		if !file.When.Matches(config) {
+			continue
+		}
+		text, err := os.ReadFile(filepath.Join(pack.dir, file.Template))
+		if err != nil {
+			return nil, err
+		}
+		tmpl, err := parseTemplate(file.Template, string(text))
+		if err != nil {
+			return nil, i18n.Error("pack.template.error", file.Template, pack.Name, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, config); err != nil {
+			return nil, i18n.Error("err.generate", file.Path, err)
+		}
+
+		content := buf.Bytes()
+		if strings.HasSuffix(file.Path, ".go") {
+			if content, err = formatGo(content); err != nil {
+				return nil, i18n.Error("err.format", file.Path, err)
+			}
+		}
+		files = append(files, generatedFile{filepath.Clean(file.Path), content})
+	}
+	return files, nil
+}
+
+// extractPack unpacks a .tar.gz archive into dir and returns the pack root:
+// dir itself, or its only subdirectory when the archive wraps the pack in one
+func extractPack(archive, dir string) (string, error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return "", err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if !filepath.IsLocal(header.Name) {
+			return "", i18n.Error("pack.tar.outside", header.Name)
+		}
+		target := filepath.Join(dir, header.Name)
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := EnsureDir(target); err != nil {
+				return "", err
+			}
+		case tar.TypeReg:
+			if err := EnsureDir(filepath.Dir(target)); err != nil {
+				return "", err
+			}
+			content, err := io.ReadAll(tr)
+			if err != nil {
+				return "", err
+			}
+			if err := WriteFile(target, content); err != nil {
+				return "", err
+			}
+		default:
+			// Links and devices have no place in a pack
+			return "", i18n.Error("pack.tar.irregular", header.Name)
+		}
+	}
+
+	if FileExists(filepath.Join(dir, PackManifestFile)) {
+		return dir, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		return filepath.Join(dir, entries[0].Name()), nil
+	}
+	return dir, nil
+}
+
+// copyTree copies the regular files under src to dst
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return EnsureDir(target)
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return WriteFile(target, content)
+	})
+}
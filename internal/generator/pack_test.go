@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// TestLoadPackRejectsPaths asks for packs whose name leads out of the packs
+// directory, which is refused before reading anything
+func TestLoadPackRejectsPaths(t *testing.T) {
+	packs := t.TempDir()
+	t.Setenv("CLEANGO_PACKS", filepath.Join(packs, "installed"))
+	// A pack outside the packs directory that a path would reach
+	outside := filepath.Join(packs, "outside")
+	if err := os.MkdirAll(outside, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outside, PackManifestFile), []byte("name: outside\nversion: 1.0.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, spec := range []string{"../outside", "../../outside@1.0.0", "/tmp/outside", "Acme", ""} {
+		name, _, _ := strings.Cut(spec, "@")
+		want := i18n.T("pack.invalid.name", name)
+		if _, err := LoadPack(spec); err == nil || err.Error() != want {
+			t.Errorf("LoadPack(%q) = %v, want %q", spec, err, want)
+		}
+	}
+}
+
+// TestActivePackWarnsVersion resolves templates in a project generated with
+// another version of its pack than the installed one: the project is warned
+// once to upgrade, and the installed version is used
+func TestActivePackWarnsVersion(t *testing.T) {
+	output := Output
+	var printed bytes.Buffer
+	Output = &printed
+	t.Cleanup(func() { Output = output })
+	t.Setenv("CLEANGO_TEMPLATES", t.TempDir())
+	t.Setenv("CLEANGO_PACKS", t.TempDir())
+	if _, _, err := InstallPack(filepath.Join("testdata", "pack"), false); err != nil {
+		t.Fatal(err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(cwd) })
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	manifest := "version: 1\nname: shop\nmodule: example.com/shop\nframework: chi\ndatabase: none\npack:\n  name: acme\n  version: 0.9.0\n  vars:\n    team: payments\n"
+	if err := os.WriteFile(ManifestFile, []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report := StartReport()
+	t.Cleanup(func() { activeReport = nil })
+
+	for i := 0; i < 2; i++ {
+		pack, _, err := activePack()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pack.Version != "1.0.0" {
+			t.Errorf("active pack is version %s, want the installed 1.0.0", pack.Version)
+		}
+	}
+	if len(report.Warnings) != 1 || !strings.Contains(report.Warnings[0], "0.9.0") || !strings.Contains(report.Warnings[0], "cleango upgrade") {
+		t.Errorf("warnings are %q, want one about version 0.9.0", report.Warnings)
+	}
+	if n := strings.Count(printed.String(), "0.9.0"); n != 1 {
+		t.Errorf("the warning is printed %d times, want once", n)
+	}
+}
@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// usecasePort is the port a use case generated for a model depends on: an
+// interface of domain/, outside domain/usecases, named after the model
+// (OrderRepository), whose mock the use case test builds it with
+type usecasePort struct {
+	// Type is the interface as the use cases refer to it
+	// (ports.OrderRepository), Name its name and Field the use case field
+	// holding it
+	Type  string
+	Name  string
+	Field string
+	// Mock is the name of its mock in the mocks package
+	Mock string
+	// Methods are the methods of the port, whose calls the test counts
+	Methods []mockMethod
+
+	importPath string
+	alias      string
+}
+
+// findUsecasePort returns the port of model that a new use case depends on,
+// or nil when there is none or it has no mock to test the use case with. The
+// mocks must be kept in sync by the 'add' commands (--mocks) or include the
+// port already.
+func findUsecasePort(modulePath, model string) (*usecasePort, error) {
+	if model == "" {
+		return nil, nil
+	}
+	model = ToPascalCase(model)
+	models, err := listModels()
+	if err != nil {
+		return nil, err
+	}
+	interfaces, err := mockInterfaces(modulePath, &MocksResult{})
+	if err != nil {
+		return nil, err
+	}
+	mocksEnabled := false
+	if manifest, err := LoadManifest(); err == nil {
+		mocksEnabled = manifest.Mocks
+	}
+
+	for _, iface := range interfaces {
+		_, name, _ := strings.Cut(iface.Source, ".")
+		if !strings.HasPrefix(iface.importPath, modulePath+"/domain/") ||
+			iface.importPath == modulePath+"/domain/usecases" || !portOf(name, model, models) {
+			continue
+		}
+		if !mocksEnabled && !FileExists(filepath.Join(mocksDir, ToSnakeCase(iface.Name)+".go")) {
+			continue
+		}
+		return &usecasePort{
+			Type:       iface.Source,
+			Name:       name,
+			Field:      ToCamelCase(name),
+			Mock:       iface.Name,
+			Methods:    iface.Methods,
+			importPath: iface.importPath,
+			alias:      iface.imports[iface.importPath],
+		}, nil
+	}
+	return nil, nil
+}
+
+// portOf reports whether the interface name belongs to model: it is the
+// model's name followed by another word, and no longer model's name
+// (OrderItemRepository is not a port of Order)
+func portOf(name, model string, models []string) bool {
+	rest, ok := strings.CutPrefix(name, model)
+	if !ok || rest == "" {
+		return false
+	}
+	if r, _ := utf8.DecodeRuneInString(rest); !unicode.IsUpper(r) {
+		return false
+	}
+	for _, other := range models {
+		if len(other) > len(model) && strings.HasPrefix(name, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// importSpec is the import of the package declaring the port, as written in
+// the use case
+func (p *usecasePort) importSpec() string {
+	if p.alias != "" {
+		return fmt.Sprintf("%s %q", p.alias, p.importPath)
+	}
+	return p.importPath
+}
@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// userPorts declares the ports of the User and UserProfile models. The port
+// of UserProfile comes first so it would be taken for User if the model name
+// was matched as a plain prefix.
+const userPorts = `package ports
+
+import (
+	"context"
+
+	"example.com/shop/domain/models"
+)
+
+// UserProfileRepository stores user profiles
+type UserProfileRepository interface {
+	Save(ctx context.Context, profile *models.UserProfile) error
+}
+
+// UserRepository stores users
+type UserRepository interface {
+	Save(ctx context.Context, user *models.User) error
+	FindByID(ctx context.Context, id string) (*models.User, error)
+}
+`
+
+// TestUsecaseTestMocksPort generates a use case of a model with a port: the
+// use case takes the port as an option, leaving its constructor callable
+// without arguments, and its test builds it with the port's mock, which
+// invalid inputs must not reach. The project is then compiled and the test
+// run, unless -short is set.
+func TestUsecaseTestMocksPort(t *testing.T) {
+	testProject(t)
+	fields, err := ParseFieldSpecs([]string{"name:string:required"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, model := range []string{"User", "UserProfile"} {
+		if err := GenerateModel(model, fields, false); err != nil {
+			t.Fatalf("add model %s: %v", model, err)
+		}
+	}
+	if err := EnsureDir("domain/ports"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile("domain/ports/user.go", []byte(userPorts), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GenerateMocks(); err != nil {
+		t.Fatalf("mocks: %v", err)
+	}
+
+	if err := GenerateUsecase("CreateUser", "User", true); err != nil {
+		t.Fatalf("add usecase: %v", err)
+	}
+
+	usecase, err := os.ReadFile("domain/usecases/create_user.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"userRepository ports.UserRepository",
+		"func CreateUserWithUserRepository(userRepository ports.UserRepository) CreateUserOption {",
+		"func NewCreateUserUseCase(opts ...CreateUserOption) CreateUserUseCase {",
+	} {
+		if !strings.Contains(string(usecase), want) {
+			t.Errorf("use case lacks %q:\n%s", want, usecase)
+		}
+	}
+	if strings.Contains(string(usecase), "uc.userRepository.") {
+		t.Errorf("use case calls the port before it is implemented:\n%s", usecase)
+	}
+	test, err := os.ReadFile("domain/usecases/create_user_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"usecases.NewCreateUserUseCase(usecases.CreateUserWithUserRepository(userRepository))",
+		"len(userRepository.SaveCalls) + len(userRepository.FindByIDCalls)",
+	} {
+		if !strings.Contains(string(test), want) {
+			t.Errorf("use case test lacks %q:\n%s", want, test)
+		}
+	}
+
+	if testing.Short() {
+		return
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compileGoldenProject(t, dir)
+	cmd := exec.Command("go", "test", "./domain/usecases/")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated use case test fails: %v\n%s", err, output)
+	}
+}
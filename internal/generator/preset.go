@@ -0,0 +1,205 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// presetExt is the extension of the named presets
+const presetExt = ".yaml"
+
+// Preset holds the answers of 'cleango new' so projects can be created
+// repeatedly with the same choices. Empty fields are asked or defaulted as
+// usual.
+type Preset struct {
+	ModulePrefix string            `yaml:"modulePrefix,omitempty"`
+	Framework    string            `yaml:"framework,omitempty"`
+	Database     string            `yaml:"database,omitempty"`
+	Logger       string            `yaml:"logger,omitempty"`
+	Redis        *bool             `yaml:"redis,omitempty"`
+	Kafka        *bool             `yaml:"kafka,omitempty"`
+	Pack         string            `yaml:"pack,omitempty"`
+	Vars         map[string]string `yaml:"vars,omitempty"`
+}
+
+// PresetsDir returns the directory of the named presets: $CLEANGO_PRESETS, or
+// cleango/presets under the user configuration directory
+func PresetsDir() (string, error) {
+	if dir := os.Getenv("CLEANGO_PRESETS"); dir != "" {
+		return dir, nil
+	}
+	config, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(config, "cleango", "presets"), nil
+}
+
+// presetPath returns the file of the named preset
+func presetPath(name string) (string, error) {
+	if !packNamePattern.MatchString(name) {
+		return "", i18n.Error("preset.invalid.name", name)
+	}
+	dir, err := PresetsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+presetExt), nil
+}
+
+// LoadPreset reads and validates the preset source: a YAML file, or else the
+// name of a saved preset
+func LoadPreset(source string) (*Preset, error) {
+	path := source
+	if !FileExists(source) {
+		var err error
+		if path, err = presetPath(source); err != nil {
+			return nil, codedError(CodeNotFound, "preset.source.missing", source)
+		}
+		if !FileExists(path) {
+			return nil, codedError(CodeNotFound, "preset.source.missing_list", source)
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var preset Preset
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	if err := dec.Decode(&preset); err != nil {
+		return nil, i18n.Error("preset.read", path, err)
+	}
+	if err := preset.Validate(); err != nil {
+		return nil, i18n.Error("preset.invalid", path, err)
+	}
+	return &preset, nil
+}
+
+// Validate checks the options of the preset against the allowed values
+func (p *Preset) Validate() error {
+	var problems []string
+	if p.ModulePrefix != "" {
+		if err := ValidateModulePath(p.ModulePrefix); err != nil {
+			problems = append(problems, "modulePrefix: "+err.Error())
+		}
+	}
+	options := []struct {
+		name, value string
+		allowed     []string
+	}{
+		{"framework", p.Framework, Frameworks},
+		{"database", p.Database, Databases},
+		{"logger", p.Logger, Loggers},
+	}
+	for _, option := range options {
+		if option.value == "" {
+			continue
+		}
+		if err := ValidateOption(option.name, option.value, option.allowed); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if p.Pack == "" && len(p.Vars) > 0 {
+		problems = append(problems, i18n.T("preset.vars"))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// SavePreset stores preset under name in the presets directory and returns
+// the path written. An existing preset is kept unless force is set.
+func SavePreset(name string, preset *Preset, force bool) (string, error) {
+	path, err := presetPath(name)
+	if err != nil {
+		return "", err
+	}
+	if FileExists(path) && !force {
+		return "", codedError(CodeAlreadyExists, "preset.exists", name)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, i18n.T("preset.header", name))
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(preset); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+
+	if err := EnsureDir(filepath.Dir(path)); err != nil {
+		return "", err
+	}
+	return path, WriteFile(path, buf.Bytes())
+}
+
+// PresetNames returns the names of the saved presets, sorted
+func PresetNames() ([]string, error) {
+	dir, err := PresetsDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), presetExt) {
+			names = append(names, strings.TrimSuffix(entry.Name(), presetExt))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// RemovePreset deletes the saved preset name
+func RemovePreset(name string) error {
+	path, err := presetPath(name)
+	if err != nil {
+		return err
+	}
+	if err := RemoveFile(path); os.IsNotExist(err) {
+		return codedError(CodeNotFound, "preset.missing", name)
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// PresetFromConfig returns the preset reproducing the choices of config. The
+// module prefix is kept when the module path ends with the project name.
+func PresetFromConfig(config ProjectConfig) *Preset {
+	preset := &Preset{
+		Framework: config.Framework,
+		Database:  config.Database,
+		Logger:    config.Logger,
+		Redis:     &config.UseRedis,
+		Kafka:     &config.UseKafka,
+	}
+	if prefix, ok := strings.CutSuffix(config.ModulePath, "/"+config.Name); ok {
+		preset.ModulePrefix = prefix
+	}
+	if config.Pack != nil {
+		preset.Pack = config.Pack.Name
+		preset.Vars = config.Pack.Vars
+	}
+	return preset
+}
@@ -1,326 +1,391 @@
 package generator
 
 import (
-	"bytes"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"text/template"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
 )
 
-// GenerateProject generates a new Go project with Clean Architecture
-func GenerateProject(targetDir string, config ProjectConfig) error {
+// projectDirs are the directories of the Clean Architecture layout
+var projectDirs = []string{
+	"cmd/api",
+	"config",
+	"domain/errors",
+	"domain/models",
+	"domain/usecases",
+	"infrastructure/adapters/database",
+	"infrastructure/adapters/logger",
+	"infrastructure/entrypoints/http",
+	"migrations",
+}
+
+// GenerateProject generates a new Go project with Clean Architecture. The
+// dependencies are pinned in go.mod and resolved as deps says. When targetDir
+// is missing, the project is built in a staging directory next to it and
+// moved there once complete, so targetDir never holds a partial project. On
+// failure everything written is rolled back and the working directory is
+// restored.
+func GenerateProject(targetDir string, config ProjectConfig, deps DependencyOptions) (err error) {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if err := Conflicts.Validate(); err != nil {
+		return err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return i18n.Error("project.chdir", err)
+	}
+	tx := beginTransaction()
+	defer tx.end(&err)
+	defer func() {
+		if err != nil {
+			os.Chdir(cwd)
+		}
+	}()
+	defer generateProject(config)()
+
+	// A new project directory is built in a staging directory and moved into
+	// place once complete, so a failure never leaves it half-populated
+	projectDir := targetDir
+	if !FileExists(targetDir) {
+		if projectDir, err = tx.stage(targetDir); err != nil {
+			return i18n.Error("err.mkdir", targetDir, err)
+		}
+	}
+
 	// Create directory structure following Clean Architecture
-	dirs := []string{
-		"cmd/api",
-		"config",
-		"domain/models",
-		"domain/usecases",
-		"infrastructure/adapters/database",
-		"infrastructure/adapters/logger",
-		"infrastructure/entrypoints/http",
-		"migrations",
-	}
-
-	for _, dir := range dirs {
-		dirPath := filepath.Join(targetDir, dir)
+	for _, dir := range projectDirs {
+		dirPath := filepath.Join(projectDir, dir)
 		if err := EnsureDir(dirPath); err != nil {
-			return fmt.Errorf("error creating directory %s: %w", dir, err)
+			return i18n.Error("err.mkdir", dir, err)
 		}
 	}
 
 	// Change to target directory
-	if err := os.Chdir(targetDir); err != nil {
-		return fmt.Errorf("error changing to target directory: %w", err)
+	if err := os.Chdir(projectDir); err != nil {
+		return i18n.Error("project.chdir", err)
 	}
 
-	// Initialize go module if not exists
+	// Render every file before writing any
+	files, err := renderProjectFiles(config)
+	if err != nil {
+		return err
+	}
+	manifest, err := NewManifest(config).encode()
+	if err != nil {
+		return i18n.Error("err.create", ManifestFile, err)
+	}
+
+	// Create go.mod with the pinned dependencies, or add them to an existing one
 	if !FileExists("go.mod") {
-		cmd := exec.Command("go", "mod", "init", config.ModulePath)
-		if output, err := cmd.CombinedOutput(); err != nil {
-			return fmt.Errorf("error initializing go module: %w\nOutput: %s", err, output)
+		if err := WriteFile("go.mod", renderGoMod(config)); err != nil {
+			return i18n.Error("err.create", "go.mod", err)
 		}
+	} else if err := requireDependencies(config); err != nil {
+		return i18n.Error("project.require", err)
 	}
 
-	// Generate .gitignore
-	if err := WriteFile(".gitignore", []byte(gitignoreTemplate)); err != nil {
-		return fmt.Errorf("error creating .gitignore: %w", err)
+	// Write the manifest and the project files, keeping a pristine copy of
+	// the latter for 'cleango upgrade'. Existing files, as when re-running
+	// 'cleango new' in the same folder, are handled by the conflict policy.
+	if _, err := Conflicts.write(generatedFile{ManifestFile, manifest}); err != nil {
+		return err
+	}
+	if _, err := writeFiles(files); err != nil {
+		return err
 	}
 
-	// Generate config
-	configPath := filepath.Join("config/config.go")
-	if err := WriteFile(configPath, []byte(configTemplate)); err != nil {
-		return fmt.Errorf("error creating config: %w", err)
+	// Resolve the transitive dependencies and go.sum
+	if err := resolveDependencies(deps); err != nil {
+		return err
 	}
+	if projectDir == targetDir {
+		return nil
+	}
+
+	// Leave the staging directory to move it to the target one
+	if err := os.Chdir(cwd); err != nil {
+		return i18n.Error("project.chdir", err)
+	}
+	if err := tx.commit(projectDir); err != nil {
+		return i18n.Error("err.create", targetDir, err)
+	}
+	if err := os.Chdir(targetDir); err != nil {
+		return i18n.Error("project.chdir", err)
+	}
+	return nil
+}
 
-	// Generate logger
-	loggerPath := filepath.Join("infrastructure/adapters/logger/logger.go")
-	if err := WriteFile(loggerPath, []byte(loggerTemplate)); err != nil {
-		return fmt.Errorf("error creating logger: %w", err)
+// renderProjectFiles renders the files created by 'cleango new' with the
+// current templates
+func renderProjectFiles(config ProjectConfig) ([]generatedFile, error) {
+	var files []generatedFile
+	for _, file := range []struct {
+		path     string
+		template string
+	}{
+		{".gitignore", "gitignore"},
+		{"config/config.go", "config"},
+		{"infrastructure/adapters/logger/logger.go", "logger"},
+	} {
+		render := renderTemplate
+		if strings.HasSuffix(file.path, ".go") {
+			render = renderGoTemplate
+		}
+		content, err := render(file.template, config)
+		if err != nil {
+			return nil, i18n.Error("err.create", file.path, err)
+		}
+		files = append(files, generatedFile{file.path, content})
 	}
 
-	// Generate README with structure explanation
-	readmePath := filepath.Join("README.md")
-	readmeContent := generateReadme(config)
-	if err := WriteFile(readmePath, []byte(readmeContent)); err != nil {
-		return fmt.Errorf("error creating README.md: %w", err)
+	// Domain error model, HTTP error responder and request decoder
+	support, err := renderHTTPSupportFiles(config)
+	if err != nil {
+		return nil, i18n.Error("project.errors", err)
 	}
+	files = append(files, support...)
 
-	// Generate main.go based on framework
+	// README with structure explanation
+	files = append(files, generatedFile{"README.md", []byte(generateReadme(config))})
+
+	// main.go based on framework
 	mainContent, err := generateMainFile(config)
 	if err != nil {
-		return fmt.Errorf("error generating main.go: %w", err)
+		return nil, i18n.Error("err.generate", "main.go", err)
 	}
+	files = append(files, generatedFile{"cmd/api/main.go", mainContent})
 
-	mainPath := filepath.Join("cmd/api/main.go")
-	if err := WriteFile(mainPath, mainContent); err != nil {
-		return fmt.Errorf("error creating main.go: %w", err)
+	// The server runner shared by every entrypoint
+	serverContent, err := renderGoTemplate("mainServer", config)
+	if err != nil {
+		return nil, i18n.Error("err.generate", "server.go", err)
 	}
+	files = append(files, generatedFile{"cmd/api/server.go", serverContent})
 
-	// Generate database-specific files
-	if err := generateDatabaseFiles(config); err != nil {
-		return fmt.Errorf("error generating database files: %w", err)
+	// Database-specific files
+	databaseFiles, err := renderDatabaseFiles(config)
+	if err != nil {
+		return nil, i18n.Error("project.database", err)
 	}
+	files = append(files, databaseFiles...)
 
-	// Generate .env.example
+	// .env.example
 	envContent, err := renderEnvExample(config)
 	if err != nil {
-		return fmt.Errorf("error creating .env.example: %w", err)
-	}
-	if err := WriteFile(".env.example", envContent); err != nil {
-		return fmt.Errorf("error creating .env.example: %w", err)
+		return nil, i18n.Error("err.create", ".env.example", err)
 	}
+	files = append(files, generatedFile{".env.example", envContent})
 
-	// Generate Makefile if PostgreSQL
+	// Makefile if PostgreSQL
 	if config.Database == "postgres" {
 		makefileContent, err := generateMakefile(config)
 		if err != nil {
-			return fmt.Errorf("error generating Makefile: %w", err)
-		}
-		if err := WriteFile("Makefile", makefileContent); err != nil {
-			return fmt.Errorf("error creating Makefile: %w", err)
+			return nil, i18n.Error("err.generate", "Makefile", err)
 		}
+		files = append(files, generatedFile{"Makefile", makefileContent})
 	}
 
-	// Install dependencies
-	fmt.Println("📦 Instalando dependencias...")
-	for _, dep := range config.GetDependencies() {
-		fmt.Printf("   - %s\n", dep)
-		cmd := exec.Command("go", "get", dep)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		if err := cmd.Run(); err != nil {
-			fmt.Printf("⚠️  Advertencia: No se pudo instalar %s: %v\n", dep, err)
-		}
-	}
-
-	// Run go mod tidy
-	fmt.Println("🧹 Ejecutando go mod tidy...")
-	cmd := exec.Command("go", "mod", "tidy")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		fmt.Printf("⚠️  Advertencia: Error ejecutando go mod tidy: %v\n", err)
+	// Extra files of the template pack
+	packFiles, err := renderPackFiles(config)
+	if err != nil {
+		return nil, i18n.Error("project.pack", err)
 	}
+	files = append(files, packFiles...)
 
-	return nil
+	return files, nil
 }
 
 // generateMainFile generates the main.go file based on the framework
 func generateMainFile(config ProjectConfig) ([]byte, error) {
-	var tmplStr string
+	var name string
 
 	switch config.Framework {
 	case "chi":
-		tmplStr = mainChiTemplate
+		name = "mainChi"
 	case "gin":
-		tmplStr = mainGinTemplate
+		name = "mainGin"
 	case "fiber":
-		tmplStr = mainFiberTemplate
+		name = "mainFiber"
 	default:
-		tmplStr = mainNetHTTPTemplate
-	}
-
-	tmpl, err := template.New("main").Parse(tmplStr)
-	if err != nil {
-		return nil, err
+		name = "mainNetHTTP"
 	}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, config); err != nil {
-		return nil, err
-	}
-
-	return buf.Bytes(), nil
+	return renderGoTemplate(name, config)
 }
 
-// generateDatabaseFiles generates database-specific files based on configuration
-func generateDatabaseFiles(config ProjectConfig) error {
+// renderDatabaseFiles renders the database-specific files of the configuration
+func renderDatabaseFiles(config ProjectConfig) ([]generatedFile, error) {
 	templates := map[string]struct {
-		filename string
-		content  string
-		test     string
+		filename     string
+		template     string
+		testTemplate string
 	}{
-		"postgres": {"postgres", postgresTemplate, postgresTestTemplate},
-		"mysql":    {"mysql", mysqlTemplate, mysqlTestTemplate},
-		"mongodb":  {"mongodb", mongoTemplate, mongoTestTemplate},
-		"oracle":   {"oracle", oracleTemplate, oracleTestTemplate},
+		"postgres": {"postgres", "postgres", "postgresTest"},
+		"mysql":    {"mysql", "mysql", "mysqlTest"},
+		"mongodb":  {"mongodb", "mongo", "mongoTest"},
+		"oracle":   {"oracle", "oracle", "oracleTest"},
 	}
 
 	tmpl, ok := templates[config.Database]
 	if !ok {
-		return nil
+		return nil, nil
 	}
 
-	dbPath := filepath.Join("infrastructure/adapters/database", tmpl.filename+".go")
-	if err := WriteFile(dbPath, []byte(tmpl.content)); err != nil {
-		return fmt.Errorf("error creating %s: %w", dbPath, err)
+	content, err := renderGoTemplate(tmpl.template, config)
+	if err != nil {
+		return nil, err
 	}
+	files := []generatedFile{{filepath.Join("infrastructure/adapters/database", tmpl.filename+".go"), content}}
 
-	if tmpl.test != "" {
-		testPath := filepath.Join("infrastructure/adapters/database", tmpl.filename+"_test.go")
-		if err := WriteFile(testPath, []byte(tmpl.test)); err != nil {
-			return fmt.Errorf("error creating %s: %w", testPath, err)
+	if tmpl.testTemplate != "" {
+		test, err := renderGoTemplate(tmpl.testTemplate, config)
+		if err != nil {
+			return nil, err
 		}
+		files = append(files, generatedFile{filepath.Join("infrastructure/adapters/database", tmpl.filename+"_test.go"), test})
 	}
-
-	return nil
+	return files, nil
 }
 
 // generateMakefile generates a Makefile based on configuration
 func generateMakefile(config ProjectConfig) ([]byte, error) {
-	tmpl, err := template.New("makefile").Parse(makefileTemplate)
-	if err != nil {
-		return nil, err
-	}
-
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, config); err != nil {
-		return nil, err
-	}
-
-	return buf.Bytes(), nil
+	return renderTemplate("makefile", config)
 }
 
-// generateReadme generates a README with the project structure
+// generateReadme generates a README with the project structure in the
+// language of the project
 func generateReadme(config ProjectConfig) string {
+	t := func(key string, args ...interface{}) string {
+		return i18n.Translate(config.Lang, "readme."+key, args...)
+	}
+	// tree returns a line of the structure diagram with its aligned comment
+	tree := func(entry, comment string) string {
+		return fmt.Sprintf("%-37s # %s\n", entry, t("tree."+comment))
+	}
+
 	readme := "# " + config.Name + "\n\n"
-	readme += "Proyecto Go con Clean Architecture\n\n"
-	readme += "## Estructura del Proyecto\n\n"
-	readme += "Este proyecto sigue los principios de Clean Architecture:\n\n"
+	readme += t("title") + "\n\n"
+	readme += "## " + t("structure") + "\n\n"
+	readme += t("structure.intro") + "\n\n"
 	readme += "```\n"
 	readme += config.Name + "/\n"
-	readme += "├── cmd/api/                          # Punto de entrada de la aplicación\n"
-	readme += "│   └── main.go\n"
-	readme += "├── config/                           # Configuraciones\n"
+	readme += tree("├── cmd/api/", "cmd")
+	readme += "│   ├── main.go\n"
+	readme += tree("│   └── server.go", "server")
+	readme += tree("├── config/", "config")
 	readme += "│   └── config.go\n"
-	readme += "├── domain/                           # Capa de Dominio (Reglas de Negocio)\n"
-	readme += "│   ├── models/                       # Entidades de dominio\n"
-	readme += "│   └── usecases/                     # Casos de uso (puertos)\n"
-	readme += "├── infrastructure/                   # Capa de Infraestructura\n"
-	readme += "│   ├── adapters/                     # Adaptadores (implementaciones)\n"
-	readme += "│   │   ├── database/                 # Repositorios de base de datos\n"
-	readme += "│   │   └── logger/                   # Sistema de logging\n"
-	readme += "│   └── entrypoints/                  # Puntos de entrada\n"
-	readme += "│       └── http/                     # Handlers HTTP\n"
-	readme += "├── migrations/                       # Migraciones de base de datos\n"
-	readme += "├── .env.example                      # Variables de entorno ejemplo\n"
+	readme += tree("├── domain/", "domain")
+	readme += tree("│   ├── errors/", "errors")
+	readme += tree("│   ├── models/", "models")
+	readme += tree("│   └── usecases/", "usecases")
+	readme += tree("├── infrastructure/", "infrastructure")
+	readme += tree("│   ├── adapters/", "adapters")
+	readme += tree("│   │   ├── database/", "database")
+	readme += tree("│   │   └── logger/", "logger")
+	readme += tree("│   └── entrypoints/", "entrypoints")
+	readme += tree("│       └── http/", "http")
+	readme += tree("├── migrations/", "migrations")
+	readme += tree("├── .env.example", "env")
+	readme += tree("├── cleango.yaml", "manifest")
 	readme += "├── .gitignore\n"
 	readme += "├── go.mod\n"
 	if config.Database == "postgres" {
-		readme += "├── Makefile                          # Comandos útiles\n"
+		readme += tree("├── Makefile", "makefile")
 	}
 	readme += "└── README.md\n"
 	readme += "```\n\n"
-	readme += "## Capas de Clean Architecture\n\n"
-	readme += "### Domain (Dominio)\n"
-	readme += "- **models/**: Entidades de dominio, objetos de negocio puros sin dependencias externas\n"
-	readme += "- **usecases/**: Lógica de negocio, casos de uso de la aplicación (interfaces/puertos)\n\n"
-	readme += "### Infrastructure (Infraestructura)\n"
-	readme += "- **adapters/**: Implementaciones concretas de los puertos definidos en domain\n"
-	readme += "  - **database/**: Repositorios que acceden a la base de datos\n"
-	readme += "  - **logger/**: Sistema de logging\n"
-	readme += "- **entrypoints/**: Puntos de entrada a la aplicación\n"
-	readme += "  - **http/**: Handlers HTTP, controladores REST\n\n"
-	readme += "## Configuración\n\n"
+	readme += "## " + t("layers") + "\n\n"
+	readme += "### " + t("layers.domain") + "\n"
+	readme += "- **errors/**: " + t("layers.errors") + "\n"
+	readme += "- **models/**: " + t("layers.models") + "\n"
+	readme += "- **usecases/**: " + t("layers.usecases") + "\n\n"
+	readme += "### " + t("layers.infrastructure") + "\n"
+	readme += "- **adapters/**: " + t("layers.adapters") + "\n"
+	readme += "  - **database/**: " + t("layers.database") + "\n"
+	readme += "  - **logger/**: " + t("layers.logger") + "\n"
+	readme += "- **entrypoints/**: " + t("layers.entrypoints") + "\n"
+	readme += "  - **http/**: " + t("layers.http") + "\n\n"
+	readme += "## " + t("errors") + "\n\n"
+	readme += t("errors.intro") + "\n\n"
+	readme += "| " + t("errors.error") + " | " + t("errors.status") + " |\n"
+	readme += "|-------|-------------|\n"
+	readme += "| `NotFound` | 404 |\n"
+	readme += "| `Conflict` | 409 |\n"
+	readme += "| `Validation` | 422 |\n"
+	readme += "| `Unauthorized` | 401 |\n"
+	readme += "| `TooLarge` | 413 |\n"
+	readme += "| " + t("errors.other") + " | 500 |\n\n"
+	readme += "## " + t("config") + "\n\n"
 	readme += fmt.Sprintf("- **Framework**: %s\n", config.Framework)
-	readme += fmt.Sprintf("- **Base de datos**: %s\n", config.Database)
+	readme += fmt.Sprintf("- **%s**: %s\n", t("config.database"), config.Database)
 	readme += fmt.Sprintf("- **Redis**: %v\n", config.UseRedis)
 	readme += fmt.Sprintf("- **Kafka**: %v\n\n", config.UseKafka)
 
 	// Add database-specific quick start
 	if config.Database == "postgres" {
-		readme += "## Inicio Rápido con PostgreSQL\n\n"
-		readme += "### 1. Configurar variables de entorno\n\n"
-		readme += "Copia el archivo `.env.example` a `.env` y configura tu URL de PostgreSQL:\n\n"
+		readme += "## " + t("postgres") + "\n\n"
+		readme += "### 1. " + t("postgres.env") + "\n\n"
+		readme += t("postgres.env.intro") + "\n\n"
 		readme += "```bash\n"
 		readme += "cp .env.example .env\n"
-		readme += "# Edita .env con tus credenciales\n"
+		readme += "# " + t("postgres.env.edit") + "\n"
 		readme += "```\n\n"
-		readme += "### 2. Iniciar base de datos (opcional con Docker)\n\n"
+		readme += "### 2. " + t("postgres.db") + "\n\n"
 		readme += "```bash\n"
 		readme += "make db-up\n"
 		readme += "```\n\n"
-		readme += "### 3. Ejecutar tests\n\n"
+		readme += "### 3. " + t("postgres.tests") + "\n\n"
 		readme += "```bash\n"
-		readme += "# Solo tests unitarios (sin base de datos)\n"
+		readme += "# " + t("postgres.tests.short") + "\n"
 		readme += "make test-short\n\n"
-		readme += "# Tests de integración (requiere base de datos)\n"
+		readme += "# " + t("postgres.tests.integration") + "\n"
 		readme += "make test-integration\n\n"
-		readme += "# Todos los tests\n"
+		readme += "# " + t("postgres.tests.all") + "\n"
 		readme += "make test\n"
 		readme += "```\n\n"
-		readme += "### 4. Ejecutar la aplicación\n\n"
+		readme += "### 4. " + t("run") + "\n\n"
 		readme += "```bash\n"
 		readme += "make dev\n"
-		readme += "# o\n"
+		readme += "# " + t("postgres.or") + "\n"
 		readme += "go run ./cmd/api\n"
 		readme += "```\n\n"
 	} else {
-		readme += "## Ejecutar la aplicación\n\n"
+		readme += "## " + t("run") + "\n\n"
 		readme += "```bash\n"
 		readme += "go run ./cmd/api\n"
 		readme += "```\n\n"
 	}
 
-	readme += "## Agregar componentes\n\n"
+	readme += "## " + t("add") + "\n\n"
 	readme += "```bash\n"
-	readme += "# Agregar un nuevo modelo de dominio\n"
+	readme += "# " + t("add.model") + "\n"
 	readme += "cleango add model User\n\n"
-	readme += "# Agregar un nuevo caso de uso\n"
+	readme += "# " + t("add.usecase") + "\n"
 	readme += "cleango add usecase CreateUser\n\n"
-	readme += "# Agregar un nuevo adaptador/repositorio\n"
+	readme += "# " + t("add.adapter") + "\n"
 	readme += "cleango add adapter UserRepository\n\n"
-	readme += "# Agregar un nuevo handler HTTP\n"
+	readme += "# " + t("add.handler") + "\n"
 	readme += "cleango add handler User\n"
 	readme += "```\n\n"
-	readme += "## Principios de Clean Architecture\n\n"
-	readme += "1. **Independencia de frameworks**: El dominio no depende de frameworks\n"
-	readme += "2. **Testeable**: La lógica de negocio puede testearse sin UI, BD, etc.\n"
-	readme += "3. **Independencia de la UI**: La UI puede cambiar sin afectar el dominio\n"
-	readme += "4. **Independencia de la BD**: Puedes cambiar de BD sin afectar el negocio\n"
-	readme += "5. **Independencia de agentes externos**: El dominio no conoce nada del mundo exterior\n\n"
-	readme += "## Flujo de dependencias\n\n"
+	readme += "## " + t("principles") + "\n\n"
+	for i, principle := range []string{"frameworks", "testable", "ui", "database", "external"} {
+		readme += fmt.Sprintf("%d. %s\n", i+1, t("principles."+principle))
+	}
+	readme += "\n## " + t("flow") + "\n\n"
 	readme += "```\n"
 	readme += "entrypoints -> usecases -> models\n"
 	readme += "              ↑\n"
 	readme += "           adapters\n"
 	readme += "```\n\n"
-	readme += "Las dependencias siempre apuntan hacia adentro (hacia el dominio).\n"
+	readme += t("flow.inward") + "\n"
 	return readme
 }
 
 func renderEnvExample(config ProjectConfig) ([]byte, error) {
-	tmpl, err := template.New("env").Parse(envExampleTemplate)
-	if err != nil {
-		return nil, err
-	}
-
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, config); err != nil {
-		return nil, err
-	}
-
-	return buf.Bytes(), nil
+	return renderTemplate("envExample", config)
 }
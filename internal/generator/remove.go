@@ -0,0 +1,285 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// RemovableKinds lists the component kinds RemoveComponent handles
+var RemovableKinds = []string{"usecase", "adapter", "model", "handler"}
+
+// RemoveResult lists what RemoveComponent deleted and unregistered
+type RemoveResult struct {
+	Removed      []string
+	Unregistered []string
+	Mocks        *MocksResult
+}
+
+// componentFiles returns the files created by 'cleango add <kind> <name>',
+// the source first, and the registration line it added to routes.go
+func componentFiles(kind, name string) (files []string, registration string, err error) {
+	snake := ToSnakeCase(name)
+	switch kind {
+	case "usecase":
+		files = []string{filepath.Join("domain/usecases", snake+".go"), filepath.Join("domain/usecases", snake+"_test.go")}
+	case "adapter":
+		files = []string{filepath.Join("infrastructure/adapters/database", snake+".go"), filepath.Join("infrastructure/adapters/database", snake+"_test.go")}
+	case "model":
+		files = []string{filepath.Join("domain/models", snake+".go"), filepath.Join("domain/models", snake+"_test.go")}
+	case "handler":
+		files = []string{filepath.Join("infrastructure/entrypoints/http", snake+"_handler.go"), filepath.Join("infrastructure/entrypoints/http", snake+"_handler_test.go")}
+		registration = fmt.Sprintf("New%sHandler().RegisterRoutes(r)", ToPascalCase(name))
+	default:
+		return nil, "", i18n.Error("remove.kind", kind, strings.Join(RemovableKinds, ", "))
+	}
+	return files, registration, nil
+}
+
+// RemoveComponent undoes 'cleango add <kind> <name>': it deletes the files
+// the generator created, removes the route registration of handlers and
+// refreshes the GraphQL schema, the OpenAPI spec and the mocks. It refuses when the files were
+// modified since generation or other code still references the component,
+// unless force is set. On failure everything is rolled back.
+func RemoveComponent(kind, name string, force bool) (_ *RemoveResult, err error) {
+	defer beginTransaction().end(&err)
+
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, registration, err := componentFiles(kind, name)
+	if err != nil {
+		return nil, err
+	}
+	if !FileExists(candidates[0]) {
+		return nil, codedError(CodeNotFound, "remove.missing", candidates[0])
+	}
+
+	var files, modified []string
+	for _, file := range candidates {
+		if !FileExists(file) {
+			continue
+		}
+		files = append(files, file)
+		pristine, err := isPristine(file)
+		if err != nil {
+			return nil, err
+		}
+		if !pristine {
+			modified = append(modified, file)
+		}
+	}
+
+	// The registration is removed in memory first, so it does not count as a
+	// reference to the handler
+	routesPath := filepath.Join("infrastructure/entrypoints/http", "routes.go")
+	var routes []byte
+	if registration != "" {
+		if content, err := os.ReadFile(routesPath); err == nil {
+			routes = removeLine(content, registration)
+		}
+	}
+
+	references, err := findReferences(config.ModulePath, files, map[string][]byte{routesPath: routes})
+	if err != nil {
+		return nil, err
+	}
+
+	if !force {
+		var problems []string
+		if len(modified) > 0 {
+			problems = append(problems, i18n.T("remove.modified", strings.Join(modified, ", ")))
+		}
+		if len(references) > 0 {
+			problems = append(problems, i18n.T("remove.referenced", strings.Join(references, "\n   ")))
+		}
+		if len(problems) > 0 {
+			return nil, codedError(CodeConflict, "remove.refused", kind, name, strings.Join(problems, "; "))
+		}
+	}
+
+	result := &RemoveResult{}
+	if routes != nil {
+		if err := WriteFile(routesPath, routes); err != nil {
+			return nil, err
+		}
+		result.Unregistered = append(result.Unregistered, fmt.Sprintf("%s: %s", routesPath, registration))
+	}
+	for _, file := range files {
+		if err := RemoveFile(file); err != nil {
+			return nil, err
+		}
+		for _, bookkeeping := range []string{pristinePath(file), insertedPath(file)} {
+			if FileExists(bookkeeping) {
+				if err := RemoveFile(bookkeeping); err != nil {
+					return nil, err
+				}
+			}
+		}
+		result.Removed = append(result.Removed, file)
+	}
+
+	if err := RefreshGraphQL(); err != nil {
+		return nil, i18n.Error("err.update.graphql", err)
+	}
+	if err := RefreshOpenAPI(); err != nil {
+		return nil, i18n.Error("err.update.openapi", err)
+	}
+	if FileExists(mocksDir) {
+		if result.Mocks, err = GenerateMocks(); err != nil {
+			return nil, i18n.Error("add.err.mocks", err)
+		}
+	}
+	return result, nil
+}
+
+// removeLine returns content without the lines equal to line once trimmed,
+// or nil when there is none
+func removeLine(content []byte, line string) []byte {
+	lines := strings.Split(string(content), "\n")
+	kept := lines[:0]
+	for _, existing := range lines {
+		if strings.TrimSpace(existing) != strings.TrimSpace(line) {
+			kept = append(kept, existing)
+		}
+	}
+	if len(kept) == len(lines) {
+		return nil
+	}
+	return []byte(strings.Join(kept, "\n"))
+}
+
+// findReferences lists the uses, outside files, of the top-level identifiers
+// declared in files. Mocks and GraphQL resolvers are skipped, since they are
+// regenerated after the removal. overrides replaces the content of files
+// about to change.
+func findReferences(modulePath string, files []string, overrides map[string][]byte) ([]string, error) {
+	fset := token.NewFileSet()
+	removed := map[string]bool{}
+	names := map[string]bool{}
+	dir := filepath.ToSlash(filepath.Dir(files[0]))
+	var pkgName string
+	for _, path := range files {
+		removed[filepath.ToSlash(path)] = true
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, i18n.Error("err.read", path, err)
+		}
+		if !strings.HasSuffix(path, "_test.go") {
+			pkgName = file.Name.Name
+		}
+		for name := range declaredNames(file) {
+			names[name] = true
+		}
+	}
+	importPath := modulePath + "/" + dir
+
+	var references []string
+	skip := map[string]bool{filepath.Join(graphqlDir, "resolvers.go"): true}
+	err := filepath.WalkDir(".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if path != "." && (name == "vendor" || name == "testdata" || name == mocksDir || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel := filepath.ToSlash(path)
+		if !strings.HasSuffix(path, ".go") || removed[rel] || skip[path] {
+			return nil
+		}
+
+		var src interface{}
+		if content, ok := overrides[path]; ok && content != nil {
+			src = content
+		}
+		file, err := parser.ParseFile(fset, path, src, 0)
+		if err != nil {
+			return i18n.Error("err.read", path, err)
+		}
+
+		add := func(ident *ast.Ident) {
+			pos := fset.Position(ident.Pos())
+			references = append(references, i18n.T("remove.uses", rel, pos.Line, ident.Name))
+		}
+
+		// Same package: uses of other files' declarations are unresolved
+		if filepath.ToSlash(filepath.Dir(path)) == dir && file.Name.Name == pkgName {
+			for _, ident := range file.Unresolved {
+				if names[ident.Name] {
+					add(ident)
+				}
+			}
+			return nil
+		}
+
+		aliases := map[string]bool{}
+		for _, spec := range file.Imports {
+			if p, _ := strconv.Unquote(spec.Path.Value); p == importPath {
+				alias := importName(p)
+				if spec.Name != nil {
+					alias = spec.Name.Name
+				}
+				aliases[alias] = true
+			}
+		}
+		if len(aliases) == 0 {
+			return nil
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if x, ok := sel.X.(*ast.Ident); ok && aliases[x.Name] && names[sel.Sel.Name] {
+					add(sel.Sel)
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(references)
+	return references, nil
+}
+
+// declaredNames returns the top-level types, functions, variables and
+// constants declared in file
+func declaredNames(file *ast.File) map[string]bool {
+	names := map[string]bool{}
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				names[d.Name.Name] = true
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					names[s.Name.Name] = true
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						names[name.Name] = true
+					}
+				}
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRemoveHandlerRefreshesOpenAPI removes a handler: the OpenAPI spec and
+// its served copy no longer list its routes
+func TestRemoveHandlerRefreshesOpenAPI(t *testing.T) {
+	testProject(t)
+	for _, model := range []string{"Client", "Order"} {
+		if err := GenerateModel(model, nil, false); err != nil {
+			t.Fatalf("add model %s: %v", model, err)
+		}
+		if err := GenerateHandler(model, "", false); err != nil {
+			t.Fatalf("add handler %s: %v", model, err)
+		}
+	}
+	if _, err := GenerateOpenAPI(OpenAPIOptions{Output: "openapi.yaml", Serve: true}); err != nil {
+		t.Fatalf("openapi: %v", err)
+	}
+
+	if _, err := RemoveComponent("handler", "Client", true); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GenerateOpenAPI(OpenAPIOptions{Output: "openapi.yaml", Check: true}); err != nil {
+		t.Error(err)
+	}
+	for _, path := range []string{"openapi.yaml", filepath.Join("infrastructure/entrypoints/http", "openapi.yaml")} {
+		spec, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(spec), "/clients") || !strings.Contains(string(spec), "/orders") {
+			t.Errorf("%s does not follow the removal:\n%s", path, spec)
+		}
+	}
+}
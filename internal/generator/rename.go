@@ -0,0 +1,504 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/YeridStick/cleango/internal/merge"
+)
+
+// FileChange is a file rewritten, and possibly moved, by a refactoring
+type FileChange struct {
+	Path    string
+	NewPath string
+	Old     []byte
+	New     []byte
+}
+
+// RenamePlan holds the changes of a rename before they are applied
+type RenamePlan struct {
+	From    string
+	To      string
+	Changes []FileChange
+
+	renamer *renamer
+}
+
+// Diff returns the unified diff of the plan, with renamed files shown as
+// moves
+func (p *RenamePlan) Diff() string {
+	var out strings.Builder
+	for _, change := range p.Changes {
+		if change.NewPath != change.Path {
+			fmt.Fprintln(&out, i18n.T("rename.diff.move", change.Path, change.NewPath))
+		}
+		out.WriteString(merge.Unified("a/"+filepath.ToSlash(change.Path), "b/"+filepath.ToSlash(change.NewPath), change.Old, change.New))
+	}
+	return out.String()
+}
+
+// Apply writes the changes, moves the renamed files along with their
+// pristine copies and the lines recorded as inserted into them, and
+// regenerates the GraphQL schema, the OpenAPI spec and
+// the mocks. On failure everything is rolled back.
+func (p *RenamePlan) Apply() (err error) {
+	defer beginTransaction().end(&err)
+
+	for _, change := range p.Changes {
+		if err := EnsureDir(filepath.Dir(change.NewPath)); err != nil {
+			return err
+		}
+		if err := WriteFile(change.NewPath, change.New); err != nil {
+			return err
+		}
+		if change.NewPath != change.Path {
+			if err := RemoveFile(change.Path); err != nil {
+				return err
+			}
+		}
+
+		// A file untouched since generation stays untouched after the rename
+		base, err := os.ReadFile(pristinePath(change.Path))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if renamed, err := p.renamer.rewrite(change.Path, base); err == nil {
+			base = renamed
+		}
+		if change.NewPath != change.Path {
+			if err := RemoveFile(pristinePath(change.Path)); err != nil {
+				return err
+			}
+		}
+		if err := recordPristine([]generatedFile{{change.NewPath, base}}); err != nil {
+			return err
+		}
+
+		// The lines inserted since are renamed like the file, or they would
+		// be taken for edits by hand
+		inserted, err := os.ReadFile(insertedPath(change.Path))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if change.NewPath != change.Path {
+			if err := RemoveFile(insertedPath(change.Path)); err != nil {
+				return err
+			}
+		}
+		if err := EnsureDir(filepath.Dir(insertedPath(change.NewPath))); err != nil {
+			return err
+		}
+		if err := WriteFile(insertedPath(change.NewPath), p.renamer.lines(inserted)); err != nil {
+			return err
+		}
+	}
+
+	if err := RefreshGraphQL(); err != nil {
+		return i18n.Error("err.update.graphql", err)
+	}
+	if err := RefreshOpenAPI(); err != nil {
+		return i18n.Error("err.update.openapi", err)
+	}
+	if FileExists(mocksDir) {
+		if _, err := GenerateMocks(); err != nil {
+			return i18n.Error("add.err.mocks", err)
+		}
+	}
+	return nil
+}
+
+// PlanRenameModel plans renaming the model from to to across every layer:
+// identifiers derived from its name (CustomerInput, NewCustomerHandler,
+// customerRepository...), doc comments, route paths, file names and
+// migrations. Identifiers are rewritten from the syntax tree, so selectors on
+// packages outside the module are never touched.
+func PlanRenameModel(from, to string) (*RenamePlan, error) {
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateName(i18n.T("kind.model"), to); err != nil {
+		return nil, err
+	}
+	from, to = ToPascalCase(from), ToPascalCase(to)
+	if from == "" {
+		return nil, i18n.Error("rename.from.empty")
+	}
+	if from == to {
+		return nil, i18n.Error("rename.same")
+	}
+	modelPath := filepath.Join("domain/models", ToSnakeCase(from)+".go")
+	if !FileExists(modelPath) {
+		return nil, codedError(CodeNotFound, "rename.model.missing", from, modelPath)
+	}
+	if target := filepath.Join("domain/models", ToSnakeCase(to)+".go"); FileExists(target) {
+		return nil, codedError(CodeAlreadyExists, "rename.model.exists", to, target)
+	}
+
+	models, err := listModels()
+	if err != nil {
+		return nil, err
+	}
+	r := newRenamer(config.ModulePath, from, to, models)
+	plan := &RenamePlan{From: from, To: to, renamer: r}
+
+	declared := map[string]map[string]bool{}
+	renamed := map[string]map[string]string{}
+	err = filepath.WalkDir(".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if path != "." && (name == "vendor" || name == "testdata" || name == mocksDir || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		isGo := strings.HasSuffix(path, ".go")
+		isMigration := strings.HasPrefix(filepath.ToSlash(path), "migrations/")
+		if (!isGo && !isMigration) || path == filepath.Join(graphqlDir, "resolvers.go") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		var updated []byte
+		if isGo {
+			file, err := parser.ParseFile(token.NewFileSet(), path, content, parser.SkipObjectResolution)
+			if err != nil {
+				return i18n.Error("err.read", path, err)
+			}
+			dir := filepath.Dir(path)
+			if declared[dir] == nil {
+				declared[dir], renamed[dir] = map[string]bool{}, map[string]string{}
+			}
+			for name := range declaredNames(file) {
+				declared[dir][name] = true
+				if newName := r.ident(name, false); newName != name {
+					renamed[dir][newName] = name
+				}
+			}
+
+			if updated, err = r.rewrite(path, content); err != nil {
+				return err
+			}
+		} else {
+			updated = r.text(content)
+		}
+
+		newPath := filepath.Join(filepath.Dir(path), r.fileName(filepath.Base(path)))
+		if !bytes.Equal(updated, content) || newPath != path {
+			plan.Changes = append(plan.Changes, FileChange{Path: path, NewPath: newPath, Old: content, New: updated})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Refuse renames that would collide with existing declarations or files
+	var conflicts []string
+	for dir, names := range renamed {
+		for newName, oldName := range names {
+			if declared[dir][newName] {
+				conflicts = append(conflicts, i18n.T("rename.declared", filepath.ToSlash(dir), oldName, newName))
+			}
+		}
+	}
+	for _, change := range plan.Changes {
+		if change.NewPath != change.Path && FileExists(change.NewPath) {
+			conflicts = append(conflicts, i18n.T("rename.file.exists", change.Path, change.NewPath))
+		}
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return nil, codedError(CodeConflict, "rename.conflicts", strings.Join(conflicts, "\n   "))
+	}
+
+	sort.Slice(plan.Changes, func(i, j int) bool { return plan.Changes[i].Path < plan.Changes[j].Path })
+	return plan, nil
+}
+
+// renamer maps the forms of a name to the forms of the new one
+type renamer struct {
+	modulePath string
+
+	// idents are the Pascal and camel case forms, singular and plural, of
+	// the name and of the other models, longest first. The other models map
+	// to themselves, so a model holding the name (OrderItem when renaming
+	// Order) is matched as a whole and kept.
+	idents []nameForm
+	// files and routes map the snake case words of file names and the URL
+	// path segments in the same way
+	files  map[string]string
+	routes map[string]string
+
+	snake, snakePlural [2]string
+
+	sqlWords *regexp.Regexp
+	fileWord *regexp.Regexp
+	route    *regexp.Regexp
+}
+
+// nameForm is a form of a name and what it becomes
+type nameForm struct {
+	from, to string
+}
+
+// newRenamer renames from to to, keeping the names of the other models of
+// the project
+func newRenamer(modulePath, from, to string, models []string) *renamer {
+	plural := func(name string) string { return ToPascalCase(Pluralize(ToSnakeCase(name))) }
+	r := &renamer{
+		modulePath:  modulePath,
+		files:       map[string]string{},
+		routes:      map[string]string{},
+		snake:       [2]string{ToSnakeCase(from), ToSnakeCase(to)},
+		snakePlural: [2]string{Pluralize(ToSnakeCase(from)), Pluralize(ToSnakeCase(to))},
+	}
+
+	idents := map[string]string{}
+	add := func(from, to string) {
+		for _, form := range [][2]string{
+			{from, to},
+			{plural(from), plural(to)},
+			{ToCamelCase(from), ToCamelCase(to)},
+			{ToCamelCase(plural(from)), ToCamelCase(plural(to))},
+		} {
+			idents[form[0]] = form[1]
+		}
+		r.files[ToSnakeCase(from)] = ToSnakeCase(to)
+		r.files[Pluralize(ToSnakeCase(from))] = Pluralize(ToSnakeCase(to))
+		r.routes[ToResourcePath(from)] = ToResourcePath(to)
+	}
+	for _, model := range models {
+		if model != from {
+			add(model, model)
+		}
+	}
+	add(from, to)
+
+	for _, form := range longestFirst(idents) {
+		r.idents = append(r.idents, nameForm{form, idents[form]})
+	}
+	r.sqlWords = regexp.MustCompile(`\b(` + regexp.QuoteMeta(r.snakePlural[0]) + `|` + regexp.QuoteMeta(r.snake[0]) + `)\b`)
+	r.fileWord = regexp.MustCompile(`(^|[_.-])(` + alternation(r.files) + `)([_.-]|$)`)
+	r.route = regexp.MustCompile(`/(` + alternation(r.routes) + `)([^a-z0-9-]|$)`)
+	return r
+}
+
+// longestFirst returns the keys of forms, longest first
+func longestFirst(forms map[string]string) []string {
+	keys := make([]string, 0, len(forms))
+	for key := range forms {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// alternation returns a regular expression matching the keys of forms,
+// preferring the longest
+func alternation(forms map[string]string) string {
+	keys := longestFirst(forms)
+	for i, key := range keys {
+		keys[i] = regexp.QuoteMeta(key)
+	}
+	return strings.Join(keys, "|")
+}
+
+// ident renames the name parts of an identifier: Pascal case words anywhere
+// (CreateCustomerInput) and a camel case word at the start
+// (customerRepository). In comments, word is set and camel case words may
+// start any word.
+func (r *renamer) ident(name string, word bool) string {
+	var out strings.Builder
+	for i := 0; i < len(name); {
+		matched := false
+		for _, form := range r.idents {
+			camel := unicode.IsLower(rune(form.from[0]))
+			if camel && i != 0 && !(word && wordStart(name, i)) {
+				continue
+			}
+			if !strings.HasPrefix(name[i:], form.from) || !wordEnd(name, i+len(form.from)) {
+				continue
+			}
+			out.WriteString(form.to)
+			i += len(form.from)
+			matched = true
+			break
+		}
+		if !matched {
+			_, size := utf8.DecodeRuneInString(name[i:])
+			out.WriteString(name[i : i+size])
+			i += size
+		}
+	}
+	return out.String()
+}
+
+// wordEnd reports whether a word of an identifier can end at i: at the end,
+// or before an upper case letter, a digit or an underscore
+func wordEnd(s string, i int) bool {
+	if i >= len(s) {
+		return true
+	}
+	next, _ := utf8.DecodeRuneInString(s[i:])
+	return unicode.IsUpper(next) || unicode.IsDigit(next) || next == '_' || !unicode.IsLetter(next)
+}
+
+// wordStart reports whether a word of a comment starts at i
+func wordStart(s string, i int) bool {
+	prev, _ := utf8.DecodeLastRuneInString(s[:i])
+	return !unicode.IsLetter(prev) && !unicode.IsDigit(prev)
+}
+
+// fileName renames the snake case words of a file name
+func (r *renamer) fileName(name string) string {
+	return r.fileWord.ReplaceAllStringFunc(name, func(m string) string {
+		parts := r.fileWord.FindStringSubmatch(m)
+		return parts[1] + r.files[parts[2]] + parts[3]
+	})
+}
+
+// routePaths renames the URL path segments of a string literal
+func (r *renamer) routePaths(s string) string {
+	return r.route.ReplaceAllStringFunc(s, func(m string) string {
+		parts := r.route.FindStringSubmatch(m)
+		return "/" + r.routes[parts[1]] + parts[2]
+	})
+}
+
+// text renames the table and column names of a migration
+func (r *renamer) text(content []byte) []byte {
+	return r.sqlWords.ReplaceAllFunc(content, func(m []byte) []byte {
+		if string(m) == r.snakePlural[0] {
+			return []byte(r.snakePlural[1])
+		}
+		return []byte(r.snake[1])
+	})
+}
+
+// lines renames the identifiers and route paths of loose lines of Go code,
+// such as those recorded as inserted by the generators
+func (r *renamer) lines(content []byte) []byte {
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		lines[i] = r.routePaths(r.ident(line, true))
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// edit replaces the bytes between start and end
+type edit struct {
+	start, end int
+	text       string
+}
+
+// rewrite renames the identifiers, comments and route paths of a Go file
+// and gofmts the result
+func (r *renamer) rewrite(path string, content []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, content, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, i18n.Error("err.read", path, err)
+	}
+
+	// Packages outside the module keep their identifiers
+	external := map[string]bool{}
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		if importPath == r.modulePath || strings.HasPrefix(importPath, r.modulePath+"/") {
+			continue
+		}
+		name := importName(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		external[name] = true
+	}
+
+	var edits []edit
+	offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.ImportSpec:
+			return false
+		case *ast.SelectorExpr:
+			if pkg, ok := x.X.(*ast.Ident); ok && external[pkg.Name] {
+				return false
+			}
+		case *ast.Ident:
+			if x == file.Name {
+				return false
+			}
+			if renamed := r.ident(x.Name, false); renamed != x.Name {
+				edits = append(edits, edit{offset(x.Pos()), offset(x.End()), renamed})
+			}
+		case *ast.BasicLit:
+			if x.Kind == token.STRING {
+				if renamed := r.routePaths(x.Value); renamed != x.Value {
+					edits = append(edits, edit{offset(x.Pos()), offset(x.End()), renamed})
+				}
+			}
+		}
+		return true
+	})
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			if renamed := r.ident(comment.Text, true); renamed != comment.Text {
+				edits = append(edits, edit{offset(comment.Pos()), offset(comment.End()), renamed})
+			}
+		}
+	}
+	if len(edits) == 0 {
+		return content, nil
+	}
+
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	var out bytes.Buffer
+	last := 0
+	for _, e := range edits {
+		out.Write(content[last:e.start])
+		out.WriteString(e.text)
+		last = e.end
+	}
+	out.Write(content[last:])
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, i18n.Error("err.format", path, err)
+	}
+	return formatted, nil
+}
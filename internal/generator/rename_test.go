@@ -0,0 +1,165 @@
+package generator
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// testProject generates a chi project without a database in a temporary
+// directory and changes to it for the rest of the test
+func testProject(t *testing.T) {
+	t.Helper()
+	output := Output
+	Output = io.Discard
+	t.Cleanup(func() { Output = output })
+	t.Setenv("CLEANGO_TEMPLATES", t.TempDir())
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(cwd) })
+
+	config := ProjectConfig{
+		Name:       "shop",
+		ModulePath: "example.com/shop",
+		Framework:  "chi",
+		Database:   "none",
+		Logger:     Loggers[0],
+		Lang:       "es",
+	}
+	if err := GenerateProject(filepath.Join(t.TempDir(), config.Name), config, DependencyOptions{Skip: true}); err != nil {
+		t.Fatalf("new: %v", err)
+	}
+}
+
+// TestRenameModelKeepsLongerModels renames a model whose name starts another
+// model's: the other model, its files and its routes keep their names, and
+// the OpenAPI spec follows the new routes
+func TestRenameModelKeepsLongerModels(t *testing.T) {
+	testProject(t)
+	for _, model := range []string{"Order", "OrderItem"} {
+		if err := GenerateModel(model, nil, false); err != nil {
+			t.Fatalf("add model %s: %v", model, err)
+		}
+		if err := GenerateHandler(model, "", false); err != nil {
+			t.Fatalf("add handler %s: %v", model, err)
+		}
+	}
+
+	if _, err := GenerateOpenAPI(OpenAPIOptions{Output: "openapi.yaml", Serve: true}); err != nil {
+		t.Fatalf("openapi: %v", err)
+	}
+
+	plan, err := PlanRenameModel("Order", "Purchase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, change := range plan.Changes {
+		if strings.Contains(change.Path, "order_item") || strings.Contains(change.NewPath, "order_item") {
+			t.Errorf("%s is changed, to %s", change.Path, change.NewPath)
+		}
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{
+		"domain/models/purchase.go",
+		"domain/models/order_item.go",
+		"infrastructure/entrypoints/http/purchase_handler.go",
+		"infrastructure/entrypoints/http/order_item_handler.go",
+	} {
+		if !FileExists(path) {
+			t.Errorf("%s is missing", path)
+		}
+	}
+	for _, path := range []string{"domain/models/order.go", "domain/models/purchase_item.go"} {
+		if FileExists(path) {
+			t.Errorf("%s exists", path)
+		}
+	}
+
+	item, err := os.ReadFile("domain/models/order_item.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(item), "type OrderItem struct") || strings.Contains(string(item), "Purchase") {
+		t.Errorf("order_item.go is renamed:\n%s", item)
+	}
+
+	for path, want := range map[string][]string{
+		"infrastructure/entrypoints/http/routes.go":             {"NewPurchaseHandler", "NewOrderItemHandler"},
+		"infrastructure/entrypoints/http/purchase_handler.go":   {`"/purchases/{id}"`, "type PurchaseHandler struct"},
+		"infrastructure/entrypoints/http/order_item_handler.go": {`"/order-items/{id}"`, "type OrderItemHandler struct"},
+	} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range want {
+			if !strings.Contains(string(content), w) {
+				t.Errorf("%s does not hold %s:\n%s", path, w, content)
+			}
+		}
+		if strings.Contains(string(content), "PurchaseItem") || strings.Contains(string(content), "purchase-items") {
+			t.Errorf("%s renames OrderItem:\n%s", path, content)
+		}
+	}
+
+	// The spec and its served copy are regenerated
+	if _, err := GenerateOpenAPI(OpenAPIOptions{Output: "openapi.yaml", Check: true}); err != nil {
+		t.Error(err)
+	}
+	spec, err := os.ReadFile("openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(spec), "/purchases/{id}:") || strings.Contains(string(spec), "/orders") {
+		t.Errorf("openapi.yaml does not follow the rename:\n%s", spec)
+	}
+}
+
+// TestRenameModelKeepsFilesPristine renames a model right after generating
+// it: the lines 'add handler' inserted into routes.go are renamed with the
+// file, so doctor reports no file edited by hand
+func TestRenameModelKeepsFilesPristine(t *testing.T) {
+	testProject(t)
+	if err := GenerateModel("Customer", nil, false); err != nil {
+		t.Fatalf("add model: %v", err)
+	}
+	if err := GenerateHandler("Customer", "", false); err != nil {
+		t.Fatalf("add handler: %v", err)
+	}
+
+	plan, err := PlanRenameModel("Customer", "Client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatal(err)
+	}
+
+	inserted, err := os.ReadFile(insertedPath("infrastructure/entrypoints/http/routes.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(inserted), "NewClientHandler") || strings.Contains(string(inserted), "Customer") {
+		t.Errorf("the inserted lines are not renamed:\n%s", inserted)
+	}
+
+	checks, err := Doctor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, check := range checks {
+		if check.Name == i18n.T("doctor.modified") && check.Status != DoctorOK {
+			t.Errorf("doctor reports %s after the rename: %s", check.Status, check.Message)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package generator
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// Output receives the progress messages of the generators. The CLI discards
+// them when it prints a JSON result instead.
+var Output io.Writer = os.Stdout
+
+// Report records what a command changed on disk and the external commands it
+// ran, for the JSON output of the CLI. Paths are relative to the working
+// directory at the time of the change.
+type Report struct {
+	Created  []string     `json:"created"`
+	Modified []string     `json:"modified"`
+	Removed  []string     `json:"removed"`
+	Skipped  []string     `json:"skipped"`
+	Commands []CommandRun `json:"commands"`
+	Warnings []string     `json:"warnings"`
+	// Conflicts are the existing files the generators found in their way
+	Conflicts []Conflict `json:"conflicts"`
+	// RolledBack lists the changes undone after a generator failed
+	RolledBack *Rollback `json:"rolledBack,omitempty"`
+}
+
+// CommandRun is an external command run by a generator
+type CommandRun struct {
+	Command  string `json:"command"`
+	ExitCode int    `json:"exitCode"`
+}
+
+// activeReport is the report being recorded, if any
+var activeReport *Report
+
+// StartReport starts recording the changes of the generators into a new
+// report and returns it
+func StartReport() *Report {
+	activeReport = &Report{}
+	return activeReport
+}
+
+// Skip records a file left untouched because it already existed
+func (r *Report) Skip(path string) {
+	r.Skipped = appendUnique(r.Skipped, reportPath(path))
+}
+
+// Warn records a warning
+func (r *Report) Warn(message string) {
+	r.Warnings = appendUnique(r.Warnings, message)
+}
+
+// recordWrite records the write of path, which existed before or not. Files
+// created and then rewritten by the same command are reported as created.
+func recordWrite(path string, existed bool) {
+	if activeReport == nil || isPristineCopy(path) {
+		return
+	}
+	path = reportPath(path)
+	switch {
+	case contains(activeReport.Created, path), contains(activeReport.Modified, path):
+	case existed:
+		activeReport.Modified = append(activeReport.Modified, path)
+	default:
+		activeReport.Created = append(activeReport.Created, path)
+	}
+}
+
+// recordRemove records the removal of path
+func recordRemove(path string) {
+	if activeReport == nil || isPristineCopy(path) {
+		return
+	}
+	activeReport.Removed = appendUnique(activeReport.Removed, reportPath(path))
+}
+
+// recordConflict records the strategy applied to an existing file
+func recordConflict(conflict Conflict) {
+	if activeReport != nil {
+		activeReport.Conflicts = append(activeReport.Conflicts, conflict)
+	}
+}
+
+// reportMark is the length of the lists of a report at some point
+type reportMark struct {
+	created, modified, removed, skipped, conflicts int
+}
+
+// markReport returns the current length of the lists of the active report
+func markReport() reportMark {
+	if activeReport == nil {
+		return reportMark{}
+	}
+	r := activeReport
+	return reportMark{len(r.Created), len(r.Modified), len(r.Removed), len(r.Skipped), len(r.Conflicts)}
+}
+
+// recordRollback records the changes undone after a generator failed,
+// forgetting those recorded since mark: they are no longer on disk
+func recordRollback(mark reportMark, rollback *Rollback) {
+	if activeReport == nil {
+		return
+	}
+	r := activeReport
+	r.Created = r.Created[:mark.created]
+	r.Modified = r.Modified[:mark.modified]
+	r.Removed = r.Removed[:mark.removed]
+	r.Skipped = r.Skipped[:mark.skipped]
+	r.Conflicts = r.Conflicts[:mark.conflicts]
+	r.RolledBack = rollback
+}
+
+// recordWarning records a warning printed by a generator
+func recordWarning(message string) {
+	if activeReport != nil {
+		activeReport.Warn(message)
+	}
+}
+
+// runCommand runs cmd and records its command line and exit code
+func runCommand(cmd *exec.Cmd) error {
+	err := cmd.Run()
+	recordCommand(cmd, err)
+	return err
+}
+
+// recordCommand records a command already run, with the error it returned
+func recordCommand(cmd *exec.Cmd, err error) {
+	if activeReport == nil {
+		return
+	}
+	code := 0
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		code = exitErr.ExitCode()
+	case err != nil:
+		code = -1
+	}
+	line := append([]string{filepath.Base(cmd.Path)}, cmd.Args[1:]...)
+	activeReport.Commands = append(activeReport.Commands, CommandRun{Command: strings.Join(line, " "), ExitCode: code})
+}
+
+// isPristineCopy reports whether path is one of the original copies kept for
+// upgrade, or the lines inserted into them since, which are bookkeeping
+// rather than project files
+func isPristineCopy(path string) bool {
+	path = filepath.ToSlash(filepath.Clean(path))
+	return strings.HasPrefix(path, pristineDir+"/") || strings.HasPrefix(path, insertedDir+"/")
+}
+
+// reportPath returns path in the form used by reports
+func reportPath(path string) string {
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+func appendUnique(list []string, value string) []string {
+	if contains(list, value) {
+		return list
+	}
+	return append(list, value)
+}
+
+// Error codes of the JSON output. They are part of the contract with the
+// tools that wrap the CLI: new codes may be added, existing ones never change.
+const (
+	CodeInvalidName       = "invalid_name"
+	CodeInvalidModulePath = "invalid_module_path"
+	CodeInvalidOption     = "invalid_option"
+	CodeNotAProject       = "not_a_project"
+	CodeAlreadyExists     = "already_exists"
+	CodeNotFound          = "not_found"
+	CodeConflict          = "conflict"
+	CodeDependencies      = "dependency_error"
+	CodeCheckFailed       = "check_failed"
+	CodeCancelled         = "cancelled"
+	CodeUsage             = "usage_error"
+	CodeFailed            = "command_failed"
+)
+
+// CodedError is an error with a stable code for the JSON output
+type CodedError struct {
+	Code string
+	Err  error
+}
+
+func (e *CodedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CodedError) Unwrap() error {
+	return e.Err
+}
+
+// codedError returns a new error with code and the message key formatted
+// with args, as i18n.Error
+func codedError(code, key string, args ...interface{}) error {
+	return &CodedError{Code: code, Err: i18n.Error(key, args...)}
+}
+
+// WithCode attaches code to err, keeping a code already attached
+func WithCode(code string, err error) error {
+	var coded *CodedError
+	if err == nil || errors.As(err, &coded) {
+		return err
+	}
+	return &CodedError{Code: code, Err: err}
+}
+
+// ErrorCode returns the code of err, or CodeFailed when it has none
+func ErrorCode(err error) string {
+	var coded *CodedError
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return CodeNotFound
+	}
+	return CodeFailed
+}
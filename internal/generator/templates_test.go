@@ -0,0 +1,332 @@
+package generator
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// goldenSpec is where the golden projects keep the spec of 'add api'
+const goldenSpec = "api/openapi.yaml"
+
+// goldenSkipped are the paths left out of the golden files: go.mod and go.sum
+// depend on what the go command resolves, and .cleango is bookkeeping
+var goldenSkipped = []string{"go.mod", "go.sum", ".cleango"}
+
+// TestTemplatesGolden generates a project for every framework and database
+// with every generator, so every template is rendered, and compares the files
+// with testdata/golden/<framework>-<database>.golden. Run with -update to
+// rewrite them after changing a template.
+//
+// The projects are then compiled and vetted, tests included, unless -short is
+// set. A project whose modules cannot be downloaded is not compiled, and the
+// gRPC service only is when protoc and its Go plugins are installed.
+func TestTemplatesGolden(t *testing.T) {
+	output := Output
+	Output = io.Discard
+	t.Cleanup(func() { Output = output })
+	// The user's template overrides must not change the result
+	t.Setenv("CLEANGO_TEMPLATES", t.TempDir())
+
+	fields, err := ParseFieldSpecs([]string{"name:string:required,min=2", "email:string:required,email", "age:int:min=18", "born:time"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The spec is copied into the project, so the generated headers name it
+	// the same wherever the repository is checked out
+	spec, err := os.ReadFile(filepath.Join("testdata", "api.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, framework := range Frameworks {
+		for j, database := range Databases {
+			config := ProjectConfig{
+				Name:       "shop",
+				ModulePath: "example.com/shop",
+				Framework:  framework,
+				Database:   database,
+				Logger:     Loggers[(i+j)%len(Loggers)],
+				UseRedis:   j%2 == 1,
+				UseKafka:   j%2 == 1,
+				Lang:       "es",
+			}
+			t.Run(framework+"-"+database, func(t *testing.T) {
+				dir := filepath.Join(t.TempDir(), config.Name)
+				grpcDir := filepath.Join(t.TempDir(), config.Name)
+				protoc := generateGoldenProject(t, dir, grpcDir, config, fields, spec)
+
+				compareGolden(t, framework+"-"+database, readGoldenFiles(t, grpcDir))
+
+				if testing.Short() {
+					return
+				}
+				// The go commands do not change the working directory, so the
+				// projects compile in parallel
+				t.Parallel()
+				compileGoldenProject(t, dir)
+				if protoc {
+					compileGoldenProject(t, grpcDir)
+				}
+			})
+		}
+	}
+}
+
+// TestTemplatesGoldenPack generates a project with the pack in testdata/pack,
+// whose overrides, variables and extra files are rendered before the manifest
+// recording the pack is written, and compares the files with
+// testdata/golden/pack.golden
+func TestTemplatesGoldenPack(t *testing.T) {
+	output := Output
+	Output = io.Discard
+	t.Cleanup(func() { Output = output })
+	t.Setenv("CLEANGO_TEMPLATES", t.TempDir())
+	t.Setenv("CLEANGO_PACKS", t.TempDir())
+
+	pack, _, err := InstallPack(filepath.Join("testdata", "pack"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vars, err := pack.Vars(map[string]string{"team": "payments"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields, err := ParseFieldSpecs([]string{"name:string:required"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := ProjectConfig{
+		Name:       "shop",
+		ModulePath: "example.com/shop",
+		Framework:  "gin",
+		Database:   "postgres",
+		Logger:     Loggers[0],
+		Lang:       "es",
+		Pack:       pack.Ref(vars),
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd)
+	dir := filepath.Join(t.TempDir(), config.Name)
+	if err := GenerateProject(dir, config, DependencyOptions{Skip: true}); err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	// Later generators find the pack in the manifest
+	if err := GenerateModel("User", fields, true); err != nil {
+		t.Fatalf("add model: %v", err)
+	}
+	if err := os.Chdir(cwd); err != nil {
+		t.Fatal(err)
+	}
+
+	compareGolden(t, "pack", readGoldenFiles(t, dir))
+	if !testing.Short() {
+		compileGoldenProject(t, dir)
+	}
+}
+
+// compareGolden compares files with testdata/golden/<name>.golden, or
+// rewrites it with -update
+func compareGolden(t *testing.T, name string, files []byte) {
+	t.Helper()
+	golden := filepath.Join("testdata", "golden", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(golden, files, 0644); err != nil {
+			t.Fatal(err)
+		}
+	} else if want, err := os.ReadFile(golden); err != nil {
+		t.Fatalf("%v (run go test -run TestTemplatesGolden -update)", err)
+	} else if !bytes.Equal(files, want) {
+		t.Errorf("generated files differ from %s:\n%s", golden, firstDifference(want, files))
+	}
+}
+
+// generateGoldenProject runs the generators in dir, copies the result to
+// grpcDir and adds a gRPC service to the copy. It reports whether protoc
+// generated the code of the service.
+func generateGoldenProject(t *testing.T, dir, grpcDir string, config ProjectConfig, fields []FieldSpec, spec []byte) bool {
+	t.Helper()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd)
+
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{"new", func() error { return GenerateProject(dir, config, DependencyOptions{Skip: true}) }},
+		{"add model", func() error { return GenerateModel("User", fields, true) }},
+		// The use case takes the port as an option, so the entrypoints
+		// calling its constructor keep compiling
+		{"add port", func() error { return writeGoldenPort(config.ModulePath) }},
+		{"mocks", func() error { _, err := GenerateMocks(); return err }},
+		{"add usecase", func() error { return GenerateUsecase("CreateUser", "User", true) }},
+		{"add adapter", func() error { return GenerateAdapter("UserRepository", true) }},
+		{"add handler", func() error { return GenerateHandler("User", "", true) }},
+		{"add api", func() error {
+			if err := EnsureDir("api"); err != nil {
+				return err
+			}
+			if err := WriteFile(goldenSpec, spec); err != nil {
+				return err
+			}
+			_, err := GenerateAPI(goldenSpec)
+			return err
+		}},
+		{"mocks", func() error { _, err := GenerateMocks(); return err }},
+		{"openapi", func() error {
+			_, err := GenerateOpenAPI(OpenAPIOptions{Output: "openapi.yaml", Serve: true})
+			return err
+		}},
+		{"add graphql", func() error { _, err := GenerateGraphQL(); return err }},
+	}
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+	}
+
+	if err := os.CopyFS(grpcDir, os.DirFS(dir)); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(grpcDir); err != nil {
+		t.Fatal(err)
+	}
+	result, err := GenerateGRPC("User", nil)
+	if err != nil {
+		t.Fatalf("add grpc: %v", err)
+	}
+	return result.Generated
+}
+
+// writeGoldenPort declares a port of the User model in domain/ports
+func writeGoldenPort(modulePath string) error {
+	if err := EnsureDir("domain/ports"); err != nil {
+		return err
+	}
+	return WriteFile("domain/ports/user_store.go", []byte(`package ports
+
+import (
+	"context"
+
+	"`+modulePath+`/domain/models"
+)
+
+// UserStore stores users
+type UserStore interface {
+	Save(ctx context.Context, user *models.User) error
+}
+`))
+}
+
+// readGoldenFiles returns the files of the project in dir, but for
+// goldenSkipped, concatenated in path order with a "-- path --" header each
+func readGoldenFiles(t *testing.T, dir string) []byte {
+	t.Helper()
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		for _, skipped := range goldenSkipped {
+			if rel == skipped {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+		if !d.IsDir() {
+			paths = append(paths, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(paths)
+
+	var b bytes.Buffer
+	for _, path := range paths {
+		content, err := os.ReadFile(filepath.Join(dir, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(&b, "-- %s --\n", filepath.ToSlash(path))
+		b.Write(content)
+		if len(content) > 0 && content[len(content)-1] != '\n' {
+			b.WriteByte('\n')
+		}
+	}
+	return b.Bytes()
+}
+
+// firstDifference describes the first line where got differs from want
+func firstDifference(want, got []byte) string {
+	wantLines := strings.Split(string(want), "\n")
+	gotLines := strings.Split(string(got), "\n")
+	file := ""
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if strings.HasPrefix(w, "-- ") {
+			file = w
+		}
+		if w != g {
+			return fmt.Sprintf("line %d (in %s)\nwant: %q\ngot:  %q", i+1, file, w, g)
+		}
+	}
+	return ""
+}
+
+// compileGoldenProject builds and vets the project in dir, tests included,
+// after downloading its modules. When they cannot be downloaded, as without
+// network access and a module cache holding them, the project is skipped.
+func compileGoldenProject(t *testing.T, dir string) {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+
+	goCommand := func(args ...string) ([]byte, error) {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		// The skipped dependency resolution leaves go.sum to fill in
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+		return cmd.CombinedOutput()
+	}
+	if output, err := goCommand("mod", "download"); err != nil {
+		t.Skipf("modules unavailable, not compiling: %v\n%s", err, output)
+	}
+	if output, err := goCommand("vet", "./..."); err != nil {
+		t.Fatalf("generated project does not compile: %v\n%s", err, output)
+	}
+}
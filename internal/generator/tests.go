@@ -0,0 +1,291 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// testValue is a sample field value for generated tests, as a Go literal and
+// as its JSON encoding. A nil JSON value leaves the key out of the body.
+type testValue struct {
+	Go   string
+	JSON interface{}
+}
+
+// testCase is a value that breaks one validation rule of a field
+type testCase struct {
+	Name   string
+	Field  string
+	GoName string
+	Value  testValue
+}
+
+// validationCase is a rendered test case: the statement applying the value on
+// the test receiver and the request body carrying it
+type validationCase struct {
+	Name   string
+	Field  string
+	Assign string
+	Body   string
+}
+
+// testDate is the valid time used by generated tests
+var testDate = testValue{Go: "time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)", JSON: "2024-01-01T00:00:00Z"}
+
+// validTestValue returns a value satisfying every rule of spec. Fields without
+// rules keep their zero value, reported by ok being false.
+func validTestValue(spec FieldSpec) (value testValue, ok bool) {
+	if len(spec.Rules) == 0 || spec.Type == "bool" {
+		return testValue{}, false
+	}
+
+	required := false
+	var min, max *float64
+	for _, rule := range spec.Rules {
+		switch rule.Name {
+		case "required":
+			required = true
+		case "email":
+			return testValue{Go: strconv.Quote("user@example.com"), JSON: "user@example.com"}, true
+		case "oneof":
+			option := strings.Split(rule.Arg, "|")[0]
+			return testValue{Go: strconv.Quote(option), JSON: option}, true
+		case "min", "max":
+			n, err := strconv.ParseFloat(rule.Arg, 64)
+			if err != nil {
+				continue
+			}
+			if rule.Name == "min" {
+				min = &n
+			} else {
+				max = &n
+			}
+		}
+	}
+
+	if spec.Type == "time" {
+		return testDate, required
+	}
+
+	n := 0.0
+	if required {
+		n = 1
+	}
+	if min != nil && n < *min {
+		n = *min
+	}
+	if max != nil && n > *max {
+		n = *max
+	}
+	if n == 0 {
+		// The zero value already satisfies the rules
+		return testValue{}, false
+	}
+
+	if spec.Type == "string" {
+		s := strings.Repeat("a", int(n))
+		return testValue{Go: strconv.Quote(s), JSON: s}, true
+	}
+	return numberTestValue(spec, n), true
+}
+
+// invalidTestCases returns one value per rule of spec that breaks that rule
+func invalidTestCases(spec FieldSpec) []testCase {
+	var cases []testCase
+	add := func(name string, value testValue) {
+		cases = append(cases, testCase{
+			Name:   spec.JSONName() + " " + name,
+			Field:  spec.JSONName(),
+			GoName: spec.GoName(),
+			Value:  value,
+		})
+	}
+
+	for _, rule := range spec.Rules {
+		n, _ := strconv.ParseFloat(rule.Arg, 64)
+		switch rule.Name {
+		case "required":
+			switch spec.Type {
+			case "bool":
+			case "string":
+				add("is required", testValue{Go: `""`, JSON: ""})
+			case "time":
+				add("is required", testValue{Go: "time.Time{}"})
+			default:
+				add("is required", numberTestValue(spec, 0))
+			}
+		case "min":
+			if spec.Type == "string" {
+				if n > 0 {
+					s := strings.Repeat("a", int(n)-1)
+					add("is too short", testValue{Go: strconv.Quote(s), JSON: s})
+				}
+			} else {
+				add("is below the minimum", numberTestValue(spec, n-1))
+			}
+		case "max":
+			if spec.Type == "string" {
+				s := strings.Repeat("a", int(n)+1)
+				add("is too long", testValue{Go: strconv.Quote(s), JSON: s})
+			} else {
+				add("is above the maximum", numberTestValue(spec, n+1))
+			}
+		case "email":
+			add("is not an email", testValue{Go: strconv.Quote("not-an-email"), JSON: "not-an-email"})
+		case "oneof":
+			value := "not-" + strings.Join(strings.Split(rule.Arg, "|"), "-")
+			add("is not an allowed value", testValue{Go: strconv.Quote(value), JSON: value})
+		}
+	}
+	return cases
+}
+
+// numberTestValue renders n for a numeric field
+func numberTestValue(spec FieldSpec, n float64) testValue {
+	if spec.Type == "float" || spec.Type == "float64" {
+		return testValue{Go: strconv.FormatFloat(n, 'f', -1, 64), JSON: n}
+	}
+	return testValue{Go: strconv.Itoa(int(n)), JSON: int(n)}
+}
+
+// testData builds the template data shared by the generated tests: the
+// statements filling receiver with valid values, the valid JSON body and one
+// case per broken rule. Fields read from the URL are left out of bodies.
+func testData(receiver string, specs []FieldSpec) (valid []string, body string, cases []validationCase, usesTime bool) {
+	validJSON := map[string]interface{}{}
+	for _, spec := range specs {
+		value, ok := validTestValue(spec)
+		if !ok {
+			continue
+		}
+		valid = append(valid, fmt.Sprintf("%s.%s = %s", receiver, spec.GoName(), value.Go))
+		if spec.Location == "" && value.JSON != nil {
+			validJSON[spec.JSONName()] = value.JSON
+		}
+		usesTime = usesTime || strings.HasPrefix(value.Go, "time.")
+	}
+
+	for _, spec := range specs {
+		for _, tc := range invalidTestCases(spec) {
+			caseJSON := map[string]interface{}{}
+			for key, value := range validJSON {
+				caseJSON[key] = value
+			}
+			if spec.Location == "" {
+				if tc.Value.JSON == nil {
+					delete(caseJSON, tc.Field)
+				} else {
+					caseJSON[tc.Field] = tc.Value.JSON
+				}
+			}
+
+			cases = append(cases, validationCase{
+				Name:   tc.Name,
+				Field:  tc.Field,
+				Assign: fmt.Sprintf("%s.%s = %s", receiver, tc.GoName, tc.Value.Go),
+				Body:   jsonLiteral(caseJSON),
+			})
+			usesTime = usesTime || strings.HasPrefix(tc.Value.Go, "time.")
+		}
+	}
+
+	return valid, jsonLiteral(validJSON), cases, usesTime
+}
+
+// jsonLiteral renders value as a Go string literal holding its JSON encoding
+func jsonLiteral(value map[string]interface{}) string {
+	encoded, _ := json.Marshal(value)
+	if strings.Contains(string(encoded), "`") {
+		return strconv.Quote(string(encoded))
+	}
+	return "`" + string(encoded) + "`"
+}
+
+// writeTestFile renders the test template name into filename, handling an
+// existing test with the conflict policy
+func writeTestFile(filename, name string, data interface{}) error {
+	content, err := renderGoTemplate(name, data)
+	if err != nil {
+		return err
+	}
+	return writeComponent(filename, content)
+}
+
+// generateModelTest writes the table-driven Validate test of a model
+func generateModelTest(config ProjectConfig, name string, fields []FieldSpec) error {
+	valid, _, cases, usesTime := testData("m", fields)
+
+	std := []string{"testing"}
+	if usesTime {
+		std = append(std, "time")
+	}
+	data := map[string]interface{}{
+		"Name":    ToPascalCase(name),
+		"Valid":   valid,
+		"Cases":   cases,
+		"Imports": renderImports(std, []string{fmt.Sprintf("domainerrors %q", config.ModulePath+"/domain/errors")}),
+	}
+
+	filename := filepath.Join("domain/models", ToSnakeCase(name)+"_test.go")
+	return writeTestFile(filename, "modelTest", data)
+}
+
+// generateUsecaseTest writes the table-driven test of a use case. It lives
+// in the external usecases_test package so it can use the generated mocks of
+// the use case ports. When the use case depends on port, it is built with the
+// port's mock, which invalid inputs must not reach.
+func generateUsecaseTest(config ProjectConfig, name string, fields []FieldSpec, port *usecasePort) error {
+	valid, _, cases, usesTime := testData("in", fields)
+
+	std := []string{"context", "testing"}
+	if usesTime {
+		std = append(std, "time")
+	}
+	local := []string{config.ModulePath + "/domain/usecases"}
+	if len(cases) > 0 {
+		local = append(local, fmt.Sprintf("domainerrors %q", config.ModulePath+"/domain/errors"))
+	}
+	if port != nil {
+		local = append(local, config.ModulePath+"/"+mocksDir)
+	}
+	data := map[string]interface{}{
+		"Name":    ToPascalCase(name),
+		"Valid":   valid,
+		"Cases":   cases,
+		"Port":    port,
+		"Imports": renderImports(std, local),
+	}
+
+	filename := filepath.Join("domain/usecases", ToSnakeCase(name)+"_test.go")
+	return writeTestFile(filename, "usecaseTest", data)
+}
+
+// generateHandlerTest writes the table-driven httptest test of a handler,
+// serving the requests through the project's router
+func generateHandlerTest(config ProjectConfig, name string, fields []FieldSpec) error {
+	_, body, cases, _ := testData("req", fields)
+
+	var external []string
+	switch config.Framework {
+	case "chi":
+		external = []string{"github.com/go-chi/chi/v5"}
+	case "gin":
+		external = []string{"github.com/gin-gonic/gin"}
+	case "fiber":
+		external = []string{"github.com/gofiber/fiber/v2"}
+	}
+	data := map[string]interface{}{
+		"Name":      ToPascalCase(name),
+		"Resource":  ToResourcePath(name),
+		"Framework": config.Framework,
+		"Body":      body,
+		"Cases":     cases,
+		"Imports":   renderImports([]string{"net/http", "net/http/httptest", "strings", "testing"}, external),
+	}
+
+	filename := filepath.Join("infrastructure/entrypoints/http", ToSnakeCase(name)+"_handler_test.go")
+	return writeTestFile(filename, "handlerTest", data)
+}
@@ -0,0 +1,307 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// Rollback lists the changes undone after a generator failed
+type Rollback struct {
+	// Removed files and directories were created by the failed command
+	Removed []string `json:"removed"`
+	// Restored files were changed or removed by the failed command and have
+	// their previous content again
+	Restored []string `json:"restored"`
+}
+
+// transaction journals the changes a generator makes to the project, so that
+// they are undone when it fails halfway, panics or is interrupted. Paths are
+// kept absolute, as 'cleango new' changes to the project directory while
+// generating.
+//
+// Generators that change an existing project write in place rather than into
+// a staging area: they read back what they wrote (the GraphQL schema, the
+// mocks and the OpenAPI spec are derived from the files of the project), so
+// a staging area would have to shadow the whole tree. A new directory is
+// staged instead, see stage.
+type transaction struct {
+	// depth counts the nested generators sharing the transaction
+	depth int
+	// changes are the journaled paths in the order they were first changed
+	changes []journaled
+	seen    map[string]bool
+	// mark is the state of the report when the transaction started
+	mark reportMark
+	// staged maps the staging directories to the directories they become
+	staged map[string]string
+
+	// mu is held while a change is made or rolled back, so an interrupt
+	// never rolls back halfway through a write
+	mu sync.Mutex
+	// interrupts receives SIGINT and SIGTERM until done is closed
+	interrupts chan os.Signal
+	done       chan struct{}
+}
+
+// journaled is the state of a path before the transaction changed it
+type journaled struct {
+	abs      string
+	existed  bool
+	dir      bool
+	original []byte
+	mode     os.FileMode
+}
+
+// activeTransaction is the transaction of the running generator, if any
+var activeTransaction *transaction
+
+// beginTransaction starts a transaction, or joins the one of the generator
+// calling this one. It is ended with end:
+//
+//	defer beginTransaction().end(&err)
+func beginTransaction() *transaction {
+	if activeTransaction == nil {
+		t := &transaction{
+			seen:       map[string]bool{},
+			mark:       markReport(),
+			staged:     map[string]string{},
+			interrupts: make(chan os.Signal, 1),
+			done:       make(chan struct{}),
+		}
+		signal.Notify(t.interrupts, os.Interrupt, syscall.SIGTERM)
+		go t.rollbackOnInterrupt()
+		activeTransaction = t
+	}
+	activeTransaction.depth++
+	return activeTransaction
+}
+
+// end finishes the transaction when its outermost generator returns. If that
+// generator failed with *err or panicked, the journaled changes are rolled
+// back and recorded in the report, and any error undoing them is added to
+// *err. A panic goes on once the changes are undone.
+func (t *transaction) end(err *error) {
+	if p := recover(); p != nil {
+		defer panic(p)
+		if *err == nil {
+			*err = fmt.Errorf("%v", p)
+		}
+	}
+
+	t.depth--
+	if t.depth > 0 {
+		return
+	}
+	activeTransaction = nil
+	signal.Stop(t.interrupts)
+	defer close(t.done)
+	if *err == nil || len(t.changes) == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rollback, rollbackErr := t.rollback()
+	recordRollback(t.mark, rollback)
+	if rollbackErr != nil {
+		*err = &CodedError{Code: ErrorCode(*err), Err: i18n.Error("tx.rollback_failed", *err, rollbackErr)}
+	}
+}
+
+// rollbackOnInterrupt undoes the changes and exits when the command is
+// interrupted before the transaction ends. Changes in progress are finished
+// first, and no further one is made.
+func (t *transaction) rollbackOnInterrupt() {
+	select {
+	case <-t.done:
+	case <-t.interrupts:
+		t.mu.Lock()
+		rollback, err := t.rollback()
+		fmt.Fprintln(os.Stderr, i18n.T("tx.interrupted", len(rollback.Removed), len(rollback.Restored)))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(130)
+	}
+}
+
+// lockTransaction keeps an interrupt from rolling the active transaction back
+// while a change is made, and returns the function that releases it
+func lockTransaction() func() {
+	t := activeTransaction
+	if t == nil {
+		return func() {}
+	}
+	t.mu.Lock()
+	return t.mu.Unlock
+}
+
+// stage creates the staging directory in which a generator builds target, a
+// directory that does not exist yet: a hidden directory next to it, removed
+// on rollback. commit moves it to target once the generator is done, so
+// target never holds a partial result.
+func (t *transaction) stage(target string) (string, error) {
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return "", err
+	}
+	if err := EnsureDir(filepath.Dir(abs)); err != nil {
+		return "", err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	dir, err := os.MkdirTemp(filepath.Dir(abs), "."+filepath.Base(abs)+".tmp-")
+	if err != nil {
+		return "", err
+	}
+	t.seen[dir] = true
+	t.changes = append(t.changes, journaled{abs: dir, dir: true})
+	t.staged[dir] = abs
+	// MkdirTemp creates it private, unlike the directories of EnsureDir
+	if err := os.Chmod(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// commit moves the staging directory dir to its target
+func (t *transaction) commit(dir string) error {
+	defer lockTransaction()()
+	target := t.staged[dir]
+	if err := os.Rename(dir, target); err != nil {
+		return err
+	}
+	// The target is what a later failure rolls back
+	for i := range t.changes {
+		if t.changes[i].abs == dir {
+			t.changes[i].abs = target
+		}
+	}
+	delete(t.staged, dir)
+	return nil
+}
+
+// rollback undoes the journaled changes, newest first
+func (t *transaction) rollback() (*Rollback, error) {
+	rollback := &Rollback{Removed: []string{}, Restored: []string{}}
+	cwd, _ := os.Getwd()
+	var errs []error
+	for i := len(t.changes) - 1; i >= 0; i-- {
+		change := t.changes[i]
+		current, readErr := os.ReadFile(change.abs)
+		// Paths journaled before a change that never happened are left out
+		if !change.existed && !FileExists(change.abs) || change.existed && readErr == nil && bytes.Equal(current, change.original) {
+			continue
+		}
+
+		var err error
+		switch {
+		case !change.existed && change.dir:
+			err = os.RemoveAll(change.abs)
+		case !change.existed:
+			err = os.Remove(change.abs)
+		default:
+			err = os.WriteFile(change.abs, change.original, change.mode)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		// Paths are reported relative to the working directory now, which
+		// the generator may have changed since. A staging directory is
+		// reported as the directory it was for.
+		path := change.abs
+		if target, ok := t.staged[path]; ok {
+			path = target
+		}
+		if rel, err := filepath.Rel(cwd, change.abs); err == nil && !outside(rel) {
+			path = rel
+		}
+		if slashed := "/" + reportPath(path) + "/"; strings.Contains(slashed, "/"+pristineDir+"/") || strings.Contains(slashed, "/"+insertedDir+"/") || strings.HasSuffix(slashed, "/.cleango/") {
+			// Bookkeeping of upgrade rather than project files
+			continue
+		}
+		if change.existed {
+			rollback.Restored = append(rollback.Restored, reportPath(path))
+		} else {
+			rollback.Removed = append(rollback.Removed, reportPath(path))
+		}
+	}
+	return rollback, errors.Join(errs...)
+}
+
+// journal records the state of the file at path before the active
+// transaction first changes it
+func journal(path string) {
+	t := activeTransaction
+	if t == nil {
+		return
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil || t.seen[abs] || t.insideCreatedDir(abs) {
+		return
+	}
+	t.seen[abs] = true
+
+	change := journaled{abs: abs}
+	if info, err := os.Stat(abs); err == nil && !info.IsDir() {
+		if content, err := os.ReadFile(abs); err == nil {
+			change.existed, change.original, change.mode = true, content, info.Mode().Perm()
+		}
+	}
+	t.changes = append(t.changes, change)
+}
+
+// journalDir records the creation of the directory at path, or of its
+// outermost missing ancestor, by the active transaction
+func journalDir(path string) {
+	t := activeTransaction
+	if t == nil {
+		return
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil || FileExists(abs) || t.insideCreatedDir(abs) {
+		return
+	}
+	for parent := filepath.Dir(abs); parent != abs && !FileExists(parent); parent = filepath.Dir(parent) {
+		abs = parent
+	}
+	t.seen[abs] = true
+	t.changes = append(t.changes, journaled{abs: abs, dir: true})
+}
+
+// journalModule records go.mod, go.sum and vendor/ before a go command that
+// may rewrite them runs in the current directory
+func journalModule() {
+	journal("go.mod")
+	journal("go.sum")
+	journalDir("vendor")
+}
+
+// insideCreatedDir reports whether abs is inside a directory the transaction
+// created, which the rollback removes as a whole
+func (t *transaction) insideCreatedDir(abs string) bool {
+	for _, change := range t.changes {
+		if change.dir && !change.existed {
+			if rel, err := filepath.Rel(change.abs, abs); err == nil && rel != "." && !outside(rel) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// outside reports whether the relative path rel leaves its base directory
+func outside(rel string) bool {
+	return rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerateProjectRollsBack breaks a template of 'cleango new': the
+// project directory is never created, no staging directory is left next to
+// it, and the working directory is restored
+func TestGenerateProjectRollsBack(t *testing.T) {
+	output := Output
+	Output = io.Discard
+	t.Cleanup(func() { Output = output })
+	templates := t.TempDir()
+	t.Setenv("CLEANGO_TEMPLATES", templates)
+	if err := os.WriteFile(filepath.Join(templates, "logger.tmpl"), []byte("{{.Broken"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report := StartReport()
+	t.Cleanup(func() { activeReport = nil })
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent := t.TempDir()
+	config := ProjectConfig{
+		Name:       "shop",
+		ModulePath: "example.com/shop",
+		Framework:  "chi",
+		Database:   "none",
+		Logger:     Loggers[0],
+		Lang:       "es",
+	}
+	if err := GenerateProject(filepath.Join(parent, config.Name), config, DependencyOptions{Skip: true}); err == nil {
+		t.Fatal("expected the broken template to fail")
+	}
+
+	if dir, _ := os.Getwd(); dir != cwd {
+		t.Errorf("working directory is %s, want %s", dir, cwd)
+	}
+	entries, err := os.ReadDir(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		t.Errorf("%s is left in the parent directory", entry.Name())
+	}
+	if report.RolledBack == nil || len(report.RolledBack.Removed) != 1 || !strings.HasSuffix(report.RolledBack.Removed[0], "/shop") {
+		t.Errorf("rollback reports %+v, want the project directory removed", report.RolledBack)
+	}
+	if len(report.Created) != 0 {
+		t.Errorf("report still lists created files: %v", report.Created)
+	}
+}
+
+// TestGenerateProjectCommitsStaging generates a project into a missing
+// directory: it is moved there complete, with the mode of the directories
+// the generators create, and becomes the working directory
+func TestGenerateProjectCommitsStaging(t *testing.T) {
+	testProject(t)
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(dir) != "shop" || !FileExists("go.mod") {
+		t.Fatalf("working directory is %s, want the project", dir)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("project directory has mode %v, want 0755", info.Mode().Perm())
+	}
+	entries, err := os.ReadDir(filepath.Dir(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("the parent directory holds %d entries, want the project only", len(entries))
+	}
+}
+
+// TestGenerateAdapterRollsBack makes the test of 'add adapter --with-tests'
+// fail to write after the adapter overwrote an edited one: the edited adapter
+// is restored and reported as such
+func TestGenerateAdapterRollsBack(t *testing.T) {
+	testProject(t)
+	conflicts := Conflicts
+	Conflicts = ConflictPolicy{Strategy: ConflictOverwrite}
+	t.Cleanup(func() { Conflicts = conflicts })
+
+	if err := GenerateAdapter("UserRepository", false); err != nil {
+		t.Fatalf("add adapter: %v", err)
+	}
+	adapter := "infrastructure/adapters/database/user_repository.go"
+	edited := []byte("package database\n\n// Edited by hand\n")
+	if err := os.WriteFile(adapter, edited, 0644); err != nil {
+		t.Fatal(err)
+	}
+	// A directory where the test goes cannot be written
+	if err := os.Mkdir("infrastructure/adapters/database/user_repository_test.go", 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	report := StartReport()
+	t.Cleanup(func() { activeReport = nil })
+	if err := GenerateAdapter("UserRepository", true); err == nil {
+		t.Fatal("expected the test file to fail")
+	}
+
+	content, err := os.ReadFile(adapter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != string(edited) {
+		t.Errorf("adapter is not restored:\n%s", content)
+	}
+	if report.RolledBack == nil || len(report.RolledBack.Restored) != 1 || report.RolledBack.Restored[0] != adapter {
+		t.Errorf("rollback reports %+v, want %s restored", report.RolledBack, adapter)
+	}
+	if len(report.Modified) != 0 || len(report.Conflicts) != 0 {
+		t.Errorf("report still lists the undone changes: %v %v", report.Modified, report.Conflicts)
+	}
+}
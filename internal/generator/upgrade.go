@@ -0,0 +1,267 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+	"github.com/YeridStick/cleango/internal/merge"
+)
+
+// pristineDir keeps a copy of each file as cleango generated it, the common
+// ancestor 'cleango upgrade' merges the user's edits and the new templates from
+const pristineDir = ".cleango/pristine"
+
+// pristinePath returns where the pristine copy of a project file is kept
+func pristinePath(path string) string {
+	return filepath.Join(pristineDir, filepath.FromSlash(path))
+}
+
+// recordPristine stores the generated content of files
+func recordPristine(files []generatedFile) error {
+	for _, file := range files {
+		path := pristinePath(file.path)
+		if err := EnsureDir(filepath.Dir(path)); err != nil {
+			return err
+		}
+		if err := WriteFile(path, file.content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isPristine reports whether a file still has the content cleango generated.
+// Files without a recorded copy are reported as modified.
+func isPristine(path string) (bool, error) {
+	current, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	base, err := os.ReadFile(pristinePath(path))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(current, base), nil
+}
+
+// insertedDir keeps the lines the generators inserted into files with a
+// pristine copy after generating them (route registrations, imports, ...),
+// so that they are not taken for edits by hand
+const insertedDir = ".cleango/inserted"
+
+// insertedPath returns where the lines inserted into a project file are kept
+func insertedPath(path string) string {
+	return filepath.Join(insertedDir, filepath.FromSlash(path))
+}
+
+// writeInsertion writes the content of path after a generator inserted lines
+// into it, recording those lines when path has a pristine copy
+func writeInsertion(path string, before, after []byte) error {
+	if err := WriteFile(path, after); err != nil {
+		return err
+	}
+	added := addedLines(before, after)
+	if len(added) == 0 || !FileExists(pristinePath(path)) {
+		return nil
+	}
+	recorded, err := os.ReadFile(insertedPath(path))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := EnsureDir(filepath.Dir(insertedPath(path))); err != nil {
+		return err
+	}
+	return WriteFile(insertedPath(path), append(recorded, strings.Join(added, "\n")+"\n"...))
+}
+
+// normalizedLines returns the non-blank lines of content with their spacing
+// collapsed, as gofmt realigns the code around inserted lines
+func normalizedLines(content []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			lines = append(lines, strings.Join(fields, " "))
+		}
+	}
+	return lines
+}
+
+// addedLines returns the normalized lines of after beyond those of before
+func addedLines(before, after []byte) []string {
+	counts := map[string]int{}
+	for _, line := range normalizedLines(before) {
+		counts[line]++
+	}
+	var added []string
+	for _, line := range normalizedLines(after) {
+		if counts[line] > 0 {
+			counts[line]--
+		} else {
+			added = append(added, line)
+		}
+	}
+	return added
+}
+
+// isEditedByHand reports whether a file with a pristine copy differs from it
+// by more than the lines the generators inserted since and the spacing
+func isEditedByHand(path string) (bool, error) {
+	if pristine, err := isPristine(path); err != nil || pristine {
+		return false, err
+	}
+	current, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	base, err := os.ReadFile(pristinePath(path))
+	if err != nil {
+		return false, err
+	}
+	inserted, err := os.ReadFile(insertedPath(path))
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	skip := map[string]int{}
+	for _, line := range normalizedLines(inserted) {
+		skip[line]++
+	}
+	var lines []string
+	for _, line := range normalizedLines(current) {
+		if skip[line] > 0 {
+			skip[line]--
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n") != strings.Join(normalizedLines(base), "\n"), nil
+}
+
+// UpgradeResult lists what 'cleango upgrade' did to each file
+type UpgradeResult struct {
+	// Updated files had no local changes and now match the new templates
+	Updated []string
+	// Merged files combine local changes with the new templates
+	Merged []string
+	// Conflicts are merged files with conflict markers to resolve
+	Conflicts []string
+	// Created files did not exist in the project
+	Created  []string
+	Warnings []string
+}
+
+// Changed reports whether the upgrade touches any file
+func (r *UpgradeResult) Changed() bool {
+	return len(r.Updated)+len(r.Merged)+len(r.Conflicts)+len(r.Created) > 0
+}
+
+// Upgrade regenerates the files created by 'cleango new' with the current
+// templates and the configuration recorded in the manifest. Each file is
+// merged three ways: the pristine copy recorded at generation is the common
+// ancestor of the user's version and the new one. With dryRun nothing is
+// written.
+func Upgrade(dryRun bool) (_ *UpgradeResult, err error) {
+	defer beginTransaction().end(&err)
+
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+	files, err := renderProjectFiles(config)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &UpgradeResult{}
+	var pristine []generatedFile
+	var writes []generatedFile
+	for _, file := range files {
+		current, err := os.ReadFile(file.path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		base, baseErr := os.ReadFile(pristinePath(file.path))
+		if baseErr != nil && !os.IsNotExist(baseErr) {
+			return nil, baseErr
+		}
+		exists, recorded := err == nil, baseErr == nil
+
+		switch {
+		case !exists && recorded:
+			result.Warnings = append(result.Warnings, i18n.T("upgrade.deleted", file.path))
+			continue
+		case !exists:
+			// A file added to the templates after the project was created
+			result.Created = append(result.Created, file.path)
+			writes = append(writes, file)
+		case bytes.Equal(current, file.content):
+			// Already up to date, only the pristine copy may be missing
+		case !recorded:
+			result.Warnings = append(result.Warnings, i18n.T("upgrade.no_pristine", file.path))
+			continue
+		case bytes.Equal(base, file.content):
+			// The template did not change: keep the local edits
+			continue
+		case bytes.Equal(current, base):
+			result.Updated = append(result.Updated, file.path)
+			writes = append(writes, file)
+		default:
+			merged, conflicts := merge.Merge(base, current, file.content, merge.Labels{
+				Ours:   "local",
+				Base:   "original",
+				Theirs: "cleango",
+			})
+			if conflicts > 0 {
+				result.Conflicts = append(result.Conflicts, file.path)
+			} else {
+				result.Merged = append(result.Merged, file.path)
+			}
+			writes = append(writes, generatedFile{file.path, merged})
+		}
+		pristine = append(pristine, file)
+	}
+
+	// The files now come from the installed version of the pack
+	var manifest *Manifest
+	if config.Pack != nil {
+		pack, err := LoadPack(config.Pack.Name)
+		if err != nil {
+			return nil, err
+		}
+		if pack.Version != config.Pack.Version {
+			if manifest, err = LoadManifest(); err != nil {
+				return nil, err
+			}
+			manifest.Pack.Version = pack.Version
+			result.Updated = append(result.Updated, fmt.Sprintf("%s (pack %s %s → %s)", ManifestFile, pack.Name, config.Pack.Version, pack.Version))
+		}
+	}
+
+	if dryRun {
+		return result, nil
+	}
+	if manifest != nil {
+		if err := manifest.Save(); err != nil {
+			return nil, err
+		}
+	}
+	for _, file := range writes {
+		if err := EnsureDir(filepath.Dir(file.path)); err != nil {
+			return nil, err
+		}
+		if err := WriteFile(file.path, file.content); err != nil {
+			return nil, i18n.Error("err.write", file.path, err)
+		}
+	}
+	if err := recordPristine(pristine); err != nil {
+		return nil, i18n.Error("upgrade.record", err)
+	}
+	return result, nil
+}
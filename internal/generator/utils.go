@@ -1,42 +1,124 @@
 package generator
 
 import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
 	"os"
-	"regexp"
+	"strconv"
 	"strings"
 	"unicode"
-)
 
-// ToSnakeCase converts a string to snake_case
-func ToSnakeCase(s string) string {
-	var matchFirstCap = regexp.MustCompile("(.)([A-Z][a-z]+)")
-	var matchAllCap = regexp.MustCompile("([a-z0-9])([A-Z])")
+	"github.com/YeridStick/cleango/internal/i18n"
+)
 
-	snake := matchFirstCap.ReplaceAllString(s, "${1}_${2}")
-	snake = matchAllCap.ReplaceAllString(snake, "${1}_${2}")
-	return strings.ToLower(snake)
+// commonInitialisms are the acronyms written in a single case in Go
+// identifiers, as in UserID or httpServer
+var commonInitialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true,
+	"EOF": true, "GUID": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true,
+	"IP": true, "JSON": true, "JWT": true, "QPS": true, "RAM": true, "RPC": true,
+	"SKU": true, "SLA": true, "SMTP": true, "SQL": true, "SSH": true, "TCP": true,
+	"TLS": true, "TTL": true, "UDP": true, "UI": true, "UID": true, "URI": true,
+	"URL": true, "UTF8": true, "UUID": true, "VM": true, "XML": true, "XSRF": true,
+	"XSS": true,
 }
 
-// ToPascalCase converts a string to PascalCase
-func ToPascalCase(s string) string {
-	words := strings.FieldsFunc(s, func(r rune) bool {
+// splitWords splits s into words at non alphanumeric separators, at lower to
+// upper case changes and at the end of acronyms ("HTTPServer" is HTTP and
+// Server), keeping plural initialisms such as IDs whole. Letters of any script
+// are supported.
+func splitWords(s string) []string {
+	var words []string
+	fields := strings.FieldsFunc(s, func(r rune) bool {
 		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
 	})
+	for _, field := range fields {
+		runes := []rune(field)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			prev, cur := runes[i-1], runes[i]
+			if !unicode.IsUpper(cur) {
+				continue
+			}
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+				words = append(words, string(runes[start:i]))
+				start = i
+				continue
+			}
+			// The last capital of an acronym starts the next word, unless it
+			// is the plural "s" of an initialism
+			if unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+				plural := runes[i+1] == 's' && (i+2 == len(runes) || unicode.IsUpper(runes[i+2]))
+				if plural && commonInitialisms[string(runes[start:i+1])] {
+					continue
+				}
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
 
+// initialism returns the Go spelling of word when it is an initialism or the
+// plural of one ("id" is ID, "ids" is IDs)
+func initialism(word string) (string, bool) {
+	upper := strings.ToUpper(word)
+	if commonInitialisms[upper] {
+		return upper, true
+	}
+	if base, ok := strings.CutSuffix(upper, "S"); ok && len(base) > 1 && commonInitialisms[base] {
+		return base + "s", true
+	}
+	return "", false
+}
+
+// capitalize returns word with its first letter upper case and the rest lower
+// case, or its initialism spelling
+func capitalize(word string) string {
+	if spelled, ok := initialism(word); ok {
+		return spelled
+	}
+	runes := []rune(strings.ToLower(word))
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// ToSnakeCase converts a string to snake_case
+func ToSnakeCase(s string) string {
+	words := splitWords(s)
 	for i, word := range words {
-		words[i] = strings.Title(strings.ToLower(word))
+		words[i] = strings.ToLower(word)
 	}
+	return strings.Join(words, "_")
+}
 
+// ToPascalCase converts a string to PascalCase, spelling initialisms in upper
+// case ("user_id" is UserID)
+func ToPascalCase(s string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		words[i] = capitalize(word)
+	}
 	return strings.Join(words, "")
 }
 
-// ToCamelCase converts a string to camelCase
+// ToCamelCase converts a string to camelCase, spelling a leading initialism
+// in lower case ("HTTPServer" is httpServer)
 func ToCamelCase(s string) string {
-	pascal := ToPascalCase(s)
-	if len(pascal) == 0 {
-		return pascal
+	words := splitWords(s)
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word)
+		} else {
+			words[i] = capitalize(word)
+		}
 	}
-	return strings.ToLower(pascal[:1]) + pascal[1:]
+	return strings.Join(words, "")
 }
 
 // FileExists checks if a file or directory exists
@@ -48,6 +130,8 @@ func FileExists(path string) bool {
 // EnsureDir creates a directory if it doesn't exist
 func EnsureDir(path string) error {
 	if !FileExists(path) {
+		defer lockTransaction()()
+		journalDir(path)
 		return os.MkdirAll(path, 0755)
 	}
 	return nil
@@ -55,5 +139,168 @@ func EnsureDir(path string) error {
 
 // WriteFile writes content to a file, creating parent directories if needed
 func WriteFile(path string, content []byte) error {
-	return os.WriteFile(path, content, 0644)
+	defer lockTransaction()()
+	existed := FileExists(path)
+	journal(path)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return err
+	}
+	recordWrite(path, existed)
+	return nil
+}
+
+// RemoveFile deletes a file of the project
+func RemoveFile(path string) error {
+	defer lockTransaction()()
+	journal(path)
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	recordRemove(path)
+	return nil
+}
+
+// generatedFile is the rendered content of a file owned by a generator
+type generatedFile struct {
+	path    string
+	content []byte
+}
+
+// outdatedFiles returns the files whose content on disk differs from the
+// rendered one, including those missing
+func outdatedFiles(files []generatedFile) []generatedFile {
+	var outdated []generatedFile
+	for _, file := range files {
+		if existing, err := os.ReadFile(file.path); err == nil && bytes.Equal(existing, file.content) {
+			continue
+		}
+		outdated = append(outdated, file)
+	}
+	return outdated
+}
+
+// Pluralize returns a naive English plural of a lowercase word
+func Pluralize(s string) string {
+	switch {
+	case s == "":
+		return s
+	case strings.HasSuffix(s, "s"), strings.HasSuffix(s, "x"), strings.HasSuffix(s, "z"),
+		strings.HasSuffix(s, "ch"), strings.HasSuffix(s, "sh"):
+		return s + "es"
+	case strings.HasSuffix(s, "y") && len(s) > 1 && !strings.ContainsRune("aeiou", rune(s[len(s)-2])):
+		return s[:len(s)-1] + "ies"
+	default:
+		return s + "s"
+	}
+}
+
+// ToResourcePath converts a component name to a plural, kebab-case URL segment
+func ToResourcePath(s string) string {
+	return strings.ReplaceAll(Pluralize(ToSnakeCase(ToPascalCase(s))), "_", "-")
+}
+
+// InsertBeforeMarker inserts line above the line containing marker, using the
+// marker's indentation. It does nothing when the line is already present.
+func InsertBeforeMarker(path, marker, line string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(content), "\n")
+	for _, existing := range lines {
+		if strings.TrimSpace(existing) == strings.TrimSpace(line) {
+			return nil
+		}
+	}
+
+	for i, existing := range lines {
+		if strings.Contains(existing, marker) {
+			indent := existing[:len(existing)-len(strings.TrimLeft(existing, " \t"))]
+			lines = append(lines[:i], append([]string{indent + line}, lines[i:]...)...)
+			return writeInsertion(path, content, []byte(strings.Join(lines, "\n")))
+		}
+	}
+
+	return i18n.Error("utils.marker", marker, path)
+}
+
+// InsertAfterLine inserts line below the first line containing match, unless
+// a line containing present already exists
+func InsertAfterLine(path, match, present, line string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(content), "\n")
+	for _, existing := range lines {
+		if strings.Contains(existing, present) {
+			return nil
+		}
+	}
+
+	for i, existing := range lines {
+		if strings.Contains(existing, match) {
+			indent := existing[:len(existing)-len(strings.TrimLeft(existing, " \t"))]
+			lines = append(lines[:i+1], append([]string{indent + line}, lines[i+1:]...)...)
+			return writeInsertion(path, content, []byte(strings.Join(lines, "\n")))
+		}
+	}
+
+	return i18n.Error("utils.match", match, path)
+}
+
+// AddImport adds an import, optionally aliased, to a Go source file and
+// gofmts it. It does nothing when the path is already imported.
+func AddImport(path, alias, importPath string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, content, parser.ImportsOnly)
+	if err != nil {
+		return i18n.Error("err.read", path, err)
+	}
+	for _, spec := range file.Imports {
+		if existing, _ := strconv.Unquote(spec.Path.Value); existing == importPath {
+			return nil
+		}
+	}
+
+	spec := strconv.Quote(importPath)
+	if alias != "" {
+		spec = alias + " " + spec
+	}
+
+	// Group the import with those sharing its host, or start a new group at
+	// the end of the last import block
+	host := strings.SplitN(importPath, "/", 2)[0]
+	var updated string
+	if n := len(file.Imports); n > 0 {
+		anchor := file.Imports[n-1]
+		separator := "\n\n\t"
+		for _, existing := range file.Imports {
+			if existingPath, _ := strconv.Unquote(existing.Path.Value); strings.SplitN(existingPath, "/", 2)[0] == host {
+				anchor = existing
+				separator = "\n\t"
+			}
+		}
+		if decl := file.Decls[len(file.Decls)-1].(*ast.GenDecl); !decl.Lparen.IsValid() {
+			separator = "\nimport "
+		}
+		offset := fset.Position(anchor.End()).Offset
+		updated = string(content[:offset]) + separator + spec + string(content[offset:])
+	} else {
+		offset := fset.Position(file.Name.End()).Offset
+		updated = string(content[:offset]) + "\n\nimport " + spec + string(content[offset:])
+	}
+
+	formatted, err := format.Source([]byte(updated))
+	if err != nil {
+		return i18n.Error("err.format", path, err)
+	}
+	return writeInsertion(path, content, formatted)
 }
@@ -0,0 +1,63 @@
+package generator
+
+import "testing"
+
+// TestCaseConversion converts names between the cases of generated code,
+// keeping initialisms, their plurals and letters of any script
+func TestCaseConversion(t *testing.T) {
+	tests := []struct {
+		in                   string
+		pascal, camel, snake string
+	}{
+		{"user", "User", "user", "user"},
+		{"user_id", "UserID", "userID", "user_id"},
+		{"UserID", "UserID", "userID", "user_id"},
+		{"HTTPServer", "HTTPServer", "httpServer", "http_server"},
+		{"http-server", "HTTPServer", "httpServer", "http_server"},
+		{"XMLHttpRequest", "XMLHTTPRequest", "xmlHTTPRequest", "xml_http_request"},
+		{"userIDs", "UserIDs", "userIDs", "user_ids"},
+		{"user_ids", "UserIDs", "userIDs", "user_ids"},
+		{"listAPIs", "ListAPIs", "listAPIs", "list_apis"},
+		{"APIs", "APIs", "apis", "apis"},
+		{"IDsByName", "IDsByName", "idsByName", "ids_by_name"},
+		{"Ids", "IDs", "ids", "ids"},
+		{"create order", "CreateOrder", "createOrder", "create_order"},
+		{"CREATE_ORDER", "CreateOrder", "createOrder", "create_order"},
+		{"order2Item", "Order2Item", "order2Item", "order2_item"},
+		{"Ünïcode", "Ünïcode", "ünïcode", "ünïcode"},
+		{"ÜberCliente", "ÜberCliente", "überCliente", "über_cliente"},
+		{"déjà_vu", "DéjàVu", "déjàVu", "déjà_vu"},
+		{"", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ToPascalCase(tt.in); got != tt.pascal {
+			t.Errorf("ToPascalCase(%q) = %q, want %q", tt.in, got, tt.pascal)
+		}
+		if got := ToCamelCase(tt.in); got != tt.camel {
+			t.Errorf("ToCamelCase(%q) = %q, want %q", tt.in, got, tt.camel)
+		}
+		if got := ToSnakeCase(tt.in); got != tt.snake {
+			t.Errorf("ToSnakeCase(%q) = %q, want %q", tt.in, got, tt.snake)
+		}
+	}
+}
+
+// TestToResourcePath turns component names into plural kebab-case URL
+// segments
+func TestToResourcePath(t *testing.T) {
+	tests := map[string]string{
+		"User":       "users",
+		"OrderItem":  "order-items",
+		"category":   "categories",
+		"Address":    "addresses",
+		"APIKey":     "api-keys",
+		"user_id":    "user-ids",
+		"HTTPServer": "http-servers",
+	}
+	for in, want := range tests {
+		if got := ToResourcePath(in); got != want {
+			t.Errorf("ToResourcePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
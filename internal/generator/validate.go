@@ -0,0 +1,224 @@
+package generator
+
+import (
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// ValidateName checks that name yields valid Go identifiers for a component,
+// described by kind in messages (the translated "kind.model", "kind.usecase"...).
+// The name may use any letters, digits, '_' and '-', must start with a letter
+// that has an upper case form, and its camelCase form must not be a Go keyword
+// or predeclared identifier, since generated code uses it for variables. Its
+// snake_case form names files, so it must not end in a suffix the go command
+// reads as a build constraint (_test, _linux, _amd64...).
+func ValidateName(kind, name string) error {
+	if err := validateWords(kind, name); err != nil {
+		return err
+	}
+
+	camel := ToCamelCase(name)
+	if token.IsKeyword(camel) {
+		return nameError(kind, name, i18n.T("validate.keyword", camel), ToPascalCase(name)+"Item")
+	}
+	if types.Universe.Lookup(camel) != nil {
+		return nameError(kind, name, i18n.T("validate.predeclared", camel), ToPascalCase(name)+"Item")
+	}
+
+	snake := ToSnakeCase(ToPascalCase(name))
+	switch constraint := fileConstraint(snake); {
+	case constraint == "test":
+		return nameError(kind, name, i18n.T("validate.suffix.test", snake), ToPascalCase(name)+"Item")
+	case constraint != "":
+		return nameError(kind, name, i18n.T("validate.suffix.platform", snake, constraint), ToPascalCase(name)+"Item")
+	}
+	return nil
+}
+
+// knownOS and knownArch are the GOOS and GOARCH values go/build recognizes
+// in file name suffixes
+var (
+	knownOS = map[string]bool{
+		"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true,
+		"hurd": true, "illumos": true, "ios": true, "js": true, "linux": true, "nacl": true,
+		"netbsd": true, "openbsd": true, "plan9": true, "solaris": true, "wasip1": true,
+		"windows": true, "zos": true,
+	}
+	knownArch = map[string]bool{
+		"386": true, "amd64": true, "amd64p32": true, "arm": true, "armbe": true, "arm64": true,
+		"arm64be": true, "loong64": true, "mips": true, "mipsle": true, "mips64": true,
+		"mips64le": true, "mips64p32": true, "mips64p32le": true, "ppc": true, "ppc64": true,
+		"ppc64le": true, "riscv": true, "riscv64": true, "s390": true, "s390x": true,
+		"sparc": true, "sparc64": true, "wasm": true,
+	}
+)
+
+// fileConstraint returns the constraint the go command infers from the file
+// name snake.go: "test" for test files, or the platform of a _GOOS, _GOARCH
+// or _GOOS_GOARCH suffix. As in go/build, the first word never counts, so
+// linux.go builds everywhere.
+func fileConstraint(snake string) string {
+	words := strings.Split(snake, "_")
+	n := len(words)
+	switch {
+	case n < 2:
+		return ""
+	case words[n-1] == "test":
+		return "test"
+	case n > 2 && knownOS[words[n-2]] && knownArch[words[n-1]]:
+		return words[n-2] + "/" + words[n-1]
+	case knownOS[words[n-1]], knownArch[words[n-1]]:
+		return words[n-1]
+	}
+	return ""
+}
+
+// validateWords checks the characters of name and that its PascalCase form
+// is an exported identifier
+func validateWords(kind, name string) error {
+	if name == "" {
+		return codedError(CodeInvalidName, "validate.name.empty", kind)
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' {
+			return nameError(kind, name, i18n.T("validate.name.char", r), suggestName(name))
+		}
+	}
+	if first, _ := utf8.DecodeRuneInString(name); !unicode.IsLetter(first) {
+		return nameError(kind, name, i18n.T("validate.name.letter"), suggestName(name))
+	}
+	if first, _ := utf8.DecodeRuneInString(ToPascalCase(name)); !unicode.IsUpper(first) {
+		return nameError(kind, name, i18n.T("validate.name.upper"), "")
+	}
+	return nil
+}
+
+// nameError describes an invalid name, suggesting a valid one when possible
+func nameError(kind, name, reason, suggestion string) error {
+	if suggestion != "" && suggestion != name && validateWords(kind, suggestion) == nil {
+		return codedError(CodeInvalidName, "validate.name.suggest", kind, name, reason, suggestion)
+	}
+	return codedError(CodeInvalidName, "validate.name.invalid", kind, name, reason)
+}
+
+// suggestName returns name without invalid characters and leading digits,
+// in PascalCase
+func suggestName(name string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '-'
+	}, name)
+	cleaned = strings.TrimLeftFunc(cleaned, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	return ToPascalCase(cleaned)
+}
+
+// ValidateProjectName checks the name of a new project, used as its
+// directory and as the last element of the default module path
+func ValidateProjectName(name string) error {
+	if name == "" {
+		return codedError(CodeInvalidName, "validate.project.empty")
+	}
+	for _, r := range name {
+		if !isModulePathChar(r) {
+			return codedError(CodeInvalidName, "validate.project.char", name, r)
+		}
+	}
+	if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "-") {
+		return codedError(CodeInvalidName, "validate.project.start", name)
+	}
+	return nil
+}
+
+// ValidateModulePath checks a Go module path with the rules of the go
+// command: elements separated by '/' made of ASCII letters, digits and
+// "-._~", none empty, "." or "..", nor starting or ending with a dot
+func ValidateModulePath(path string) error {
+	if path == "" {
+		return codedError(CodeInvalidModulePath, "validate.module.empty")
+	}
+	for _, r := range path {
+		if r != '/' && !isModulePathChar(r) {
+			return codedError(CodeInvalidModulePath, "validate.module.char", path, r, suggestModulePath(path))
+		}
+	}
+	for _, elem := range strings.Split(path, "/") {
+		switch {
+		case elem == "":
+			return codedError(CodeInvalidModulePath, "validate.module.empty_elem", path)
+		case elem == "." || elem == "..":
+			return codedError(CodeInvalidModulePath, "validate.module.dots", path, elem)
+		case strings.HasPrefix(elem, ".") || strings.HasSuffix(elem, "."):
+			return codedError(CodeInvalidModulePath, "validate.module.dot_edge", path, elem)
+		}
+	}
+	return nil
+}
+
+// isModulePathChar reports whether r may appear in a module path element
+func isModulePathChar(r rune) bool {
+	return r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-._~", r))
+}
+
+// suggestModulePath proposes path with the invalid characters replaced
+func suggestModulePath(path string) string {
+	suggestion := strings.Map(func(r rune) rune {
+		if r == '/' || isModulePathChar(r) {
+			return r
+		}
+		return '-'
+	}, strings.TrimSpace(path))
+	if ValidateModulePath(suggestion) != nil {
+		return ""
+	}
+	return i18n.T("validate.try", suggestion)
+}
+
+// ValidateOption checks that value is one of allowed, suggesting the closest
+// allowed value for typos
+func ValidateOption(option, value string, allowed []string) error {
+	if contains(allowed, value) {
+		return nil
+	}
+
+	best, distance := "", len(value)/2+1
+	for _, candidate := range allowed {
+		if d := editDistance(strings.ToLower(value), candidate); d < distance {
+			best, distance = candidate, d
+		}
+	}
+	if best != "" {
+		return codedError(CodeInvalidOption, "validate.option.suggest", value, option, best, strings.Join(allowed, ", "))
+	}
+	return codedError(CodeInvalidOption, "validate.option.invalid", value, option, strings.Join(allowed, ", "))
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		cur := make([]int, len(br)+1)
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(br)]
+}
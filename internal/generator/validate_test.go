@@ -0,0 +1,150 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// TestValidateName accepts names yielding valid identifiers and rejects the
+// others, with an alternative when there is one
+func TestValidateName(t *testing.T) {
+	kind := i18n.T("kind.model")
+	suggest := func(name, reason, suggestion string) string {
+		return i18n.T("validate.name.suggest", kind, name, reason, suggestion)
+	}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Order", ""},
+		{"order_item", ""},
+		{"create-order", ""},
+		{"Ünïcode", ""},
+		{"Order2", ""},
+		{"", i18n.T("validate.name.empty", kind)},
+		{"Type", suggest("Type", i18n.T("validate.keyword", "type"), "TypeItem")},
+		{"func", suggest("func", i18n.T("validate.keyword", "func"), "FuncItem")},
+		{"String", suggest("String", i18n.T("validate.predeclared", "string"), "StringItem")},
+		{"error", suggest("error", i18n.T("validate.predeclared", "error"), "ErrorItem")},
+		{"New", suggest("New", i18n.T("validate.predeclared", "new"), "NewItem")},
+		{"1Order", suggest("1Order", i18n.T("validate.name.letter"), "Order")},
+		{"_order", suggest("_order", i18n.T("validate.name.letter"), "Order")},
+		{"Order!", suggest("Order!", i18n.T("validate.name.char", '!'), "Order")},
+		{"order.item", suggest("order.item", i18n.T("validate.name.char", '.'), "OrderItem")},
+		{"123", i18n.T("validate.name.invalid", kind, "123", i18n.T("validate.name.letter"))},
+		{"日本", i18n.T("validate.name.invalid", kind, "日本", i18n.T("validate.name.upper"))},
+	}
+
+	for _, tt := range tests {
+		err := ValidateName(kind, tt.name)
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("ValidateName(%q) = %v, want nil", tt.name, err)
+		case tt.want != "" && (err == nil || err.Error() != tt.want):
+			t.Errorf("ValidateName(%q) = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+// TestValidateNameFileSuffixes rejects names whose files the go command would
+// only build in tests or on one platform, suggesting one that builds
+// everywhere
+func TestValidateNameFileSuffixes(t *testing.T) {
+	kind := i18n.T("kind.model")
+	tests := []struct {
+		name   string
+		reason string
+	}{
+		{"OrderTest", i18n.T("validate.suffix.test", "order_test")},
+		{"order_test", i18n.T("validate.suffix.test", "order_test")},
+		{"FooLinux", i18n.T("validate.suffix.platform", "foo_linux", "linux")},
+		{"FooWindows", i18n.T("validate.suffix.platform", "foo_windows", "windows")},
+		{"FooAmd64", i18n.T("validate.suffix.platform", "foo_amd64", "amd64")},
+		{"FooLinuxArm64", i18n.T("validate.suffix.platform", "foo_linux_arm64", "linux/arm64")},
+		{"Test", ""},
+		{"Linux", ""},
+		{"Wasm", ""},
+		{"TestOrder", ""},
+		{"LinuxServer", ""},
+		{"Testing", ""},
+		{"Foo386", ""},
+		{"foo_386", ""},
+	}
+
+	for _, tt := range tests {
+		err := ValidateName(kind, tt.name)
+		if tt.reason == "" {
+			if err != nil {
+				t.Errorf("ValidateName(%q) = %v, want nil", tt.name, err)
+			}
+			continue
+		}
+		want := i18n.T("validate.name.suggest", kind, tt.name, tt.reason, ToPascalCase(tt.name)+"Item")
+		if err == nil || err.Error() != want {
+			t.Errorf("ValidateName(%q) = %v, want %q", tt.name, err, want)
+		}
+	}
+}
+
+// TestValidateModulePath checks module paths with the rules of the go
+// command, suggesting a fix for invalid characters
+func TestValidateModulePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"example.com/shop", ""},
+		{"github.com/user/my-service", ""},
+		{"shop", ""},
+		{"example.com/shop/v2", ""},
+		{"", i18n.T("validate.module.empty")},
+		{"example.com/my shop", i18n.T("validate.module.char", "example.com/my shop", ' ', i18n.T("validate.try", "example.com/my-shop"))},
+		{"example.com/tienda/ñandú", i18n.T("validate.module.char", "example.com/tienda/ñandú", 'ñ', i18n.T("validate.try", "example.com/tienda/-and-"))},
+		{"example.com//shop", i18n.T("validate.module.empty_elem", "example.com//shop")},
+		{"example.com/shop/", i18n.T("validate.module.empty_elem", "example.com/shop/")},
+		{"example.com/../shop", i18n.T("validate.module.dots", "example.com/../shop", "..")},
+		{"example.com/./shop", i18n.T("validate.module.dots", "example.com/./shop", ".")},
+		{"example.com/.shop", i18n.T("validate.module.dot_edge", "example.com/.shop", ".shop")},
+		{"example.com/shop.", i18n.T("validate.module.dot_edge", "example.com/shop.", "shop.")},
+	}
+
+	for _, tt := range tests {
+		err := ValidateModulePath(tt.path)
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("ValidateModulePath(%q) = %v, want nil", tt.path, err)
+		case tt.want != "" && (err == nil || err.Error() != tt.want):
+			t.Errorf("ValidateModulePath(%q) = %v, want %q", tt.path, err, tt.want)
+		}
+	}
+}
+
+// TestValidateOption accepts the allowed values and suggests the closest one
+// for typos
+func TestValidateOption(t *testing.T) {
+	allowed := strings.Join(Frameworks, ", ")
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"chi", ""},
+		{"nethttp", ""},
+		{"gim", i18n.T("validate.option.suggest", "gim", "--framework", "gin", allowed)},
+		{"Fiber", i18n.T("validate.option.suggest", "Fiber", "--framework", "fiber", allowed)},
+		{"net-http", i18n.T("validate.option.suggest", "net-http", "--framework", "nethttp", allowed)},
+		{"express", i18n.T("validate.option.invalid", "express", "--framework", allowed)},
+		{"", i18n.T("validate.option.invalid", "", "--framework", allowed)},
+	}
+
+	for _, tt := range tests {
+		err := ValidateOption("--framework", tt.value, Frameworks)
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("ValidateOption(%q) = %v, want nil", tt.value, err)
+		case tt.want != "" && (err == nil || err.Error() != tt.want):
+			t.Errorf("ValidateOption(%q) = %v, want %q", tt.value, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,377 @@
+package graph
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// Layers of a generated project, from the outside in
+const (
+	LayerEntrypoints = "entrypoints"
+	LayerAdapters    = "adapters"
+	LayerUsecases    = "usecases"
+	LayerPorts       = "ports"
+	LayerModels      = "models"
+)
+
+// Options controls how the graph is built
+type Options struct {
+	Dir        string
+	ModulePath string
+}
+
+// Node is a component of the project
+type Node struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Layer   string `json:"layer"`
+	Kind    string `json:"kind"`
+	Package string `json:"package"`
+	File    string `json:"file"`
+}
+
+// Edge records that From uses To
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Graph holds the components of a project and their dependencies
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// layerOf assigns a package directory to a layer following the layout
+// created by 'cleango new', or returns "" for packages outside the layers
+func layerOf(dir string) string {
+	switch {
+	case dir == "domain/models" || strings.HasPrefix(dir, "domain/models/"):
+		return LayerModels
+	case dir == "domain/usecases" || strings.HasPrefix(dir, "domain/usecases/"):
+		return LayerUsecases
+	case dir == "domain/errors":
+		return ""
+	case strings.HasPrefix(dir, "domain/"):
+		return LayerPorts
+	case strings.HasPrefix(dir, "infrastructure/adapters/"):
+		return LayerAdapters
+	case strings.HasPrefix(dir, "infrastructure/entrypoints/"):
+		return LayerEntrypoints
+	}
+	return ""
+}
+
+// entrypointSuffixes name the component types of the entrypoint layer
+var entrypointSuffixes = []string{"Handler", "API", "Server", "Resolver"}
+
+// componentLayer returns the layer of an exported type declared in a layer
+// directory, or "" when the type is a DTO or configuration struct rather
+// than a component. Interfaces declared next to the use cases other than the
+// use cases themselves are the ports they depend on.
+func componentLayer(layer, name string, typ ast.Expr) string {
+	_, isInterface := typ.(*ast.InterfaceType)
+	_, isStruct := typ.(*ast.StructType)
+
+	switch layer {
+	case LayerModels:
+		if isStruct {
+			return layer
+		}
+	case LayerUsecases:
+		if isInterface && strings.HasSuffix(name, "UseCase") {
+			return layer
+		}
+		if isInterface {
+			return LayerPorts
+		}
+	case LayerPorts:
+		if isInterface {
+			return layer
+		}
+	case LayerAdapters:
+		if (isInterface || isStruct) && !strings.HasSuffix(name, "Config") {
+			return layer
+		}
+	case LayerEntrypoints:
+		if strings.HasSuffix(name, "OutputResolver") {
+			return ""
+		}
+		for _, suffix := range entrypointSuffixes {
+			if strings.HasSuffix(name, suffix) {
+				return layer
+			}
+		}
+	}
+	return ""
+}
+
+// sourceFile is a parsed file with the package it belongs to
+type sourceFile struct {
+	dir     string
+	rel     string
+	file    *ast.File
+	imports map[string]string
+}
+
+// typeKey identifies a type by import path and name
+type typeKey struct {
+	path string
+	name string
+}
+
+// builder accumulates the graph while walking the sources
+type builder struct {
+	opts  Options
+	files []*sourceFile
+	types map[string]map[string]bool
+	nodes map[typeKey]*Node
+	edges map[Edge]bool
+}
+
+// Build parses the domain and infrastructure packages of the project and
+// returns its components and the dependencies between them. A component
+// depends on another when its declaration, its methods or its New
+// constructor mention the other's type, or the Input and Output of a use case.
+func Build(opts Options) (*Graph, error) {
+	b := &builder{
+		opts:  opts,
+		types: map[string]map[string]bool{},
+		nodes: map[typeKey]*Node{},
+		edges: map[Edge]bool{},
+	}
+
+	for _, root := range []string{"domain", "infrastructure"} {
+		if err := b.parse(root); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range b.files {
+		b.collectNodes(f)
+	}
+	for _, f := range b.files {
+		b.collectEdges(f)
+	}
+
+	g := &Graph{Nodes: []Node{}, Edges: []Edge{}}
+	for _, node := range b.nodes {
+		g.Nodes = append(g.Nodes, *node)
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+	for edge := range b.edges {
+		g.Edges = append(g.Edges, edge)
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+	return g, nil
+}
+
+// parse reads the Go files under root
+func (b *builder) parse(root string) error {
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(filepath.Join(b.opts.Dir, root), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(b.opts.Dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return i18n.Error("err.read", rel, err)
+		}
+
+		f := &sourceFile{dir: filepath.ToSlash(filepath.Dir(rel)), rel: rel, file: file, imports: map[string]string{}}
+		for _, spec := range file.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			name := importName(importPath)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			f.imports[name] = importPath
+		}
+		b.files = append(b.files, f)
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// importPath returns the import path of a package directory of the project
+func (b *builder) importPath(dir string) string {
+	return b.opts.ModulePath + "/" + dir
+}
+
+// collectNodes records the types declared in f and the components among them
+func (b *builder) collectNodes(f *sourceFile) {
+	pkgPath := b.importPath(f.dir)
+	if b.types[pkgPath] == nil {
+		b.types[pkgPath] = map[string]bool{}
+	}
+
+	layer := layerOf(f.dir)
+	for _, decl := range f.file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			b.types[pkgPath][ts.Name.Name] = true
+			if layer == "" || !ts.Name.IsExported() {
+				continue
+			}
+			nodeLayer := componentLayer(layer, ts.Name.Name, ts.Type)
+			if nodeLayer == "" {
+				continue
+			}
+
+			kind := "struct"
+			if _, ok := ts.Type.(*ast.InterfaceType); ok {
+				kind = "interface"
+			}
+			b.nodes[typeKey{pkgPath, ts.Name.Name}] = &Node{
+				ID:      f.dir + "." + ts.Name.Name,
+				Name:    ts.Name.Name,
+				Layer:   nodeLayer,
+				Kind:    kind,
+				Package: f.dir,
+				File:    f.rel,
+			}
+		}
+	}
+}
+
+// collectEdges records the dependencies of the components declared in f
+func (b *builder) collectEdges(f *sourceFile) {
+	pkgPath := b.importPath(f.dir)
+
+	for _, decl := range f.file.Decls {
+		var owner string
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			if d.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range d.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if node := b.resolve(pkgPath, ts.Name.Name); node != nil {
+					b.addEdges(f, node, ts.Type)
+				}
+			}
+			continue
+		case *ast.FuncDecl:
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				owner = receiverName(d.Recv.List[0].Type)
+			} else if strings.HasPrefix(d.Name.Name, "New") {
+				owner = strings.TrimPrefix(d.Name.Name, "New")
+			}
+		}
+
+		if node := b.resolve(pkgPath, owner); owner != "" && node != nil {
+			b.addEdges(f, node, decl)
+		}
+	}
+}
+
+// addEdges links node to every component mentioned in n
+func (b *builder) addEdges(f *sourceFile, node *Node, n ast.Node) {
+	pkgPath := b.importPath(f.dir)
+
+	ast.Inspect(n, func(n ast.Node) bool {
+		var target *Node
+		switch x := n.(type) {
+		case *ast.SelectorExpr:
+			if ident, ok := x.X.(*ast.Ident); ok {
+				if importPath, ok := f.imports[ident.Name]; ok {
+					target = b.resolve(importPath, x.Sel.Name)
+				}
+			}
+			if target == nil {
+				return true
+			}
+		case *ast.Ident:
+			if b.types[pkgPath][x.Name] {
+				target = b.resolve(pkgPath, x.Name)
+			}
+		}
+
+		if target != nil && target.ID != node.ID {
+			b.edges[Edge{From: node.ID, To: target.ID}] = true
+			return false
+		}
+		return true
+	})
+}
+
+// resolve maps a type to the component it belongs to: the component itself,
+// the use case of an Input or Output DTO, or the exported component an
+// unexported implementation is named after
+func (b *builder) resolve(pkgPath, name string) *Node {
+	if node, ok := b.nodes[typeKey{pkgPath, name}]; ok {
+		return node
+	}
+	for _, suffix := range []string{"Input", "Output"} {
+		if base := strings.TrimSuffix(name, suffix); base != name {
+			if node, ok := b.nodes[typeKey{pkgPath, base + "UseCase"}]; ok {
+				return node
+			}
+		}
+	}
+	if !ast.IsExported(name) {
+		for key, node := range b.nodes {
+			if key.path == pkgPath && strings.EqualFold(key.name, name) {
+				return node
+			}
+		}
+	}
+	return nil
+}
+
+// receiverName returns the type name of a method receiver
+func receiverName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(t.X)
+	case *ast.IndexExpr:
+		return receiverName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// importName guesses the package name of an import path: its last element,
+// skipping major version suffixes and a "go-" prefix
+func importName(importPath string) string {
+	elements := strings.Split(importPath, "/")
+	name := elements[len(elements)-1]
+	if len(elements) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = elements[len(elements)-2]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	return strings.ReplaceAll(name, "-", "")
+}
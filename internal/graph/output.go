@@ -0,0 +1,127 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// Output formats
+const (
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+	FormatJSON    = "json"
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatDOT, FormatMermaid, FormatJSON}
+
+// layerOrder lists the layers from the outside in
+var layerOrder = []string{LayerEntrypoints, LayerAdapters, LayerUsecases, LayerPorts, LayerModels}
+
+// layerColors is the fill colour of each layer
+var layerColors = map[string]string{
+	LayerEntrypoints: "#90caf9",
+	LayerAdapters:    "#a5d6a7",
+	LayerUsecases:    "#ffcc80",
+	LayerPorts:       "#ce93d8",
+	LayerModels:      "#fff59d",
+}
+
+// Write renders the graph in the given format
+func Write(w io.Writer, g *Graph, format string) error {
+	switch format {
+	case FormatDOT, "":
+		return writeDOT(w, g)
+	case FormatMermaid:
+		return writeMermaid(w, g)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(g)
+	default:
+		return i18n.Error("format.unsupported", format, strings.Join(Formats, ", "))
+	}
+}
+
+// byLayer groups the nodes by layer
+func byLayer(g *Graph) map[string][]Node {
+	layers := map[string][]Node{}
+	for _, node := range g.Nodes {
+		layers[node.Layer] = append(layers[node.Layer], node)
+	}
+	return layers
+}
+
+// writeDOT renders a Graphviz digraph with one cluster per layer
+func writeDOT(w io.Writer, g *Graph) error {
+	var b strings.Builder
+	b.WriteString("digraph cleango {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+
+	layers := byLayer(g)
+	for _, layer := range layerOrder {
+		nodes := layers[layer]
+		if len(nodes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n\tsubgraph %q {\n", "cluster_"+layer)
+		fmt.Fprintf(&b, "\t\tlabel=%q;\n", layer)
+		b.WriteString("\t\tstyle=dashed;\n")
+		for _, node := range nodes {
+			fmt.Fprintf(&b, "\t\t%q [label=%q, fillcolor=%q];\n", node.ID, node.Name, layerColors[layer])
+		}
+		b.WriteString("\t}\n")
+	}
+
+	if len(g.Edges) > 0 {
+		b.WriteString("\n")
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "\t%q -> %q;\n", edge.From, edge.To)
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMermaid renders a Mermaid flowchart with one subgraph per layer
+func writeMermaid(w io.Writer, g *Graph) error {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	layers := byLayer(g)
+	for _, layer := range layerOrder {
+		nodes := layers[layer]
+		if len(nodes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "  subgraph %s [%s]\n", layer, layer)
+		for _, node := range nodes {
+			fmt.Fprintf(&b, "    %s[%q]:::%s\n", mermaidID(node.ID), node.Name, layer)
+		}
+		b.WriteString("  end\n")
+	}
+
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "  %s --> %s\n", mermaidID(edge.From), mermaidID(edge.To))
+	}
+	for _, layer := range layerOrder {
+		if len(layers[layer]) > 0 {
+			fmt.Fprintf(&b, "  classDef %s fill:%s,stroke:#555\n", layer, layerColors[layer])
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidID turns a node ID into a Mermaid identifier
+func mermaidID(id string) string {
+	return strings.NewReplacer("/", "_", ".", "_", "-", "_").Replace(id)
+}
@@ -0,0 +1,1043 @@
+package i18n
+
+// en holds the English messages
+var en = map[string]string{
+	// cleango (root command, shared CLI texts)
+	"root.short": "Scaffolding CLI for Go projects with Clean Architecture",
+	"root.long": `cleango is a CLI that makes it easy to create and manage Go projects
+following the principles of Clean Architecture.
+
+Features:
+  • Fast project generation with a predefined structure
+  • Adoption in existing Go repositories (init)
+  • Several HTTP frameworks (net/http, chi, gin, fiber)
+  • Support for several databases (Postgres, MySQL, MongoDB, Oracle)
+  • Component generation (usecases, adapters, models, handlers, gRPC, GraphQL)
+  • Safe removal of generated components (remove)
+  • Model renaming across every layer with a preview (rename)
+  • Templates customisable per project and per user (templates)
+  • Template packs shared between teams (pack)
+  • Reusable presets to create projects (new --from, preset)
+  • OpenAPI 3 specification generated from the code, and code from the specification
+  • Mocks of the domain and adapter interfaces for tests
+  • Check of the Clean Architecture dependency rule
+  • Component graph by layer in DOT, Mermaid or JSON
+  • Project diagnosis and drift correction (doctor)
+  • Upgrade of existing projects to the new templates (upgrade)
+  • Centralised configuration and structured logger
+  • JSON output of every command to integrate it with other tools (--output json)
+  • Messages in Spanish and English (--lang, CLEANGO_LANG or the system language)`,
+	"root.flag.output":        "Output format: human or json (one JSON document per command)",
+	"root.flag.lang":          "Language of the messages: es or en (defaults to CLEANGO_LANG or the system language)",
+	"cli.cancelled":           "operation cancelled",
+	"output.err.openapi_file": "%w; the specification file is set with --file",
+	"output.err.confirmation": "--output json does not support interactive confirmations; use %s",
+	"usage.usage":             "Usage:",
+	"usage.aliases":           "Aliases:",
+	"usage.examples":          "Examples:",
+	"usage.commands":          "Available Commands:",
+	"usage.flags":             "Flags:",
+	"usage.global_flags":      "Global Flags:",
+	"usage.topics":            "Additional help topics:",
+	"usage.more":              "Use \"{{.CommandPath}} [command] --help\" for more information about a command.",
+	"usage.version": `{{.Name}} version {{.Version}}
+`,
+	"usage.version_flag":       "version for %s",
+	"usage.help_flag":          "help for %s",
+	"usage.help_command":       "Help about any command",
+	"usage.completion_command": "Generate the autocompletion script for the specified shell",
+
+	// cleango new
+	"new.short": "Create a new Go project with Clean Architecture",
+	"new.long": `Create a new Go project with a Clean Architecture structure.
+
+Examples:
+  cleango new my-service
+  cleango new my-service --module github.com/user/my-service
+  cleango new my-service --framework chi --database postgres
+  cleango new my-service -m github.com/user/my-service -f gin -d postgres --redis --kafka
+  cleango new my-service --pack acme --var team=payments
+  cleango new my-service --offline --strict-deps
+  cleango new my-service --from preset.yaml --database mysql
+  cleango new my-service --save-preset backend
+
+Dependencies are pinned in go.mod to the versions tested with this version of
+cleango and resolved with 'go mod tidy'. For agents without internet access use
+--offline (module cache only), --goproxy with a proxy or a local directory,
+or --skip-deps to run no command that uses the network.
+
+A preset stores the answers to reuse them: --from accepts a YAML file or the
+name of a preset saved with --save-preset. Flags given explicitly take
+precedence over the values of the preset.
+
+The README of the project is generated in the language of the messages (--lang).
+
+If the folder already exists, the files already there are kept unless
+--on-conflict says otherwise (in a terminal it asks about each one).`,
+	"new.flag.module":          "Go module path (e.g. github.com/user/project)",
+	"new.flag.framework":       "HTTP framework: nethttp, chi, gin, fiber",
+	"new.flag.database":        "Database: none, postgres, mysql, mongodb, oracle",
+	"new.flag.redis":           "Include Redis",
+	"new.flag.kafka":           "Include Kafka",
+	"new.flag.logger":          "Logger: zap, slog",
+	"new.flag.non_interactive": "Non-interactive mode (uses default values)",
+	"new.flag.pack":            "Installed template pack (name or name@version)",
+	"new.flag.var":             "Pack variable (name=value, repeatable)",
+	"new.flag.skip_deps":       "Pin the dependencies in go.mod without downloading them",
+	"new.flag.offline":         "Resolve the dependencies from the module cache only",
+	"new.flag.goproxy":         "Module proxy: URL or local directory laid out as a proxy",
+	"new.flag.vendor":          "Copy the dependencies to vendor/",
+	"new.flag.strict_deps":     "Fail if the dependencies cannot be resolved",
+	"new.flag.from":            "Preset with the project options: YAML file or name of a saved preset",
+	"new.flag.save_preset":     "Save the chosen options as a preset with this name",
+	"new.prompt.name":          "Project name",
+	"new.prompt.module":        "Go module",
+	"new.prompt.framework":     "Select the HTTP framework",
+	"new.prompt.database":      "Select the database",
+	"new.prompt.logger":        "Select the logger",
+	"new.prompt.redis":         "Add Redis?",
+	"new.prompt.kafka":         "Add Kafka?",
+	"new.prompt.confirm":       "Create the project?",
+	"new.prompt.dir_exists":    "The folder '%s' already exists. What do you want to do?",
+	"new.prompt.dir_use":       "Use the existing folder",
+	"new.prompt.dir_cancel":    "Cancel",
+	"new.err.name_required":    "the project name is required in non-interactive mode",
+	"new.err.var_without_pack": "--var requires --pack",
+	"new.err.cwd":              "error getting the current directory: %w",
+	"new.err.generate":         "error generating the project: %w",
+	"new.summary.title": `
+=== Project summary ===`,
+	"new.summary.name":      "Name:       %s",
+	"new.summary.module":    "Module:     %s",
+	"new.summary.framework": "Framework:  %s",
+	"new.summary.database":  "Database:   %s",
+	"new.summary.logger":    "Logger:     %s",
+	"new.summary.redis":     "Redis:      %v",
+	"new.summary.kafka":     "Kafka:      %v",
+	"new.summary.pack":      "Pack:       %s %s",
+	"new.preset_saved":      "💾 Preset saved to %s",
+	"new.warn.dir_exists":   "The folder '%s' already exists. Using the existing folder.",
+	"new.generating": `
+🚀 Generating the project...`,
+	"new.done": `
+✅ Project created successfully!`,
+	"new.next_steps": `
+Next steps:`,
+	"new.database_config": `
+Base %s configuration generated in infrastructure/adapters/database/%s.go`,
+	"new.database_env": "Fill in your connection variables in .env (examples in .env.example): %s",
+	"new.add_components": `
+To add components:`,
+	"new.placeholder_name": "<name>",
+
+	// cleango add
+	"arg.name":    "[name]",
+	"arg.fields":  "[field:type[:rules]...]",
+	"arg.service": "[service]",
+	"add.short":   "Add components to the project",
+	"add.long": `Add new components to your project following Clean Architecture.
+
+Available components:
+  • usecase  - Create a new use case in domain/usecases
+  • adapter  - Create a new adapter in infrastructure/adapters/database
+  • model    - Create a new model in domain/models
+  • handler  - Create a new HTTP handler in infrastructure/entrypoints/http
+  • api      - Generate models, use cases and handlers from an OpenAPI spec
+  • grpc     - Create a gRPC service in infrastructure/entrypoints/grpc
+  • graphql  - Add a GraphQL endpoint in infrastructure/entrypoints/graphql
+
+With --mocks the mocks of the interfaces are regenerated in mocks/ and the option
+is saved in cleango.yaml for the following commands.
+
+If a file to create already exists with other content, --on-conflict decides
+what to do: in a terminal it asks showing the diff; without one the command
+fails (add api keeps the existing files).`,
+	"add.flag.mocks":    "Regenerate the mocks of the interfaces and keep them in sync",
+	"add.err.mocks":     "error updating mocks: %w",
+	"add.file":          "   File: %s",
+	"add.usecase.short": "Create a new use case",
+	"add.usecase.long": `Create a new use case in domain/usecases/.
+
+The use case follows the Clean Architecture pattern with:
+  • Use case interface (port)
+  • Concrete implementation
+  • Input struct with a Validate() method
+  • Output struct
+
+With --model, the Input fields and their validation rules are taken from the model.
+With --with-tests a table-driven test is generated in the usecases_test package,
+with a valid case and one per validation rule; the dependencies of the use case
+are injected as mocks from the mocks package (cleango mocks).
+
+Example:
+  cleango add usecase GetUser
+  cleango add usecase CreateUser --model User --with-tests`,
+	"add.usecase.flag.with_tests": "Also generate a table-driven test of the use case",
+	"add.usecase.flag.model":      "Model the Input fields and validations are derived from",
+	"add.usecase.generating":      "🔧 Generating use case '%s'...",
+	"add.usecase.err":             "error generating use case: %w",
+	"add.usecase.done":            "✅ Use case '%s' created successfully!",
+	"add.adapter.short":           "Create a new adapter/repository",
+	"add.adapter.long": `Create a new adapter in infrastructure/adapters/database/.
+
+The adapter follows the Repository pattern with:
+  • Repository interface
+  • Concrete implementation
+  • Basic CRUD methods
+  • Option to generate a base test with --with-tests to customise your connection
+
+Example:
+  cleango add adapter UserRepository
+  cleango add adapter ProductRepository`,
+	"add.adapter.flag.with_tests": "Also generate a base test to customise the adapter",
+	"add.adapter.generating":      "🔧 Generating adapter '%s'...",
+	"add.adapter.err":             "error generating adapter: %w",
+	"add.adapter.done":            "✅ Adapter '%s' created successfully!",
+	"add.model.short":             "Create a new domain model",
+	"add.model.long": `Create a new model in domain/models/.
+
+The model is a basic struct that represents a domain entity.
+Domain entities are pure business objects without external dependencies.
+
+Fields are declared as name:type[:rules], with comma-separated rules.
+  Types:  string, int, int64, float, bool, time
+  Rules:  required, min=N, max=N, email, oneof=a|b
+
+The Validate() method of the model is generated from the rules, and the handlers
+and use cases created with --model reuse the same fields and rules.
+With --with-tests a table-driven test of Validate() is generated with a case per rule.
+
+Example:
+  cleango add model User
+  cleango add model User name:string:required,max=100 email:string:required,email age:int:min=0
+  cleango add model Product name:string:required price:float:min=0 --with-tests`,
+	"add.model.flag.with_tests": "Also generate a table-driven test of Validate()",
+	"add.model.generating":      "🔧 Generating model '%s'...",
+	"add.model.err":             "error generating model: %w",
+	"add.model.done":            "✅ Model '%s' created successfully!",
+	"add.handler.short":         "Create a new HTTP handler",
+	"add.handler.long": `Create a new HTTP handler in infrastructure/entrypoints/http/.
+
+The handler includes:
+  • Handler struct
+  • Basic HTTP methods (Get, Post, Put, Delete)
+  • Input DTO with validation derived from the model (--model, or the model
+    with the same name if it exists)
+  • JSON decoding that rejects unknown fields and bodies larger than 1 MiB
+  • Domain errors answered as application/problem+json (RFC 7807)
+
+With --with-tests an httptest test is generated that sends the requests through
+the router of the project's framework, including a case per validation rule.
+
+Example:
+  cleango add handler User
+  cleango add handler Signup --model User --with-tests`,
+	"add.handler.flag.with_tests": "Also generate an httptest test of the handler",
+	"add.handler.flag.model":      "Model the request fields and validations are derived from",
+	"add.handler.generating":      "🔧 Generating handler '%s'...",
+	"add.handler.err":             "error generating handler: %w",
+	"add.handler.done":            "✅ Handler '%s' created successfully!",
+	"add.api.short":               "Generate models, use cases and handlers from an OpenAPI spec",
+	"add.api.long": `Generate the code of a project from a local OpenAPI 3 spec (contract-first).
+
+For each schema in components.schemas a model is created in domain/models/ and,
+for each operation:
+  • domain/usecases/<operation>_contract.go with the interface and the Input/Output
+    DTOs (always regenerated; do not edit it)
+  • domain/usecases/<operation>.go with the implementation to complete
+    (only created if it does not exist)
+  • infrastructure/entrypoints/http/<tag>_api.go with the handlers of each tag,
+    registered in routes.go
+
+Existing models and implementations are never overwritten, so you can run the
+command again every time the spec changes. The spec is read from disk; remote
+references are not downloaded.
+
+Example:
+  cleango add api --from openapi.yaml`,
+	"add.api.flag.from":  "OpenAPI 3 spec (YAML or JSON) the code is generated from",
+	"add.api.generating": "🔧 Generating API from '%s'...",
+	"add.api.err":        "error generating API: %w",
+	"add.api.skipped":    "already exists, not overwritten",
+	"add.api.done":       "✅ API generated successfully!",
+	"add.grpc.short":     "Create a gRPC service that exposes use cases",
+	"add.grpc.long": `Create a gRPC service whose methods call existing use cases.
+
+It generates:
+  • proto/<service>.proto with an rpc and its messages for each use case,
+    derived from the Input and Output fields
+  • infrastructure/entrypoints/grpc/<service>_server.go with the implementation
+    that translates the messages, calls the use case and converts domain errors
+    to gRPC codes
+
+The first time the gRPC server is also created (with the health and reflection
+services), GRPC_PORT (9090 by default) is added to the configuration and it is
+started from main next to the HTTP server, sharing the graceful shutdown.
+
+Without --usecase the use cases whose name contains the service name are exposed.
+If protoc, protoc-gen-go and protoc-gen-go-grpc are installed the Go code of the
+messages is generated; otherwise you are told how to install them.
+
+Example:
+  cleango add grpc User
+  cleango add grpc Billing --usecase CreateInvoice --usecase GetInvoice`,
+	"add.grpc.flag.usecase": "Use case to expose as an rpc (repeatable)",
+	"add.grpc.generating":   "🔧 Generating gRPC service '%s'...",
+	"add.grpc.err":          "error generating gRPC service: %w",
+	"add.grpc.done":         "✅ gRPC service '%s' created successfully!",
+	"add.graphql.short":     "Add a GraphQL endpoint over the models and use cases",
+	"add.graphql.long": `Add a GraphQL endpoint at /graphql mounted on the project router.
+
+These are generated in infrastructure/entrypoints/graphql/:
+  • schema.graphql with a type per model in domain/models and a field per use
+    case: Query for Get/List/Find/Search/Count, Mutation for the rest
+  • resolvers.go with the resolvers that call the use cases
+  • server.go with the HTTP handler and errors.go with the translation of domain
+    errors to GraphQL extensions (code: NOT_FOUND, BAD_USER_INPUT, ...)
+
+The entrypoint is recorded in cleango.yaml: from then on 'cleango add model',
+'add usecase' and 'add api' regenerate schema.graphql and resolvers.go.
+Do not edit those two files; add your own resolvers in other files of the
+package.
+
+Example:
+  cleango add graphql`,
+	"add.graphql.generating": "🔧 Generating GraphQL endpoint...",
+	"add.graphql.err":        "error generating GraphQL: %w",
+	"add.graphql.done":       "✅ GraphQL endpoint available at POST /graphql",
+	"mocks.done":             "✅ Mocks updated in mocks/",
+
+	// cleango doctor
+	"doctor.short": "Diagnose the state of the project",
+	"doctor.long": `Check the project against the configuration recorded in cleango.yaml and
+report the problems with how to fix them:
+
+  • Go            installed version against go.mod and the minimum (1.22)
+  • cleango.yaml  manifest present and with known values
+  • Structure     directories of the architecture
+  • Markers       comments where 'cleango add' registers routes and servers
+  • Dependencies  modules of the framework, database and extras in go.mod
+  • .env          variables of .env.example present in .env
+  • GraphQL/Mocks generated files edited by hand or out of date
+  • Generated     files of 'cleango new' and 'cleango add' edited by hand
+
+With --fix the safe fixes are applied: create directories, save the manifest,
+'go get' the dependencies, add variables to .env.example and .env, and
+regenerate schema, resolvers and mocks. The command fails if problems of
+severity error remain, so it can be used in CI.
+
+Example:
+  cleango doctor
+  cleango doctor --fix`,
+	"doctor.err":        "❌ error diagnosing the project: %v",
+	"doctor.fix_failed": "   ❌ could not be fixed: %v",
+	"doctor.fixed":      "🔧 %s: %s (fixed)",
+	"doctor.healthy":    "✅ The project is healthy",
+	"doctor.run_fix":    "💡 Run 'cleango doctor --fix' to apply the automatic fixes",
+	"doctor.errors":     "%d errors found",
+	"doctor.flag.fix":   "Apply the safe fixes",
+
+	// cleango graph
+	"graph.short": "Generate the dependency graph between components",
+	"graph.long": `Analyse the packages of the project and generate a graph of its components:
+which handlers call which use cases and which ports, adapters and models these use.
+
+Each component is assigned to a layer by the directory it lives in:
+  • entrypoints  infrastructure/entrypoints/ (handlers, gRPC services, resolvers)
+  • adapters     infrastructure/adapters/ (repositories, connections, logger)
+  • usecases     domain/usecases/ (<Name>UseCase interfaces)
+  • ports        other interfaces of domain/
+  • models       domain/models/
+
+A component depends on another when its declaration, its methods or its
+New<Name> constructor mention the type of the other (or the Input/Output of a
+use case).
+
+Formats:
+  • dot      Graphviz, with a cluster and a colour per layer
+  • mermaid  flowchart to paste into Markdown
+  • json     nodes and edges for other tools
+
+Example:
+  cleango graph | dot -Tsvg > architecture.svg
+  cleango graph --format mermaid
+  cleango graph --format json`,
+	"cli.err.analyse": "error analysing the project: %w",
+	"cli.flag.format": "Output format (%s)",
+
+	// cleango lint
+	"lint.short": "Check that dependencies point towards the domain",
+	"lint.long": `Analyse the imports of every package of the project and report the violations
+of the Clean Architecture dependency rule:
+
+  • domain-no-outer-layers   domain/ imports infrastructure/, config/ or cmd/
+  • domain-no-frameworks     domain/ imports frameworks or drivers (net/http, gin,
+                             chi, fiber, pgx, mongo, grpc, ...)
+  • models-no-usecases       domain/models imports domain/usecases
+  • entrypoints-no-adapters  an entrypoint calls an adapter without going through
+                             a use case (the logger is allowed)
+  • adapters-no-entrypoints  an adapter imports entrypoints or cmd
+
+The rules are configured in the lint section of cleango.yaml:
+
+  lint:
+    disable: [models-no-usecases]
+    severity:
+      entrypoints-no-adapters: warning
+    rules:
+      - id: usecases-no-http
+        description: Use cases know nothing about HTTP
+        from: [domain/usecases]
+        deny: [net/http]
+
+_test.go files are ignored unless 'tests: true' is set. The command fails if
+there are violations of severity error, so it can be used in CI.
+
+Example:
+  cleango lint
+  cleango lint --format json
+  cleango lint --format sarif > cleango.sarif`,
+	"lint.err":        "❌ error analysing the project: %v",
+	"lint.violations": "%d dependency violations",
+
+	// cleango mocks
+	"mocks.short": "Generate mocks of the interfaces of the project",
+	"mocks.long": `Generate a mock for every exported interface of domain/ and infrastructure/adapters/
+in the mocks/ package (one file per interface).
+
+For each method, a mock has a <Method>Func field to define its behaviour and a
+<Method>Calls slice with the arguments received. Without <Method>Func the method
+returns zero values. Mocks of interfaces that no longer exist are removed, so
+running the command again when the interfaces change is enough.
+
+With 'cleango add ... --mocks' the mocks are regenerated after creating the
+component and stay enabled in cleango.yaml for the following 'add' commands.
+
+Example:
+  cleango mocks
+  cleango add usecase GetUser --mocks`,
+	"mocks.generating": "🔧 Generating mocks...",
+	"mocks.err":        "error generating mocks: %w",
+
+	// cleango openapi
+	"openapi.short": "Generate the OpenAPI specification of the project",
+	"openapi.long": `Commands to work with the OpenAPI 3 specification of the project.
+
+Available subcommands:
+  • generate - Generate openapi.yaml from the handlers, routes and DTOs`,
+	"openapi.generate.short": "Generate openapi.yaml from the code of the project",
+	"openapi.generate.long": `Analyse the Go code of infrastructure/entrypoints/http and generate an OpenAPI 3
+specification with:
+  • Routes declared in the RegisterRoutes methods of the handlers
+  • Request bodies from the decoded DTOs (with their validation rules)
+  • Responses from the calls to WriteJSON and WriteError (problem+json)
+
+The output is deterministic: running the command twice on the same code produces
+the same file, so it can be versioned and verified in CI with --check.
+
+With --serve infrastructure/entrypoints/http/docs.go is generated as well, which
+serves the specification at /openapi.yaml and Swagger UI at /docs from the
+generated server.
+
+Example:
+  cleango openapi generate
+  cleango openapi generate --serve
+  cleango openapi generate --check`,
+	"openapi.up_to_date":       "✅ %s is up to date",
+	"openapi.generating":       "🔧 Generating OpenAPI specification...",
+	"openapi.err":              "error generating OpenAPI specification: %w",
+	"openapi.done":             "✅ OpenAPI specification generated successfully!",
+	"openapi.swagger":          "   Swagger UI available at /docs when the server runs",
+	"openapi.flag.file":        "File of the specification",
+	"openapi.flag.title":       "Title of the API (the project name by default)",
+	"openapi.flag.api_version": "Version of the API",
+	"openapi.flag.serve":       "Serve the specification and Swagger UI from the generated server",
+	"openapi.flag.check":       "Fail if the generated files are out of date (for CI)",
+
+	// cleango pack
+	"pack.short": "Manage template packs",
+	"pack.long": `A template pack distributes the style of a team or organisation: default
+options, variables asked when the project is created, templates that replace
+the embedded ones and additional files. It is described in pack.yaml:
+
+  name: acme
+  version: 1.2.0
+  description: ACME services
+  defaults:
+    modulePrefix: github.com/acme
+    framework: chi
+    database: postgres
+  variables:
+    - name: team
+      prompt: Owning team
+      required: true
+      pattern: ^[a-z-]+$
+  templates:
+    - name: logger                # templates/logger.tmpl
+    - name: mainChi
+      file: main/chi.tmpl
+      when: {framework: [chi]}
+  files:
+    - path: CODEOWNERS
+      template: files/CODEOWNERS.tmpl
+    - path: deploy/postgres.yaml
+      template: files/postgres.yaml.tmpl
+      when: {database: [postgres]}
+
+Templates use the variables with {{packVar "team"}}. Packs are installed in
+$CLEANGO_PACKS or in cleango/packs of the user configuration directory.
+
+Example:
+  cleango pack install ./acme-pack
+  cleango pack install acme-pack-1.2.0.tar.gz
+  cleango new payments --pack acme --var team=billing`,
+	"arg.path":                "[path]",
+	"pack.install.short":      "Validate and install a pack from a directory or a .tar.gz",
+	"pack.install.updated":    "✅ Pack %s updated from %s to %s",
+	"pack.install.done":       "✅ Pack %s %s installed",
+	"pack.install.usage":      "Use it with: cleango new <name> --pack %s",
+	"pack.install.next":       "cleango new <name> --pack %s",
+	"pack.list.short":         "List the installed packs",
+	"pack.list.empty":         "No packs installed. Install one with 'cleango pack install <path>'",
+	"pack.remove.short":       "Uninstall a pack",
+	"pack.remove.done":        "✅ Pack %s uninstalled",
+	"pack.install.flag.force": "Reinstall the same version or install an older one",
+
+	// cleango preset
+	"preset.short": "Manage the presets of 'cleango new'",
+	"preset.long": `A preset saves the options of 'cleango new' to create projects with the same
+choices:
+
+  modulePrefix: github.com/acme
+  framework: chi
+  database: postgres
+  logger: slog
+  redis: true
+  kafka: false
+  pack: acme
+  vars:
+    team: payments
+
+Save the answers of a session with 'cleango new --save-preset <name>' and
+reuse them with 'cleango new <project> --from <name>'. --from also accepts
+the path of a YAML file. Presets are saved in $CLEANGO_PRESETS or in
+cleango/presets of the user configuration directory.`,
+	"preset.list.short":   "List the saved presets",
+	"preset.list.empty":   "No saved presets. Save one with 'cleango new --save-preset <name>'",
+	"preset.remove.short": "Remove a saved preset",
+	"preset.remove.done":  "✅ Preset %s removed",
+
+	// cleango remove
+	"remove.short": "Remove components generated with 'cleango add'",
+	"remove.long": `Undo 'cleango add': remove the files the generator created (including their
+test), drop the route registration of handlers from routes.go and update the
+GraphQL schema, the OpenAPI spec and the mocks.
+
+For safety the command refuses to remove a component when:
+  • its files were modified since they were generated (or were generated with
+    a version of cleango that did not keep the original copy), or
+  • other files of the project still use it; file and line are listed.
+
+With --force it is removed anyway.
+
+Components:
+  • usecase  - domain/usecases/<name>.go
+  • adapter  - infrastructure/adapters/database/<name>.go
+  • model    - domain/models/<name>.go
+  • handler  - infrastructure/entrypoints/http/<name>_handler.go and its route
+
+Example:
+  cleango remove usecase GetUser
+  cleango remove handler User --force`,
+	"remove.removing":      "🗑️  Removing %s '%s'...",
+	"remove.done":          "✅ %s '%s' removed",
+	"remove.usecase.short": "Remove a use case",
+	"remove.adapter.short": "Remove an adapter",
+	"remove.model.short":   "Remove a domain model",
+	"remove.handler.short": "Remove an HTTP handler and its route",
+	"remove.flag.force":    "Remove even if the files are modified or referenced",
+
+	// cleango rename
+	"rename.short":       "Rename a component across every layer",
+	"rename.model.args":  "[current] [new]",
+	"rename.model.short": "Rename a model and everything derived from its name",
+	"rename.model.long": `Rename a model across the project: the type, and the identifiers, files and
+routes derived from its name in models, use cases, adapters, handlers, tests and
+migrations.
+
+For Customer → Client these are renamed, for example:
+  • identifiers  Customer, CustomerInput, NewCustomerHandler, customerRepository
+  • comments     // Customer represents ...
+  • routes       "/customers/:id" → "/clients/:id"
+  • files        customer.go, create_customer.go, customer_handler_test.go
+  • migrations   tables customers / customer
+
+Identifiers are rewritten from the syntax tree (go/ast): those of packages
+outside the module are left untouched and the result is formatted with gofmt.
+The command refuses to rename if a new name clashes with one already declared.
+Before applying the changes it shows the diff and asks for confirmation;
+afterwards the GraphQL schema and the mocks are regenerated.
+
+Example:
+  cleango rename model Customer Client --dry-run
+  cleango rename model Customer Client --yes`,
+	"rename.nothing":      "✅ Nothing to rename",
+	"rename.changes":      "📝 %d files change",
+	"cli.dry_run":         "ℹ️  Dry run: no file was written",
+	"rename.confirm":      "Rename %s to %s?",
+	"rename.done":         "✅ %s renamed to %s",
+	"rename.flag.dry_run": "Show the diff without applying the changes",
+	"rename.flag.yes":     "Apply the changes without asking for confirmation",
+
+	// cleango templates
+	"templates.short": "Manage custom templates",
+	"templates.long": `Every file cleango generates comes from a text/template template. A template is
+looked up, in order, in:
+
+  1. .cleango/templates/<name>.tmpl      of the project (version it with the team)
+  2. the template pack of the project, if it was created with 'cleango new --pack'
+  3. <user>/cleango/templates/<name>.tmpl
+     ($CLEANGO_TEMPLATES or the user configuration directory,
+     ~/.config/cleango/templates on Linux)
+  4. the template embedded in cleango
+
+Custom templates receive the same data as the embedded ones (ProjectConfig in
+the project templates; Name, LowerName, ... in the component templates).
+Start by copying the embedded one with 'cleango templates eject'.
+
+Example:
+  cleango templates list
+  cleango templates eject mainChi usecase
+  cleango templates eject logger --user`,
+	"templates.list.short":     "List the templates and where they are loaded from",
+	"arg.names":                "[name...]",
+	"templates.source.project": "project",
+	"templates.source.pack":    "pack",
+	"templates.source.user":    "user",
+	"templates.source.builtin": "built-in",
+	"templates.eject.short":    "Copy embedded templates to edit them",
+	"templates.eject.long": `Copy the embedded templates to .cleango/templates/ of the project, or with --user
+to the user templates directory, to customise them. Existing copies are not
+overwritten unless --force is given.
+
+Example:
+  cleango templates eject handlerGin
+  cleango templates eject --all --user`,
+	"templates.eject.no_names":   "name the templates to copy or use --all ('cleango templates list' shows them)",
+	"templates.eject.done":       "✅ Templates copied. The next commands will use your versions",
+	"templates.eject.flag.user":  "Copy to the user templates directory instead of the project",
+	"templates.eject.flag.force": "Overwrite templates already copied",
+	"templates.eject.flag.all":   "Copy every template",
+
+	// cleango upgrade
+	"upgrade.short": "Update the project files to the current templates",
+	"upgrade.long": `Regenerate the files created by 'cleango new' (main.go, server.go, config,
+logger, errors, routes, database, .env.example, Makefile, README...) with the
+templates of this version of cleango and the configuration in cleango.yaml.
+
+Each file is merged three ways: the original copy cleango saved in
+.cleango/pristine/ when it generated it is the common ancestor of your version
+and the new one.
+
+  • no local changes      it is replaced by the new version
+  • with local changes    both are merged; if they touch the same lines,
+                          conflict markers are left (<<<<<<< local / >>>>>>> cleango)
+  • deleted               it is not created again
+
+Files of projects created before cleango saved the original copies are only
+updated if they were not modified. Version .cleango/ with the project so the
+whole team can upgrade.
+
+The README is regenerated in the language recorded in cleango.yaml (lang).
+
+Example:
+  cleango upgrade --dry-run
+  cleango upgrade`,
+	"upgrade.err":          "❌ error upgrading the project: %v",
+	"upgrade.merged":       "   ⇄ %s (merged with your changes)",
+	"upgrade.conflict":     "   ✗ %s (conflicts)",
+	"upgrade.up_to_date":   "✅ The project already uses the current templates",
+	"upgrade.resolve":      "⚠️  Resolve the conflict markers and build the project",
+	"upgrade.conflicts":    "%d files with conflicts",
+	"upgrade.done":         "✅ Project upgraded. Review the changes with 'git diff'",
+	"upgrade.flag.dry_run": "Show which files would change without writing them",
+
+	// cleango init
+	"init.short": "Add Clean Architecture to an existing Go repository",
+	"init.long": `Add the Clean Architecture layout to the Go module in the current directory.
+
+The module is read from go.mod; the framework, database, Redis, Kafka and
+logger are inferred from the packages the code imports (or from the go.mod
+requirements when there is no code yet). The flags prevail over what is
+detected.
+
+Only the missing directories and files are created, the dependencies go.mod
+does not require yet are added and cleango.yaml is written if it does not
+exist. Existing files with other content are never overwritten unless told so:
+
+  • skip       keep the existing file
+  • overwrite  replace it with cleango's version
+  • backup     copy it to <file>.bak and replace it
+  • new        write cleango's version to <file>.new to review it
+  • prompt     show the diff of each file and ask what to do
+  • fail       stop with an error
+
+By default it asks in a terminal and keeps the file without one.
+
+Examples:
+  cleango init
+  cleango init --on-conflict new
+  cleango init --framework gin --database postgres --non-interactive`,
+	"init.flag.framework":       "HTTP framework: nethttp, chi, gin, fiber (defaults to the detected one)",
+	"init.flag.database":        "Database: none, postgres, mysql, mongodb, oracle (defaults to the detected one)",
+	"init.flag.logger":          "Logger: zap, slog (defaults to the detected one)",
+	"init.flag.non_interactive": "Non-interactive mode: no confirmation nor questions per file",
+	"init.summary.title": `
+=== Detected project ===`,
+	"init.summary.conflicts": "Conflicts:  %s",
+	"init.prompt.confirm":    "Add the layout to the project?",
+	"init.generating": `
+🚀 Generating the layout...`,
+	"init.err": "error initialising the project: %w",
+	"init.done": `
+✅ Clean Architecture layout added!`,
+
+	// --on-conflict
+	"conflict.flag":             "What to do with files that already exist: skip, overwrite, backup, new, prompt or fail (defaults to prompt in a terminal and %s without one)",
+	"conflict.prompt":           "%s already exists and differs from the template. What do you want to do?",
+	"conflict.prompt.skip":      "Keep the current file",
+	"conflict.prompt.overwrite": "Overwrite with cleango's version",
+	"conflict.prompt.backup":    "Overwrite and save the current one to %s",
+	"conflict.prompt.new":       "Write cleango's version to %s",
+	"conflict.summary": `
+Files that already existed:`,
+	"conflict.summary.skip":      "   = %s (kept)",
+	"conflict.summary.overwrite": "   ~ %s (overwritten)",
+	"conflict.summary.backup":    "   ~ %s (overwritten, copy in %s)",
+	"conflict.summary.new":       "   + %s (cleango's version)",
+	"conflict.summary.skipped":   "%d existing files were kept; choose another strategy with --on-conflict",
+	"conflict.summary.review":    "Review the .new files and merge them with yours",
+
+	// rollback
+	"rollback.summary": `
+The changes of the command were undone:`,
+	"rollback.removed":   "   - %s (removed)",
+	"rollback.restored":  "   ~ %s (restored)",
+	"tx.interrupted":     "Interrupted: the changes were rolled back (%d removed, %d restored)",
+	"tx.rollback_failed": "%w; in addition, not all the changes could be undone: %v",
+
+	// Components named in messages
+	"kind.field":     "field",
+	"kind.model":     "model",
+	"kind.operation": "operation",
+	"kind.service":   "service",
+	"kind.usecase":   "use case",
+	"kind.adapter":   "adapter",
+	"kind.handler":   "handler",
+
+	// Validation of names and options
+	"validate.keyword":           "%s is a Go keyword",
+	"validate.predeclared":       "%s is a predeclared Go identifier",
+	"validate.suffix.test":       "its file %s.go would only be compiled by go test",
+	"validate.suffix.platform":   "its file %s.go would only be built for %s",
+	"validate.name.empty":        "the %s name cannot be empty",
+	"validate.name.char":         "contains the character %q (use letters, digits, '_' or '-')",
+	"validate.name.letter":       "must start with a letter",
+	"validate.name.upper":        "must start with a letter that has an upper case form to be exported",
+	"validate.name.suggest":      "invalid %s name %q: %s; try %q",
+	"validate.name.invalid":      "invalid %s name %q: %s",
+	"validate.project.empty":     "the project name cannot be empty",
+	"validate.project.char":      "invalid project name %q: contains the character %q (use letters, digits, '-', '_' or '.')",
+	"validate.project.start":     "invalid project name %q: cannot start with '.' or '-'",
+	"validate.module.empty":      "the module path cannot be empty",
+	"validate.module.char":       "invalid module path %q: contains the character %q%s",
+	"validate.module.empty_elem": "invalid module path %q: it has an empty element (check the slashes)",
+	"validate.module.dots":       "invalid module path %q: cannot contain %q",
+	"validate.module.dot_edge":   "invalid module path %q: the element %q cannot start or end with '.'",
+	"validate.try":               "; try %q",
+	"validate.option.suggest":    "invalid value %q for %s, did you mean %q? (use %s)",
+	"validate.option.invalid":    "invalid value %q for %s (use %s)",
+	"option.database":            "database",
+
+	// Fields of models
+	"fields.invalid":         "invalid field %q, use name:type[:rules]",
+	"fields.type":            "unsupported type %q in field %q (types: %s)",
+	"fields.duplicated":      "the field %q is duplicated",
+	"fields.rule.type":       "the rule %s does not apply to field %q of type %s",
+	"fields.rule.number":     "the rule %s of field %q requires a valid number for %s",
+	"fields.rule.string":     "the rule %s only applies to string fields (%q)",
+	"fields.rule.oneof":      "the oneof rule of field %q requires values, e.g. oneof=a|b",
+	"fields.rule.unknown":    "unknown rule %q in field %q (rules: required, min, max, email, oneof)",
+	"fields.model.missing":   "the model %s does not exist: %w",
+	"err.read":               "error reading %s: %w",
+	"fields.struct.missing":  "struct %s not found in %s",
+	"err.read.usecases":      "error reading domain/usecases: %w",
+	"fields.usecase.missing": "the use case %s does not exist in domain/usecases (%sUseCase, %sInput and %sOutput are expected): %w",
+
+	// Components, API, gRPC and rename
+	"err.mkdir.usecases":  "error creating directory usecases: %w",
+	"err.mkdir.database":  "error creating directory database: %w",
+	"err.mkdir.models":    "error creating directory models: %w",
+	"err.mkdir.http":      "error creating directory http: %w",
+	"err.no_gomod":        "go.mod not found. Make sure you are at the root of the project",
+	"component.route":     "handler created, but its route could not be registered (add %s to RegisterRoutes): %w",
+	"err.template":        "error in template %s: %w",
+	"err.format":          "error formatting %s: %w",
+	"err.mkdir":           "error creating directory %s: %w",
+	"err.create":          "error creating %s: %w",
+	"api.schema.skipped":  "%v; the schema is skipped",
+	"api.operation.clash": "the operations %s and %s %s generate the same name %s; define distinct operationIds",
+	"api.body.param":      "%s: the body field %q matches a parameter and is skipped",
+	"api.property.scalar": "%s: the property %q of type %q is not scalar and is skipped; add it by hand",
+	"api.impl.dtos":       "%s declares %sInput; move the DTOs and the interface to %s so they are regenerated from the spec",
+	"api.route":           "add %s to RegisterRoutes: %v",
+	"grpc.no_usecases":    "there are no use cases whose name contains %q; create them with 'cleango add usecase' or name them with --usecase",
+	"grpc.server":         "the gRPC server could not be started from %s (%v); regenerate cmd/api with a recent version of cleango or add grpcentry.NewServer(\":\"+cfg.GRPCPort) to your servers",
+	"deps.installing":     "📦 Installing dependencies...",
+	"deps.install_failed": "Could not install %s: %v",
+	"warning":             "⚠️  Warning: %s",
+	"grpc.protoc.missing": `The Go code of %s was not generated because tools are missing:
+%s
+Install them and run 'go generate ./%s/...'; the project does not build until then.`,
+	"grpc.protoc.failed": `protoc failed to generate %s: %v
+%s`,
+	"grpc.protoc.done":     "Go code generated with protoc from %s",
+	"rename.diff.move":     "rename %s → %s",
+	"err.update.graphql":   "error updating GraphQL: %w",
+	"rename.from.empty":    "the current name cannot be empty",
+	"rename.same":          "the new name is the same as the current one",
+	"rename.model.missing": "the model %s does not exist (%s)",
+	"rename.model.exists":  "the model %s already exists (%s)",
+	"rename.declared":      "%s: %s → %s is already declared",
+	"rename.file.exists":   "%s → %s already exists",
+	"rename.conflicts": `the rename would clash with existing names:
+   %s`,
+
+	// Template packs, presets and dependencies
+	"pack.not_installed":     "the pack %s is not installed (install it with 'cleango pack install <path>')",
+	"pack.version.other":     "version %s of pack %s is installed, not %s",
+	"pack.version.project":   "version %s of pack %s is installed, the project was generated with %s (run 'cleango upgrade' to apply it)",
+	"pack.extract":           "error extracting %s: %w",
+	"pack.no_manifest":       "%s does not contain %s",
+	"pack.version.installed": "version %s of pack %s is already installed (use --force to reinstall it)",
+	"pack.version.newer":     "version %s of pack %s is already installed, newer than %s (use --force to install it)",
+	"pack.copy":              "error copying the pack: %w",
+	"pack.invalid.name":      "invalid name %q: use lower case letters, digits and hyphens",
+	"pack.invalid.version":   "invalid version %q: use semantic versioning (1.2.0)",
+	"pack.invalid.at":        "%s: %v",
+	"pack.invalid.var_name":  "variable %q: the name must be an identifier",
+	"pack.invalid.var_twice": "variable %q declared twice",
+	"pack.invalid.pattern":   "variable %s: invalid pattern: %v",
+	"pack.invalid.default":   "variable %s: the default value %q does not match %s",
+	"pack.invalid.template":  "templates: unknown template %q ('cleango templates list' shows the available ones)",
+	"pack.invalid.path":      "files: path %q not allowed: it must be relative to the project and outside .cleango/",
+	"pack.invalid": `invalid pack %s:
+  - %s`,
+	"pack.template.missing":      "the template is missing",
+	"pack.template.outside":      "the template %s must be inside the pack",
+	"pack.template.read":         "could not read %s",
+	"pack.var.undeclared":        "the pack %s does not declare the variable %q",
+	"pack.var.required":          "the variable %s is required",
+	"pack.var.pattern":           "the variable %s must match %s",
+	"pack.project":               "the project uses the pack %s %s: %w",
+	"pack.var.undefined":         "undefined pack variable %q",
+	"pack.template.error":        "error in template %s of pack %s: %w",
+	"err.generate":               "error generating %s: %w",
+	"pack.tar.outside":           "path %q outside the pack",
+	"pack.tar.irregular":         "%s is not a regular file",
+	"preset.invalid.name":        "invalid preset name %q: use lower case letters, digits and hyphens",
+	"preset.source.missing":      "there is no file %s nor a preset with that name",
+	"preset.source.missing_list": "there is no file %s nor a preset with that name ('cleango preset list' shows the saved ones)",
+	"preset.read":                "error reading the preset %s: %w",
+	"preset.invalid": `invalid preset %s:
+  - %s`,
+	"preset.vars":            "vars requires pack",
+	"preset.exists":          "the preset %s already exists",
+	"preset.header":          "# cleango preset. Use it with: cleango new <name> --from %s",
+	"preset.missing":         "the preset %s does not exist",
+	"deps.skip.combined":     "--skip-deps cannot be combined with --offline, --goproxy or --vendor",
+	"deps.offline.combined":  "--offline cannot be combined with --goproxy",
+	"deps.skipped":           "📦 Dependencies pinned in go.mod without downloading them (--skip-deps)",
+	"deps.resolving.offline": "📦 Resolving dependencies from the module cache (--offline)...",
+	"deps.resolving.proxy":   "📦 Resolving dependencies from %s...",
+	"deps.resolving":         "📦 Resolving dependencies...",
+	"deps.failed":            "error running go %s: %w",
+	"deps.warning":           "⚠️  Warning: error running go %s: %v",
+	"deps.pinned":            "   The versions stay pinned in go.mod; run 'go mod tidy' when you have access to the modules",
+	"deps.warning.record":    "Error running go %s: %v; the versions stay pinned in go.mod",
+
+	// Checks of doctor
+	"doctor.not_fixable":          "%s cannot be fixed automatically",
+	"doctor.pack.install":         "install version %s of the pack with 'cleango pack install <path>'",
+	"doctor.pack.version":         "version %s installed, the project was generated with %s",
+	"doctor.pack.upgrade":         "run 'cleango upgrade' to apply the installed version",
+	"doctor.pack.ok":              "version %s",
+	"doctor.go.missing":           "the go command was not found",
+	"doctor.go.install":           "install Go %s or later from https://go.dev/dl/",
+	"doctor.go.old":               "Go %s installed, the project requires Go %s",
+	"doctor.go.update":            "update Go from https://go.dev/dl/ or use GOTOOLCHAIN=go%s",
+	"doctor.go.mod":               "go.mod declares go %s, the generated routes require %s",
+	"doctor.run":                  "run: %s",
+	"doctor.manifest.missing":     "the project has no manifest; it is inferred from go.mod and the directories",
+	"doctor.manifest.save":        "save the inferred manifest so the commands do not depend on detection",
+	"doctor.manifest.framework":   "unknown framework %q",
+	"doctor.manifest.use":         "use %s in %s",
+	"doctor.manifest.database":    "unknown database %q",
+	"doctor.manifest.entrypoints": "entrypoints registered without a directory: %s",
+	"doctor.manifest.regenerate":  "generate them again with 'cleango add' or remove them from %s",
+	"doctor.structure":            "Structure",
+	"doctor.structure.ok":         "every directory of the architecture exists",
+	"doctor.structure.missing":    "missing directories: %s",
+	"doctor.structure.fix":        "create the missing directories",
+	"doctor.markers":              "Markers",
+	"doctor.markers.at":           "%s in %s",
+	"doctor.markers.ok":           "the insertion markers are present",
+	"doctor.markers.missing":      "missing markers: %s",
+	"doctor.markers.fix":          "restore the comment where 'cleango add' registers code; without it routes and servers must be registered by hand",
+	"doctor.dependencies":         "Dependencies",
+	"doctor.dependencies.ok":      "go.mod includes the %d expected dependencies",
+	"doctor.dependencies.missing": "go.mod does not include: %s",
+	"doctor.env_example.ok":       "declares the variables of the selected configuration",
+	"doctor.env_example.missing":  "missing variables: %s",
+	"doctor.env_example.fix":      "add the variables with their example values",
+	"doctor.env.missing":          "does not exist; the application will use the default values",
+	"doctor.env.create":           "create .env from .env.example and adjust its values",
+	"doctor.env.vars":             "missing variables of .env.example: %s",
+	"doctor.env.fix":              "add the variables to .env (the example values are copied)",
+	"doctor.env.ok":               "declares every variable of .env.example",
+	"doctor.graphql.outdated":     "out of date or edited by hand: %s",
+	"doctor.graphql.fix":          "regenerate them; move your own resolvers to other files of the package",
+	"doctor.graphql.ok":           "schema.graphql and resolvers.go are up to date",
+	"doctor.mocks.outdated":       "out of date: %s",
+	"doctor.mocks.ok":             "the mocks match the current interfaces",
+	"doctor.modified":             "Generated files",
+	"doctor.modified.ok":          "the %d files generated by cleango have no edits by hand",
+	"doctor.modified.files":       "edited since cleango generated them: %s",
+	"doctor.modified.fix":         "'cleango upgrade' applies newer templates keeping your edits; to start again from the template run 'cleango remove <kind> <name> --force' and 'cleango add'",
+
+	// Generators
+	"err.update.openapi":     "error updating the OpenAPI specification: %w",
+	"mocks.skipped":          "%s.%s is not mocked: %s",
+	"mocks.generic":          "it is generic",
+	"mocks.embeds":           "it embeds other interfaces",
+	"mocks.unexported":       "it has unexported methods",
+	"mocks.type":             "unsupported type: %w",
+	"openapi.marshal":        "error serialising the specification: %w",
+	"openapi.outdated":       "%s is out of date, run 'cleango openapi generate'",
+	"err.write":              "error writing %s: %w",
+	"openapi.docs.route":     "docs generated, but they could not be registered (add RegisterDocs(r) to RegisterRoutes): %w",
+	"templates.unknown":      "unknown template %q",
+	"templates.read":         "error reading the template %s: %w",
+	"templates.unknown.list": "unknown template %q (available: %s)",
+	"templates.no_gomod":     "go.mod not found. Run the command at the root of the project or use --user",
+	"templates.exists":       "%s already exists (use --force to overwrite it)",
+	"remove.kind":            "unsupported component %q (use %s)",
+	"remove.missing":         "%s does not exist",
+	"remove.modified":        "modified since they were generated: %s",
+	"remove.referenced": `still referenced in:
+   %s`,
+	"remove.refused": `the %s %s was not removed, %s
+use --force to remove it anyway`,
+	"remove.uses":         "%s:%d uses %s",
+	"upgrade.deleted":     "%s was deleted; it is not created again",
+	"upgrade.no_pristine": "%s has no original version recorded; compare it by hand with the current template",
+	"upgrade.record":      "error recording the generated files: %w",
+	"utils.marker":        "marker %q not found in %s",
+	"utils.match":         "%q not found in %s",
+	"config.no_module":    "go.mod does not declare a module",
+	"manifest.header":     "# Generated by cleango. The 'cleango add' commands read and update it.",
+	"project.chdir":       "error changing to the project directory: %w",
+	"project.require":     "error adding the dependencies to go.mod: %w",
+	"project.errors":      "error generating the error model: %w",
+	"project.database":    "error generating the database files: %w",
+	"project.pack":        "error generating the pack files: %w",
+
+	// Lint and graph
+	"lint.rule.outer":       "The domain cannot import infrastructure, config or cmd",
+	"lint.rule.frameworks":  "The domain cannot depend on HTTP frameworks, drivers or infrastructure libraries",
+	"lint.rule.models":      "Models cannot import use cases",
+	"lint.rule.entrypoints": "Entrypoints must call use cases, not adapters",
+	"lint.rule.adapters":    "Adapters cannot import entrypoints or cmd",
+	"lint.config.no_id":     "lint rule without id",
+	"lint.config.from_deny": "the lint rule %q needs from and deny",
+	"lint.config.severity":  "invalid severity %q for rule %q (use error or warning)",
+	"lint.violation":        "%s imports %s: %s",
+	"format.unsupported":    "unsupported format %q (use %s)",
+	"lint.clean":            "✅ No dependency violations (%d rules)",
+	"lint.summary":          "%d violations (%d errors)",
+
+	// OpenAPI
+	"openapi.no_handlers": "no handlers found in %s",
+	"openapi.no_routes":   "no routes found: the handlers must declare a RegisterRoutes method",
+	"openapi.parse":       "error parsing %s: %w",
+	"openapi.not_v3":      "%s is not an OpenAPI 3 document (openapi: %q)",
+
+	// init and conflicts
+	"init.scan":          "error analysing the imports: %w",
+	"conflict.no_prompt": "--on-conflict prompt needs an interactive terminal",
+	"conflict.exists":    "file %s already exists (choose what to do with --on-conflict)",
+
+	// README of the generated projects
+	"readme.title":                      "Go project with Clean Architecture",
+	"readme.structure":                  "Project Structure",
+	"readme.structure.intro":            "This project follows the principles of Clean Architecture:",
+	"readme.tree.cmd":                   "Entry point of the application",
+	"readme.tree.server":                "Start and graceful shutdown of the servers",
+	"readme.tree.config":                "Configuration",
+	"readme.tree.domain":                "Domain layer (business rules)",
+	"readme.tree.errors":                "Typed domain errors",
+	"readme.tree.models":                "Domain entities",
+	"readme.tree.usecases":              "Use cases (ports)",
+	"readme.tree.infrastructure":        "Infrastructure layer",
+	"readme.tree.adapters":              "Adapters (implementations)",
+	"readme.tree.database":              "Database repositories",
+	"readme.tree.logger":                "Logging",
+	"readme.tree.entrypoints":           "Entry points",
+	"readme.tree.http":                  "HTTP handlers",
+	"readme.tree.migrations":            "Database migrations",
+	"readme.tree.env":                   "Example environment variables",
+	"readme.tree.manifest":              "Manifest read by the cleango commands",
+	"readme.tree.makefile":              "Useful commands",
+	"readme.layers":                     "Clean Architecture Layers",
+	"readme.layers.domain":              "Domain",
+	"readme.layers.errors":              "Typed errors (NotFound, Conflict, Validation, Unauthorized)",
+	"readme.layers.models":              "Domain entities, pure business objects without external dependencies",
+	"readme.layers.usecases":            "Business logic, use cases of the application (interfaces/ports)",
+	"readme.layers.infrastructure":      "Infrastructure",
+	"readme.layers.adapters":            "Concrete implementations of the ports defined in domain",
+	"readme.layers.database":            "Repositories that access the database",
+	"readme.layers.logger":              "Logging",
+	"readme.layers.entrypoints":         "Entry points to the application",
+	"readme.layers.http":                "HTTP handlers, REST controllers",
+	"readme.errors":                     "Error handling",
+	"readme.errors.intro":               "Use cases return errors from `domain/errors` and the handlers answer them with\n`WriteError`, which translates them to HTTP status codes and `application/problem+json` bodies (RFC 7807):",
+	"readme.errors.error":               "Error",
+	"readme.errors.status":              "HTTP status",
+	"readme.errors.other":               "others",
+	"readme.config":                     "Configuration",
+	"readme.config.database":            "Database",
+	"readme.postgres":                   "Quick Start with PostgreSQL",
+	"readme.postgres.env":               "Configure the environment variables",
+	"readme.postgres.env.intro":         "Copy `.env.example` to `.env` and set your PostgreSQL URL:",
+	"readme.postgres.env.edit":          "Edit .env with your credentials",
+	"readme.postgres.db":                "Start the database (optional, with Docker)",
+	"readme.postgres.tests":             "Run the tests",
+	"readme.postgres.tests.short":       "Unit tests only (no database)",
+	"readme.postgres.tests.integration": "Integration tests (require a database)",
+	"readme.postgres.tests.all":         "All the tests",
+	"readme.postgres.or":                "or",
+	"readme.run":                        "Run the application",
+	"readme.add":                        "Add components",
+	"readme.add.model":                  "Add a new domain model",
+	"readme.add.usecase":                "Add a new use case",
+	"readme.add.adapter":                "Add a new adapter/repository",
+	"readme.add.handler":                "Add a new HTTP handler",
+	"readme.principles":                 "Clean Architecture Principles",
+	"readme.principles.frameworks":      "**Independent of frameworks**: The domain does not depend on frameworks",
+	"readme.principles.testable":        "**Testable**: The business logic can be tested without UI, database, etc.",
+	"readme.principles.ui":              "**Independent of the UI**: The UI can change without affecting the domain",
+	"readme.principles.database":        "**Independent of the database**: You can switch databases without affecting the business",
+	"readme.principles.external":        "**Independent of external agents**: The domain knows nothing about the outside world",
+	"readme.flow":                       "Dependency flow",
+	"readme.flow.inward":                "Dependencies always point inwards (towards the domain).",
+}
@@ -0,0 +1,1039 @@
+package i18n
+
+// es holds the Spanish messages, the default language
+var es = map[string]string{
+	// cleango (root command, shared CLI texts)
+	"root.short": "Scaffold CLI para proyectos Go con Clean Architecture",
+	"root.long": `cleango es un CLI que facilita la creación y gestión de proyectos Go
+siguiendo los principios de Clean Architecture.
+
+Características:
+  • Generación rápida de proyectos con estructura predefinida
+  • Adopción en repositorios Go existentes (init)
+  • Múltiples frameworks HTTP (net/http, chi, gin, fiber)
+  • Soporte para múltiples bases de datos (Postgres, MySQL, MongoDB, Oracle)
+  • Generación de componentes (usecases, adapters, models, handlers, gRPC, GraphQL)
+  • Eliminación segura de componentes generados (remove)
+  • Renombrado de modelos en todas las capas con vista previa (rename)
+  • Plantillas personalizables por proyecto y por usuario (templates)
+  • Packs de plantillas compartibles entre equipos (pack)
+  • Presets reutilizables para crear proyectos (new --from, preset)
+  • Especificación OpenAPI 3 generada desde el código, y código desde la especificación
+  • Mocks de las interfaces del dominio y adaptadores para tests
+  • Verificación de la regla de dependencias de Clean Architecture
+  • Grafo de componentes por capa en DOT, Mermaid o JSON
+  • Diagnóstico del proyecto y corrección de desviaciones (doctor)
+  • Actualización de proyectos existentes a las plantillas nuevas (upgrade)
+  • Configuración centralizada y logger estructurado
+  • Salida JSON de todos los comandos para integrarlo en otras herramientas (--output json)
+  • Mensajes en español e inglés (--lang, CLEANGO_LANG o el idioma del sistema)`,
+	"root.flag.output":        "Formato de salida: human o json (un documento JSON por comando)",
+	"root.flag.lang":          "Idioma de los mensajes: es o en (por defecto CLEANGO_LANG o el idioma del sistema)",
+	"cli.cancelled":           "operación cancelada",
+	"output.err.openapi_file": "%w; el archivo de la especificación se indica con --file",
+	"output.err.confirmation": "--output json no admite confirmaciones interactivas; usa %s",
+	"usage.usage":             "Uso:",
+	"usage.aliases":           "Alias:",
+	"usage.examples":          "Ejemplos:",
+	"usage.commands":          "Comandos disponibles:",
+	"usage.flags":             "Flags:",
+	"usage.global_flags":      "Flags globales:",
+	"usage.topics":            "Temas de ayuda adicionales:",
+	"usage.more":              "Usa \"{{.CommandPath}} [comando] --help\" para más información sobre un comando.",
+	"usage.version": `{{.Name}} versión {{.Version}}
+`,
+	"usage.version_flag":       "versión de %s",
+	"usage.help_flag":          "ayuda de %s",
+	"usage.help_command":       "Ayuda sobre cualquier comando",
+	"usage.completion_command": "Genera el script de autocompletado para la shell indicada",
+
+	// cleango new
+	"new.short": "Crea un nuevo proyecto Go con Clean Architecture",
+	"new.long": `Crea un nuevo proyecto Go con estructura de Clean Architecture.
+
+Ejemplos:
+  cleango new my-service
+  cleango new my-service --module github.com/user/my-service
+  cleango new my-service --framework chi --database postgres
+  cleango new my-service -m github.com/user/my-service -f gin -d postgres --redis --kafka
+  cleango new my-service --pack acme --var team=pagos
+  cleango new my-service --offline --strict-deps
+  cleango new my-service --from preset.yaml --database mysql
+  cleango new my-service --save-preset backend
+
+Las dependencias se fijan en go.mod en las versiones probadas con esta versión de
+cleango y se resuelven con 'go mod tidy'. Para agentes sin acceso a internet usa
+--offline (solo la caché de módulos), --goproxy con un proxy o directorio local,
+o --skip-deps para no ejecutar ningún comando que use la red.
+
+Un preset guarda las respuestas para reutilizarlas: --from acepta un archivo YAML
+o el nombre de un preset guardado con --save-preset. Los flags indicados
+prevalecen sobre los valores del preset.
+
+El README del proyecto se genera en el idioma de los mensajes (--lang).
+
+Si la carpeta ya existe, los archivos que ya están se conservan salvo que
+--on-conflict indique otra cosa (en una terminal se pregunta por cada uno).`,
+	"new.flag.module":          "Ruta del módulo Go (ej: github.com/user/project)",
+	"new.flag.framework":       "Framework HTTP: nethttp, chi, gin, fiber",
+	"new.flag.database":        "Base de datos: none, postgres, mysql, mongodb, oracle",
+	"new.flag.redis":           "Incluir Redis",
+	"new.flag.kafka":           "Incluir Kafka",
+	"new.flag.logger":          "Logger: zap, slog",
+	"new.flag.non_interactive": "Modo no interactivo (usa valores por defecto)",
+	"new.flag.pack":            "Pack de plantillas instalado (nombre o nombre@versión)",
+	"new.flag.var":             "Variable del pack (nombre=valor, repetible)",
+	"new.flag.skip_deps":       "Fija las dependencias en go.mod sin descargarlas",
+	"new.flag.offline":         "Resuelve las dependencias solo desde la caché de módulos",
+	"new.flag.goproxy":         "Proxy de módulos: URL o directorio local con formato de proxy",
+	"new.flag.vendor":          "Copia las dependencias a vendor/",
+	"new.flag.strict_deps":     "Falla si las dependencias no se pueden resolver",
+	"new.flag.from":            "Preset con las opciones del proyecto: archivo YAML o nombre de un preset guardado",
+	"new.flag.save_preset":     "Guarda las opciones elegidas como preset con este nombre",
+	"new.prompt.name":          "Nombre del proyecto",
+	"new.prompt.module":        "Módulo Go",
+	"new.prompt.framework":     "Selecciona framework HTTP",
+	"new.prompt.database":      "Selecciona base de datos",
+	"new.prompt.logger":        "Selecciona logger",
+	"new.prompt.redis":         "¿Agregar Redis?",
+	"new.prompt.kafka":         "¿Agregar Kafka?",
+	"new.prompt.confirm":       "¿Crear proyecto?",
+	"new.prompt.dir_exists":    "La carpeta '%s' ya existe. ¿Qué deseas hacer?",
+	"new.prompt.dir_use":       "Usar carpeta existente",
+	"new.prompt.dir_cancel":    "Cancelar",
+	"new.err.name_required":    "nombre del proyecto requerido en modo no interactivo",
+	"new.err.var_without_pack": "--var requiere --pack",
+	"new.err.cwd":              "error obteniendo directorio actual: %w",
+	"new.err.generate":         "error generando proyecto: %w",
+	"new.summary.title": `
+=== Resumen del proyecto ===`,
+	"new.summary.name":      "Nombre:     %s",
+	"new.summary.module":    "Módulo:     %s",
+	"new.summary.framework": "Framework:  %s",
+	"new.summary.database":  "Database:   %s",
+	"new.summary.logger":    "Logger:     %s",
+	"new.summary.redis":     "Redis:      %v",
+	"new.summary.kafka":     "Kafka:      %v",
+	"new.summary.pack":      "Pack:       %s %s",
+	"new.preset_saved":      "💾 Preset guardado en %s",
+	"new.warn.dir_exists":   "La carpeta '%s' ya existe. Usando carpeta existente.",
+	"new.generating": `
+🚀 Generando proyecto...`,
+	"new.done": `
+✅ Proyecto creado exitosamente!`,
+	"new.next_steps": `
+Próximos pasos:`,
+	"new.database_config": `
+Configuración base de %s generada en infrastructure/adapters/database/%s.go`,
+	"new.database_env": "Completa tus variables de conexión en .env (ejemplos en .env.example): %s",
+	"new.add_components": `
+Para agregar componentes:`,
+	"new.placeholder_name": "<nombre>",
+
+	// cleango add
+	"arg.name":    "[nombre]",
+	"arg.fields":  "[campo:tipo[:reglas]...]",
+	"arg.service": "[servicio]",
+	"add.short":   "Agrega componentes al proyecto",
+	"add.long": `Agrega nuevos componentes a tu proyecto siguiendo Clean Architecture.
+
+Componentes disponibles:
+  • usecase  - Crea un nuevo caso de uso en domain/usecases
+  • adapter  - Crea un nuevo adaptador en infrastructure/adapters/database
+  • model    - Crea un nuevo modelo en domain/models
+  • handler  - Crea un nuevo handler HTTP en infrastructure/entrypoints/http
+  • api      - Genera modelos, casos de uso y handlers desde un spec OpenAPI
+  • grpc     - Crea un servicio gRPC en infrastructure/entrypoints/grpc
+  • graphql  - Agrega un endpoint GraphQL en infrastructure/entrypoints/graphql
+
+Con --mocks se regeneran los mocks de las interfaces en mocks/ y la opción queda
+guardada en cleango.yaml para los siguientes comandos.
+
+Si un archivo a crear ya existe con otro contenido, --on-conflict decide qué hacer:
+en una terminal se pregunta mostrando el diff; sin ella el comando falla (add api
+conserva los archivos existentes).`,
+	"add.flag.mocks":    "Regenera los mocks de las interfaces y los mantiene sincronizados",
+	"add.err.mocks":     "error actualizando mocks: %w",
+	"add.file":          "   Archivo: %s",
+	"add.usecase.short": "Crea un nuevo caso de uso",
+	"add.usecase.long": `Crea un nuevo caso de uso en domain/usecases/.
+
+El caso de uso seguirá el patrón de Clean Architecture con:
+  • Interface del caso de uso (puerto)
+  • Implementación concreta
+  • Struct de entrada (Input) con método Validate()
+  • Struct de salida (Output)
+
+Con --model, los campos del Input y sus reglas de validación se toman del modelo.
+Con --with-tests se genera un test por tabla en el paquete usecases_test, con un
+caso válido y uno por cada regla de validación; las dependencias del caso de uso
+se inyectan como mocks del paquete mocks (cleango mocks).
+
+Ejemplo:
+  cleango add usecase GetUser
+  cleango add usecase CreateUser --model User --with-tests`,
+	"add.usecase.flag.with_tests": "Genera también un test por tabla del caso de uso",
+	"add.usecase.flag.model":      "Modelo del que se derivan los campos y validaciones del Input",
+	"add.usecase.generating":      "🔧 Generando caso de uso '%s'...",
+	"add.usecase.err":             "error generando caso de uso: %w",
+	"add.usecase.done":            "✅ Caso de uso '%s' creado exitosamente!",
+	"add.adapter.short":           "Crea un nuevo adaptador/repositorio",
+	"add.adapter.long": `Crea un nuevo adaptador en infrastructure/adapters/database/.
+
+El adaptador seguirá el patrón Repository con:
+  • Interface del repositorio
+  • Implementación concreta
+  • Métodos CRUD básicos
+  • Opción de generar un test base con --with-tests para personalizar tu conexión
+
+Ejemplo:
+  cleango add adapter UserRepository
+  cleango add adapter ProductRepository`,
+	"add.adapter.flag.with_tests": "Genera también un test base para personalizar el adapter",
+	"add.adapter.generating":      "🔧 Generando adaptador '%s'...",
+	"add.adapter.err":             "error generando adaptador: %w",
+	"add.adapter.done":            "✅ Adaptador '%s' creado exitosamente!",
+	"add.model.short":             "Crea un nuevo modelo de dominio",
+	"add.model.long": `Crea un nuevo modelo en domain/models/.
+
+El modelo será una estructura básica que representa una entidad de dominio.
+Entidades de dominio son objetos puros de negocio sin dependencias externas.
+
+Los campos se declaran como nombre:tipo[:reglas], con reglas separadas por comas.
+  Tipos:  string, int, int64, float, bool, time
+  Reglas: required, min=N, max=N, email, oneof=a|b
+
+El método Validate() del modelo se genera a partir de las reglas, y los handlers
+y casos de uso creados con --model reutilizan los mismos campos y reglas.
+Con --with-tests se genera un test por tabla de Validate() con un caso por regla.
+
+Ejemplo:
+  cleango add model User
+  cleango add model User name:string:required,max=100 email:string:required,email age:int:min=0
+  cleango add model Product name:string:required price:float:min=0 --with-tests`,
+	"add.model.flag.with_tests": "Genera también un test por tabla de Validate()",
+	"add.model.generating":      "🔧 Generando modelo '%s'...",
+	"add.model.err":             "error generando modelo: %w",
+	"add.model.done":            "✅ Modelo '%s' creado exitosamente!",
+	"add.handler.short":         "Crea un nuevo handler HTTP",
+	"add.handler.long": `Crea un nuevo handler HTTP en infrastructure/entrypoints/http/.
+
+El handler incluirá:
+  • Estructura del handler
+  • Métodos HTTP básicos (Get, Post, Put, Delete)
+  • DTO de entrada con validación derivada del modelo (--model, o el modelo
+    con el mismo nombre si existe)
+  • Decodificación JSON que rechaza campos desconocidos y cuerpos mayores a 1 MiB
+  • Errores de dominio respondidos como application/problem+json (RFC 7807)
+
+Con --with-tests se genera un test con httptest que envía las peticiones por el
+router del framework del proyecto, incluyendo un caso por regla de validación.
+
+Ejemplo:
+  cleango add handler User
+  cleango add handler Signup --model User --with-tests`,
+	"add.handler.flag.with_tests": "Genera también un test con httptest del handler",
+	"add.handler.flag.model":      "Modelo del que se derivan los campos y validaciones del request",
+	"add.handler.generating":      "🔧 Generando handler '%s'...",
+	"add.handler.err":             "error generando handler: %w",
+	"add.handler.done":            "✅ Handler '%s' creado exitosamente!",
+	"add.api.short":               "Genera modelos, casos de uso y handlers desde un spec OpenAPI",
+	"add.api.long": `Genera el código de un proyecto a partir de un spec OpenAPI 3 local (contract-first).
+
+Por cada schema de components.schemas se crea un modelo en domain/models/ y, por
+cada operación:
+  • domain/usecases/<operacion>_contract.go con la interfaz y los DTOs Input/Output
+    (se regenera siempre; no lo edites)
+  • domain/usecases/<operacion>.go con la implementación a completar
+    (solo se crea si no existe)
+  • infrastructure/entrypoints/http/<tag>_api.go con los handlers de cada tag,
+    registrados en routes.go
+
+Los modelos y las implementaciones existentes nunca se sobrescriben, así que puedes
+volver a ejecutar el comando cada vez que el spec cambie. El spec se lee del disco;
+las referencias remotas no se descargan.
+
+Ejemplo:
+  cleango add api --from openapi.yaml`,
+	"add.api.flag.from":  "Spec OpenAPI 3 (YAML o JSON) del que se genera el código",
+	"add.api.generating": "🔧 Generando API desde '%s'...",
+	"add.api.err":        "error generando API: %w",
+	"add.api.skipped":    "ya existe, no se sobrescribe",
+	"add.api.done":       "✅ API generada exitosamente!",
+	"add.grpc.short":     "Crea un servicio gRPC que expone casos de uso",
+	"add.grpc.long": `Crea un servicio gRPC cuyos métodos llaman a casos de uso existentes.
+
+Se generan:
+  • proto/<servicio>.proto con un rpc y sus mensajes por cada caso de uso,
+    derivados de los campos de Input y Output
+  • infrastructure/entrypoints/grpc/<servicio>_server.go con la implementación
+    que traduce los mensajes, llama al caso de uso y convierte los errores de
+    dominio a códigos gRPC
+
+La primera vez también se crea el servidor gRPC (con los servicios de health y
+reflection), se agrega GRPC_PORT (9090 por defecto) a la configuración y se
+inicia desde main junto al servidor HTTP, compartiendo el apagado ordenado.
+
+Sin --usecase se exponen los casos de uso cuyo nombre contiene el del servicio.
+Si protoc, protoc-gen-go y protoc-gen-go-grpc están instalados se genera el
+código Go de los mensajes; si no, se indica cómo instalarlos.
+
+Ejemplo:
+  cleango add grpc User
+  cleango add grpc Billing --usecase CreateInvoice --usecase GetInvoice`,
+	"add.grpc.flag.usecase": "Caso de uso a exponer como rpc (repetible)",
+	"add.grpc.generating":   "🔧 Generando servicio gRPC '%s'...",
+	"add.grpc.err":          "error generando servicio gRPC: %w",
+	"add.grpc.done":         "✅ Servicio gRPC '%s' creado exitosamente!",
+	"add.graphql.short":     "Agrega un endpoint GraphQL sobre los modelos y casos de uso",
+	"add.graphql.long": `Agrega un endpoint GraphQL en /graphql montado en el router del proyecto.
+
+Se generan en infrastructure/entrypoints/graphql/:
+  • schema.graphql con un type por modelo de domain/models y un campo por caso
+    de uso: Query para Get/List/Find/Search/Count, Mutation para el resto
+  • resolvers.go con los resolvers que llaman a los casos de uso
+  • server.go con el handler HTTP y errors.go con la traducción de errores de
+    dominio a extensiones GraphQL (code: NOT_FOUND, BAD_USER_INPUT, ...)
+
+El entrypoint queda registrado en cleango.yaml: a partir de entonces 'cleango add
+model', 'add usecase' y 'add api' regeneran schema.graphql y resolvers.go.
+No edites esos dos archivos; agrega tus resolvers propios en otros archivos
+del paquete.
+
+Ejemplo:
+  cleango add graphql`,
+	"add.graphql.generating": "🔧 Generando endpoint GraphQL...",
+	"add.graphql.err":        "error generando GraphQL: %w",
+	"add.graphql.done":       "✅ Endpoint GraphQL disponible en POST /graphql",
+	"mocks.done":             "✅ Mocks actualizados en mocks/",
+
+	// cleango doctor
+	"doctor.short": "Diagnostica el estado del proyecto",
+	"doctor.long": `Revisa el proyecto contra la configuración registrada en cleango.yaml y
+reporta los problemas con la forma de corregirlos:
+
+  • Go            versión instalada frente a go.mod y al mínimo (1.22)
+  • cleango.yaml  manifiesto presente y con valores conocidos
+  • Estructura    directorios de la arquitectura
+  • Marcadores    comentarios donde 'cleango add' registra rutas y servidores
+  • Dependencias  módulos del framework, base de datos y extras en go.mod
+  • .env          variables de .env.example presentes en .env
+  • GraphQL/Mocks archivos generados editados a mano o desactualizados
+  • Generados     archivos de 'cleango new' y 'cleango add' editados a mano
+
+Con --fix se aplican las correcciones seguras: crear directorios, guardar el
+manifiesto, 'go get' de las dependencias, agregar variables a .env.example y .env,
+y regenerar schema, resolvers y mocks. El comando termina con error si quedan
+problemas de severidad error, así que puede usarse en CI.
+
+Ejemplo:
+  cleango doctor
+  cleango doctor --fix`,
+	"doctor.err":        "❌ error diagnosticando el proyecto: %v",
+	"doctor.fix_failed": "   ❌ no se pudo corregir: %v",
+	"doctor.fixed":      "🔧 %s: %s (corregido)",
+	"doctor.healthy":    "✅ El proyecto está sano",
+	"doctor.run_fix":    "💡 Ejecuta 'cleango doctor --fix' para aplicar las correcciones automáticas",
+	"doctor.errors":     "%d errores encontrados",
+	"doctor.flag.fix":   "Aplica las correcciones seguras",
+
+	// cleango graph
+	"graph.short": "Genera el grafo de dependencias entre componentes",
+	"graph.long": `Analiza los paquetes del proyecto y genera un grafo de sus componentes: qué
+handlers llaman a qué casos de uso y qué puertos, adaptadores y modelos usan éstos.
+
+Cada componente se asigna a una capa según el directorio en el que vive:
+  • entrypoints  infrastructure/entrypoints/ (handlers, servicios gRPC, resolvers)
+  • adapters     infrastructure/adapters/ (repositorios, conexiones, logger)
+  • usecases     domain/usecases/ (interfaces <Nombre>UseCase)
+  • ports        otras interfaces de domain/
+  • models       domain/models/
+
+Un componente depende de otro cuando su declaración, sus métodos o su constructor
+New<Nombre> mencionan el tipo del otro (o el Input/Output de un caso de uso).
+
+Formatos:
+  • dot      Graphviz, con un cluster y un color por capa
+  • mermaid  flowchart para pegar en Markdown
+  • json     nodos y aristas para otras herramientas
+
+Ejemplo:
+  cleango graph | dot -Tsvg > arquitectura.svg
+  cleango graph --format mermaid
+  cleango graph --format json`,
+	"cli.err.analyse": "error analizando el proyecto: %w",
+	"cli.flag.format": "Formato de salida (%s)",
+
+	// cleango lint
+	"lint.short": "Verifica que las dependencias apunten hacia el dominio",
+	"lint.long": `Analiza los imports de todos los paquetes del proyecto y reporta las violaciones
+de la regla de dependencias de Clean Architecture:
+
+  • domain-no-outer-layers   domain/ importa infrastructure/, config/ o cmd/
+  • domain-no-frameworks     domain/ importa frameworks o drivers (net/http, gin,
+                             chi, fiber, pgx, mongo, grpc, ...)
+  • models-no-usecases       domain/models importa domain/usecases
+  • entrypoints-no-adapters  un entrypoint llama a un adaptador sin pasar por un
+                             caso de uso (el logger está permitido)
+  • adapters-no-entrypoints  un adaptador importa entrypoints o cmd
+
+Las reglas se configuran en la sección lint de cleango.yaml:
+
+  lint:
+    disable: [models-no-usecases]
+    severity:
+      entrypoints-no-adapters: warning
+    rules:
+      - id: usecases-no-http
+        description: Los casos de uso no conocen HTTP
+        from: [domain/usecases]
+        deny: [net/http]
+
+Los archivos _test.go se ignoran salvo con 'tests: true'. El comando termina con
+error si hay violaciones de severidad error, así que puede usarse en CI.
+
+Ejemplo:
+  cleango lint
+  cleango lint --format json
+  cleango lint --format sarif > cleango.sarif`,
+	"lint.err":        "❌ error analizando el proyecto: %v",
+	"lint.violations": "%d violaciones de dependencias",
+
+	// cleango mocks
+	"mocks.short": "Genera mocks de las interfaces del proyecto",
+	"mocks.long": `Genera un mock por cada interfaz exportada de domain/ e infrastructure/adapters/
+en el paquete mocks/ (un archivo por interfaz).
+
+Cada mock tiene, por método, un campo <Metodo>Func para definir su comportamiento
+y un slice <Metodo>Calls con los argumentos recibidos. Sin <Metodo>Func el método
+devuelve valores cero. Los mocks de interfaces que ya no existen se eliminan, así
+que basta con volver a ejecutar el comando cuando las interfaces cambian.
+
+Con 'cleango add ... --mocks' los mocks se regeneran tras crear el componente y
+quedan activados en cleango.yaml para los siguientes 'add'.
+
+Ejemplo:
+  cleango mocks
+  cleango add usecase GetUser --mocks`,
+	"mocks.generating": "🔧 Generando mocks...",
+	"mocks.err":        "error generando mocks: %w",
+
+	// cleango openapi
+	"openapi.short": "Genera la especificación OpenAPI del proyecto",
+	"openapi.long": `Comandos para trabajar con la especificación OpenAPI 3 del proyecto.
+
+Subcomandos disponibles:
+  • generate - Genera openapi.yaml a partir de los handlers, rutas y DTOs`,
+	"openapi.generate.short": "Genera openapi.yaml a partir del código del proyecto",
+	"openapi.generate.long": `Analiza el código Go de infrastructure/entrypoints/http y genera una especificación
+OpenAPI 3 con:
+  • Rutas declaradas en los métodos RegisterRoutes de los handlers
+  • Cuerpos de request a partir de los DTOs decodificados (con sus reglas de validación)
+  • Respuestas a partir de las llamadas a WriteJSON y WriteError (problem+json)
+
+La salida es determinista: ejecutar el comando dos veces sobre el mismo código produce
+el mismo archivo, así que puede versionarse y verificarse en CI con --check.
+
+Con --serve se genera además infrastructure/entrypoints/http/docs.go, que sirve la
+especificación en /openapi.yaml y Swagger UI en /docs desde el servidor generado.
+
+Ejemplo:
+  cleango openapi generate
+  cleango openapi generate --serve
+  cleango openapi generate --check`,
+	"openapi.up_to_date":       "✅ %s está actualizado",
+	"openapi.generating":       "🔧 Generando especificación OpenAPI...",
+	"openapi.err":              "error generando especificación OpenAPI: %w",
+	"openapi.done":             "✅ Especificación OpenAPI generada exitosamente!",
+	"openapi.swagger":          "   Swagger UI disponible en /docs al ejecutar el servidor",
+	"openapi.flag.file":        "Archivo de la especificación",
+	"openapi.flag.title":       "Título de la API (por defecto el nombre del proyecto)",
+	"openapi.flag.api_version": "Versión de la API",
+	"openapi.flag.serve":       "Sirve la especificación y Swagger UI desde el servidor generado",
+	"openapi.flag.check":       "Falla si los archivos generados están desactualizados (para CI)",
+
+	// cleango pack
+	"pack.short": "Gestiona los packs de plantillas",
+	"pack.long": `Un pack de plantillas distribuye el estilo de un equipo u organización: opciones
+por defecto, variables que se preguntan al crear el proyecto, plantillas que
+reemplazan a las embebidas y archivos adicionales. Se describe en pack.yaml:
+
+  name: acme
+  version: 1.2.0
+  description: Servicios de ACME
+  defaults:
+    modulePrefix: github.com/acme
+    framework: chi
+    database: postgres
+  variables:
+    - name: team
+      prompt: Equipo responsable
+      required: true
+      pattern: ^[a-z-]+$
+  templates:
+    - name: logger                # templates/logger.tmpl
+    - name: mainChi
+      file: main/chi.tmpl
+      when: {framework: [chi]}
+  files:
+    - path: CODEOWNERS
+      template: files/CODEOWNERS.tmpl
+    - path: deploy/postgres.yaml
+      template: files/postgres.yaml.tmpl
+      when: {database: [postgres]}
+
+Las plantillas usan las variables con {{packVar "team"}}. Los packs se instalan
+en $CLEANGO_PACKS o en cleango/packs del directorio de configuración del usuario.
+
+Ejemplo:
+  cleango pack install ./acme-pack
+  cleango pack install acme-pack-1.2.0.tar.gz
+  cleango new pagos --pack acme --var team=cobros`,
+	"arg.path":                "[ruta]",
+	"pack.install.short":      "Valida e instala un pack desde un directorio o un .tar.gz",
+	"pack.install.updated":    "✅ Pack %s actualizado de %s a %s",
+	"pack.install.done":       "✅ Pack %s %s instalado",
+	"pack.install.usage":      "Úsalo con: cleango new <nombre> --pack %s",
+	"pack.install.next":       "cleango new <nombre> --pack %s",
+	"pack.list.short":         "Lista los packs instalados",
+	"pack.list.empty":         "No hay packs instalados. Instala uno con 'cleango pack install <ruta>'",
+	"pack.remove.short":       "Desinstala un pack",
+	"pack.remove.done":        "✅ Pack %s desinstalado",
+	"pack.install.flag.force": "Reinstala la misma versión o instala una anterior",
+
+	// cleango preset
+	"preset.short": "Gestiona los presets de 'cleango new'",
+	"preset.long": `Un preset guarda las opciones de 'cleango new' para crear proyectos con las
+mismas elecciones:
+
+  modulePrefix: github.com/acme
+  framework: chi
+  database: postgres
+  logger: slog
+  redis: true
+  kafka: false
+  pack: acme
+  vars:
+    team: pagos
+
+Guarda las respuestas de una sesión con 'cleango new --save-preset <nombre>' y
+reutilízalas con 'cleango new <proyecto> --from <nombre>'. --from también acepta
+la ruta de un archivo YAML. Los presets se guardan en $CLEANGO_PRESETS o en
+cleango/presets del directorio de configuración del usuario.`,
+	"preset.list.short":   "Lista los presets guardados",
+	"preset.list.empty":   "No hay presets guardados. Guarda uno con 'cleango new --save-preset <nombre>'",
+	"preset.remove.short": "Elimina un preset guardado",
+	"preset.remove.done":  "✅ Preset %s eliminado",
+
+	// cleango remove
+	"remove.short": "Elimina componentes generados con 'cleango add'",
+	"remove.long": `Deshace 'cleango add': elimina los archivos que creó el generador (incluido su
+test), quita el registro de rutas de los handlers en routes.go y actualiza el
+schema GraphQL, la especificación OpenAPI y los mocks.
+
+Por seguridad el comando se niega a eliminar un componente cuando:
+  • sus archivos fueron modificados desde que se generaron (o se generaron con
+    una versión de cleango que no guardaba la copia original), o
+  • otros archivos del proyecto todavía lo usan; se listan archivo y línea.
+
+Con --force se elimina de todos modos.
+
+Componentes:
+  • usecase  - domain/usecases/<nombre>.go
+  • adapter  - infrastructure/adapters/database/<nombre>.go
+  • model    - domain/models/<nombre>.go
+  • handler  - infrastructure/entrypoints/http/<nombre>_handler.go y su ruta
+
+Ejemplo:
+  cleango remove usecase GetUser
+  cleango remove handler User --force`,
+	"remove.removing":      "🗑️  Eliminando %s '%s'...",
+	"remove.done":          "✅ %s '%s' eliminado",
+	"remove.usecase.short": "Elimina un caso de uso",
+	"remove.adapter.short": "Elimina un adaptador",
+	"remove.model.short":   "Elimina un modelo de dominio",
+	"remove.handler.short": "Elimina un handler HTTP y su ruta",
+	"remove.flag.force":    "Elimina aunque los archivos estén modificados o referenciados",
+
+	// cleango rename
+	"rename.short":       "Renombra un componente en todas las capas",
+	"rename.model.args":  "[actual] [nuevo]",
+	"rename.model.short": "Renombra un modelo y todo lo derivado de su nombre",
+	"rename.model.long": `Renombra un modelo en todo el proyecto: el tipo, y los identificadores, archivos
+y rutas derivados de su nombre en modelos, casos de uso, adaptadores, handlers,
+tests y migraciones.
+
+Para Customer → Client se renombran, por ejemplo:
+  • identificadores  Customer, CustomerInput, NewCustomerHandler, customerRepository
+  • comentarios      // Customer represents ...
+  • rutas            "/customers/:id" → "/clients/:id"
+  • archivos         customer.go, create_customer.go, customer_handler_test.go
+  • migraciones      tablas customers / customer
+
+Los identificadores se reescriben desde el árbol sintáctico (go/ast): los de
+paquetes externos al módulo no se tocan y el resultado se formatea con gofmt.
+El comando se niega a renombrar si un nombre nuevo choca con uno ya declarado.
+Antes de aplicar los cambios muestra el diff y pide confirmación; después se
+regeneran el schema GraphQL y los mocks.
+
+Ejemplo:
+  cleango rename model Customer Client --dry-run
+  cleango rename model Customer Client --yes`,
+	"rename.nothing":      "✅ No hay nada que renombrar",
+	"rename.changes":      "📝 %d archivos cambian",
+	"cli.dry_run":         "ℹ️  Simulación: no se escribió ningún archivo",
+	"rename.confirm":      "¿Renombrar %s a %s?",
+	"rename.done":         "✅ %s renombrado a %s",
+	"rename.flag.dry_run": "Muestra el diff sin aplicar los cambios",
+	"rename.flag.yes":     "Aplica los cambios sin pedir confirmación",
+
+	// cleango templates
+	"templates.short": "Gestiona las plantillas personalizadas",
+	"templates.long": `Cada archivo que genera cleango sale de una plantilla de text/template. Una
+plantilla se busca, en orden, en:
+
+  1. .cleango/templates/<nombre>.tmpl    del proyecto (versiónala con el equipo)
+  2. el pack de plantillas del proyecto, si se creó con 'cleango new --pack'
+  3. <usuario>/cleango/templates/<nombre>.tmpl
+     ($CLEANGO_TEMPLATES o el directorio de configuración del usuario,
+     ~/.config/cleango/templates en Linux)
+  4. la plantilla embebida en cleango
+
+Las plantillas personalizadas reciben los mismos datos que las embebidas
+(ProjectConfig en las del proyecto; Name, LowerName, ... en las de componentes).
+Empieza copiando la embebida con 'cleango templates eject'.
+
+Ejemplo:
+  cleango templates list
+  cleango templates eject mainChi usecase
+  cleango templates eject logger --user`,
+	"templates.list.short":     "Lista las plantillas y de dónde se cargan",
+	"arg.names":                "[nombre...]",
+	"templates.source.project": "proyecto",
+	"templates.source.pack":    "pack",
+	"templates.source.user":    "usuario",
+	"templates.source.builtin": "embebida",
+	"templates.eject.short":    "Copia plantillas embebidas para editarlas",
+	"templates.eject.long": `Copia las plantillas embebidas a .cleango/templates/ del proyecto, o con --user
+al directorio de plantillas del usuario, para personalizarlas. Las copias
+existentes no se sobrescriben salvo con --force.
+
+Ejemplo:
+  cleango templates eject handlerGin
+  cleango templates eject --all --user`,
+	"templates.eject.no_names":   "indica las plantillas a copiar o usa --all ('cleango templates list' las muestra)",
+	"templates.eject.done":       "✅ Plantillas copiadas. Los próximos comandos usarán tus versiones",
+	"templates.eject.flag.user":  "Copia al directorio de plantillas del usuario en lugar del proyecto",
+	"templates.eject.flag.force": "Sobrescribe las plantillas ya copiadas",
+	"templates.eject.flag.all":   "Copia todas las plantillas",
+
+	// cleango upgrade
+	"upgrade.short": "Actualiza los archivos del proyecto a las plantillas actuales",
+	"upgrade.long": `Vuelve a generar los archivos creados por 'cleango new' (main.go, server.go,
+config, logger, errores, rutas, base de datos, .env.example, Makefile, README...)
+con las plantillas de esta versión de cleango y la configuración de cleango.yaml.
+
+Cada archivo se combina a tres bandas: la copia original que cleango guardó en
+.cleango/pristine/ al generarlo es el ancestro común de tu versión y de la nueva.
+
+  • sin cambios locales   se reemplaza por la versión nueva
+  • con cambios locales   se combinan ambos; si tocan las mismas líneas quedan
+                          marcadores de conflicto (<<<<<<< local / >>>>>>> cleango)
+  • eliminado             no se vuelve a crear
+
+Los archivos de proyectos creados antes de que cleango guardara las copias
+originales solo se actualizan si no fueron modificados. Versiona .cleango/ junto
+con el proyecto para que todo el equipo pueda actualizar.
+
+El README se regenera en el idioma registrado en cleango.yaml (lang).
+
+Ejemplo:
+  cleango upgrade --dry-run
+  cleango upgrade`,
+	"upgrade.err":          "❌ error actualizando el proyecto: %v",
+	"upgrade.merged":       "   ⇄ %s (combinado con tus cambios)",
+	"upgrade.conflict":     "   ✗ %s (conflictos)",
+	"upgrade.up_to_date":   "✅ El proyecto ya usa las plantillas actuales",
+	"upgrade.resolve":      "⚠️  Resuelve los marcadores de conflicto y compila el proyecto",
+	"upgrade.conflicts":    "%d archivos con conflictos",
+	"upgrade.done":         "✅ Proyecto actualizado. Revisa los cambios con 'git diff'",
+	"upgrade.flag.dry_run": "Muestra qué archivos cambiarían sin escribirlos",
+
+	// cleango init
+	"init.short": "Agrega Clean Architecture a un repositorio Go existente",
+	"init.long": `Agrega la estructura de Clean Architecture al módulo Go del directorio actual.
+
+El módulo se lee de go.mod; el framework, la base de datos, Redis, Kafka y el
+logger se infieren de los paquetes que importa el código (o de los requisitos
+de go.mod si aún no hay código). Los flags prevalecen sobre lo detectado.
+
+Solo se crean los directorios y archivos que faltan, se agregan a go.mod las
+dependencias que no estén ya requeridas y se escribe cleango.yaml si no existe.
+Los archivos existentes con otro contenido nunca se sobrescriben sin indicarlo:
+
+  • skip       conserva el archivo existente
+  • overwrite  lo reemplaza por la versión de cleango
+  • backup     lo copia a <archivo>.bak y lo reemplaza
+  • new        escribe la versión de cleango en <archivo>.new para revisarla
+  • prompt     muestra el diff de cada archivo y pregunta qué hacer
+  • fail       se detiene con un error
+
+Por defecto se pregunta en una terminal y se conserva el archivo sin ella.
+
+Ejemplos:
+  cleango init
+  cleango init --on-conflict new
+  cleango init --framework gin --database postgres --non-interactive`,
+	"init.flag.framework":       "Framework HTTP: nethttp, chi, gin, fiber (por defecto, el detectado)",
+	"init.flag.database":        "Base de datos: none, postgres, mysql, mongodb, oracle (por defecto, la detectada)",
+	"init.flag.logger":          "Logger: zap, slog (por defecto, el detectado)",
+	"init.flag.non_interactive": "Modo no interactivo: sin confirmación ni preguntas por archivo",
+	"init.summary.title": `
+=== Proyecto detectado ===`,
+	"init.summary.conflicts": "Conflictos: %s",
+	"init.prompt.confirm":    "¿Agregar la estructura al proyecto?",
+	"init.generating": `
+🚀 Generando la estructura...`,
+	"init.err": "error inicializando el proyecto: %w",
+	"init.done": `
+✅ Estructura de Clean Architecture agregada!`,
+
+	// --on-conflict
+	"conflict.flag":             "Qué hacer con los archivos que ya existen: skip, overwrite, backup, new, prompt o fail (por defecto prompt en una terminal y %s sin ella)",
+	"conflict.prompt":           "%s ya existe y difiere de la plantilla. ¿Qué deseas hacer?",
+	"conflict.prompt.skip":      "Conservar el archivo actual",
+	"conflict.prompt.overwrite": "Sobrescribir con la versión de cleango",
+	"conflict.prompt.backup":    "Sobrescribir y guardar el actual en %s",
+	"conflict.prompt.new":       "Escribir la versión de cleango en %s",
+	"conflict.summary": `
+Archivos que ya existían:`,
+	"conflict.summary.skip":      "   = %s (conservado)",
+	"conflict.summary.overwrite": "   ~ %s (sobrescrito)",
+	"conflict.summary.backup":    "   ~ %s (sobrescrito, copia en %s)",
+	"conflict.summary.new":       "   + %s (versión de cleango)",
+	"conflict.summary.skipped":   "%d archivos existentes se conservaron; elige otra estrategia con --on-conflict",
+	"conflict.summary.review":    "Revisa los archivos .new y combínalos con los tuyos",
+
+	// rollback
+	"rollback.summary": `
+Se deshicieron los cambios del comando:`,
+	"rollback.removed":   "   - %s (eliminado)",
+	"rollback.restored":  "   ~ %s (restaurado)",
+	"tx.interrupted":     "Interrumpido: se deshicieron los cambios (%d eliminados, %d restaurados)",
+	"tx.rollback_failed": "%w; además, no se pudieron deshacer todos los cambios: %v",
+
+	// Components named in messages
+	"kind.field":     "campo",
+	"kind.model":     "modelo",
+	"kind.operation": "operación",
+	"kind.service":   "servicio",
+	"kind.usecase":   "caso de uso",
+	"kind.adapter":   "adaptador",
+	"kind.handler":   "handler",
+
+	// Validation of names and options
+	"validate.keyword":           "%s es una palabra reservada de Go",
+	"validate.predeclared":       "%s es un identificador predeclarado de Go",
+	"validate.suffix.test":       "su archivo %s.go solo lo compilaría go test",
+	"validate.suffix.platform":   "su archivo %s.go solo se compilaría para %s",
+	"validate.name.empty":        "el nombre del %s no puede estar vacío",
+	"validate.name.char":         "contiene el carácter %q (usa letras, dígitos, '_' o '-')",
+	"validate.name.letter":       "debe empezar por una letra",
+	"validate.name.upper":        "debe empezar por una letra con mayúscula para poder exportarse",
+	"validate.name.suggest":      "nombre de %s inválido %q: %s; prueba con %q",
+	"validate.name.invalid":      "nombre de %s inválido %q: %s",
+	"validate.project.empty":     "el nombre del proyecto no puede estar vacío",
+	"validate.project.char":      "nombre de proyecto inválido %q: contiene el carácter %q (usa letras, dígitos, '-', '_' o '.')",
+	"validate.project.start":     "nombre de proyecto inválido %q: no puede empezar por '.' ni '-'",
+	"validate.module.empty":      "la ruta del módulo no puede estar vacía",
+	"validate.module.char":       "ruta de módulo inválida %q: contiene el carácter %q%s",
+	"validate.module.empty_elem": "ruta de módulo inválida %q: tiene un elemento vacío (revisa las barras)",
+	"validate.module.dots":       "ruta de módulo inválida %q: no puede contener %q",
+	"validate.module.dot_edge":   "ruta de módulo inválida %q: el elemento %q no puede empezar ni terminar en '.'",
+	"validate.try":               "; prueba con %q",
+	"validate.option.suggest":    "valor %q no válido para %s, ¿quisiste decir %q? (usa %s)",
+	"validate.option.invalid":    "valor %q no válido para %s (usa %s)",
+	"option.database":            "base de datos",
+
+	// Fields of models
+	"fields.invalid":         "campo inválido %q, usa nombre:tipo[:reglas]",
+	"fields.type":            "tipo %q no soportado en el campo %q (tipos: %s)",
+	"fields.duplicated":      "el campo %q está duplicado",
+	"fields.rule.type":       "la regla %s no aplica al campo %q de tipo %s",
+	"fields.rule.number":     "la regla %s del campo %q requiere un número válido para %s",
+	"fields.rule.string":     "la regla %s solo aplica a campos string (%q)",
+	"fields.rule.oneof":      "la regla oneof del campo %q requiere valores, ej: oneof=a|b",
+	"fields.rule.unknown":    "regla %q desconocida en el campo %q (reglas: required, min, max, email, oneof)",
+	"fields.model.missing":   "el modelo %s no existe: %w",
+	"err.read":               "error leyendo %s: %w",
+	"fields.struct.missing":  "no se encontró el struct %s en %s",
+	"err.read.usecases":      "error leyendo domain/usecases: %w",
+	"fields.usecase.missing": "el caso de uso %s no existe en domain/usecases (se esperan %sUseCase, %sInput y %sOutput): %w",
+
+	// Components, API, gRPC and rename
+	"err.mkdir.usecases":  "error creando directorio usecases: %w",
+	"err.mkdir.database":  "error creando directorio database: %w",
+	"err.mkdir.models":    "error creando directorio models: %w",
+	"err.mkdir.http":      "error creando directorio http: %w",
+	"err.no_gomod":        "no se encontró go.mod. Asegúrate de estar en la raíz del proyecto",
+	"component.route":     "handler creado, pero no se pudo registrar su ruta (agrega %s a RegisterRoutes): %w",
+	"err.template":        "error en la plantilla %s: %w",
+	"err.format":          "error formateando %s: %w",
+	"err.mkdir":           "error creando directorio %s: %w",
+	"err.create":          "error creando %s: %w",
+	"api.schema.skipped":  "%v; se omite el esquema",
+	"api.operation.clash": "las operaciones %s y %s %s generan el mismo nombre %s; define operationId distintos",
+	"api.body.param":      "%s: el campo %q del body coincide con un parámetro y se omite",
+	"api.property.scalar": "%s: la propiedad %q de tipo %q no es escalar y se omite; agrégala manualmente",
+	"api.impl.dtos":       "%s declara %sInput; mueve los DTOs y la interfaz a %s para que se regeneren desde el spec",
+	"api.route":           "agrega %s a RegisterRoutes: %v",
+	"grpc.no_usecases":    "no hay casos de uso cuyo nombre contenga %q; créalos con 'cleango add usecase' o indícalos con --usecase",
+	"grpc.server":         "no se pudo iniciar el servidor gRPC desde %s (%v); regenera cmd/api con una versión reciente de cleango o agrega grpcentry.NewServer(\":\"+cfg.GRPCPort) a tus servidores",
+	"deps.installing":     "📦 Instalando dependencias...",
+	"deps.install_failed": "No se pudo instalar %s: %v",
+	"warning":             "⚠️  Advertencia: %s",
+	"grpc.protoc.missing": `No se generó el código Go de %s porque faltan herramientas:
+%s
+Instálalas y ejecuta 'go generate ./%s/...'; el proyecto no compila hasta entonces.`,
+	"grpc.protoc.failed": `protoc falló al generar %s: %v
+%s`,
+	"grpc.protoc.done":     "Código Go generado con protoc desde %s",
+	"rename.diff.move":     "renombrar %s → %s",
+	"err.update.graphql":   "error actualizando GraphQL: %w",
+	"rename.from.empty":    "el nombre actual no puede estar vacío",
+	"rename.same":          "el nombre nuevo es igual al actual",
+	"rename.model.missing": "no existe el modelo %s (%s)",
+	"rename.model.exists":  "ya existe el modelo %s (%s)",
+	"rename.declared":      "%s: %s → %s ya está declarado",
+	"rename.file.exists":   "%s → %s ya existe",
+	"rename.conflicts": `el renombrado chocaría con nombres existentes:
+   %s`,
+
+	// Template packs, presets and dependencies
+	"pack.not_installed":     "el pack %s no está instalado (instálalo con 'cleango pack install <ruta>')",
+	"pack.version.other":     "está instalada la versión %s del pack %s, no la %s",
+	"pack.version.project":   "está instalada la versión %s del pack %s, el proyecto se generó con la %s (ejecuta 'cleango upgrade' para aplicarla)",
+	"pack.extract":           "error extrayendo %s: %w",
+	"pack.no_manifest":       "%s no contiene %s",
+	"pack.version.installed": "la versión %s del pack %s ya está instalada (usa --force para reinstalarla)",
+	"pack.version.newer":     "ya está instalada la versión %s del pack %s, más reciente que la %s (usa --force para instalarla)",
+	"pack.copy":              "error copiando el pack: %w",
+	"pack.invalid.name":      "name %q inválido: usa minúsculas, dígitos y guiones",
+	"pack.invalid.version":   "version %q inválida: usa versionado semántico (1.2.0)",
+	"pack.invalid.at":        "%s: %v",
+	"pack.invalid.var_name":  "variable %q: el nombre debe ser un identificador",
+	"pack.invalid.var_twice": "variable %q declarada dos veces",
+	"pack.invalid.pattern":   "variable %s: pattern inválido: %v",
+	"pack.invalid.default":   "variable %s: el valor por defecto %q no cumple %s",
+	"pack.invalid.template":  "templates: plantilla %q desconocida ('cleango templates list' muestra las disponibles)",
+	"pack.invalid.path":      "files: ruta %q no permitida: debe ser relativa al proyecto y fuera de .cleango/",
+	"pack.invalid": `pack %s inválido:
+  - %s`,
+	"pack.template.missing":      "falta la plantilla",
+	"pack.template.outside":      "la plantilla %s debe estar dentro del pack",
+	"pack.template.read":         "no se pudo leer %s",
+	"pack.var.undeclared":        "el pack %s no declara la variable %q",
+	"pack.var.required":          "la variable %s es obligatoria",
+	"pack.var.pattern":           "la variable %s debe cumplir %s",
+	"pack.project":               "el proyecto usa el pack %s %s: %w",
+	"pack.var.undefined":         "variable de pack %q no definida",
+	"pack.template.error":        "error en la plantilla %s del pack %s: %w",
+	"err.generate":               "error generando %s: %w",
+	"pack.tar.outside":           "ruta %q fuera del pack",
+	"pack.tar.irregular":         "%s no es un archivo regular",
+	"preset.invalid.name":        "nombre de preset %q inválido: usa minúsculas, dígitos y guiones",
+	"preset.source.missing":      "no existe el archivo %s ni un preset con ese nombre",
+	"preset.source.missing_list": "no existe el archivo %s ni un preset con ese nombre ('cleango preset list' muestra los guardados)",
+	"preset.read":                "error leyendo el preset %s: %w",
+	"preset.invalid": `preset %s inválido:
+  - %s`,
+	"preset.vars":            "vars requiere pack",
+	"preset.exists":          "el preset %s ya existe",
+	"preset.header":          "# Preset de cleango. Úsalo con: cleango new <nombre> --from %s",
+	"preset.missing":         "no existe el preset %s",
+	"deps.skip.combined":     "--skip-deps no se puede combinar con --offline, --goproxy ni --vendor",
+	"deps.offline.combined":  "--offline no se puede combinar con --goproxy",
+	"deps.skipped":           "📦 Dependencias fijadas en go.mod sin descargarlas (--skip-deps)",
+	"deps.resolving.offline": "📦 Resolviendo dependencias desde la caché de módulos (--offline)...",
+	"deps.resolving.proxy":   "📦 Resolviendo dependencias desde %s...",
+	"deps.resolving":         "📦 Resolviendo dependencias...",
+	"deps.failed":            "error ejecutando go %s: %w",
+	"deps.warning":           "⚠️  Advertencia: Error ejecutando go %s: %v",
+	"deps.pinned":            "   Las versiones quedan fijadas en go.mod; ejecuta 'go mod tidy' cuando tengas acceso a los módulos",
+	"deps.warning.record":    "Error ejecutando go %s: %v; las versiones quedan fijadas en go.mod",
+
+	// Checks of doctor
+	"doctor.not_fixable":          "%s no se puede corregir automáticamente",
+	"doctor.pack.install":         "instala la versión %s del pack con 'cleango pack install <ruta>'",
+	"doctor.pack.version":         "instalada la versión %s, el proyecto se generó con la %s",
+	"doctor.pack.upgrade":         "ejecuta 'cleango upgrade' para aplicar la versión instalada",
+	"doctor.pack.ok":              "versión %s",
+	"doctor.go.missing":           "no se encontró el comando go",
+	"doctor.go.install":           "instala Go %s o superior desde https://go.dev/dl/",
+	"doctor.go.old":               "Go %s instalado, el proyecto requiere Go %s",
+	"doctor.go.update":            "actualiza Go desde https://go.dev/dl/ o usa GOTOOLCHAIN=go%s",
+	"doctor.go.mod":               "go.mod declara go %s, las rutas generadas requieren %s",
+	"doctor.run":                  "ejecuta: %s",
+	"doctor.manifest.missing":     "el proyecto no tiene manifiesto; se infiere de go.mod y los directorios",
+	"doctor.manifest.save":        "guarda el manifiesto inferido para que los comandos no dependan de la detección",
+	"doctor.manifest.framework":   "framework %q desconocido",
+	"doctor.manifest.use":         "usa %s en %s",
+	"doctor.manifest.database":    "base de datos %q desconocida",
+	"doctor.manifest.entrypoints": "entrypoints registrados sin directorio: %s",
+	"doctor.manifest.regenerate":  "vuelve a generarlos con 'cleango add' o quítalos de %s",
+	"doctor.structure":            "Estructura",
+	"doctor.structure.ok":         "todos los directorios de la arquitectura existen",
+	"doctor.structure.missing":    "faltan directorios: %s",
+	"doctor.structure.fix":        "crea los directorios faltantes",
+	"doctor.markers":              "Marcadores",
+	"doctor.markers.at":           "%s en %s",
+	"doctor.markers.ok":           "los marcadores de inserción están presentes",
+	"doctor.markers.missing":      "faltan marcadores: %s",
+	"doctor.markers.fix":          "restaura el comentario donde 'cleango add' debe registrar código; sin él hay que registrar rutas y servidores a mano",
+	"doctor.dependencies":         "Dependencias",
+	"doctor.dependencies.ok":      "go.mod incluye las %d dependencias esperadas",
+	"doctor.dependencies.missing": "go.mod no incluye: %s",
+	"doctor.env_example.ok":       "declara las variables de la configuración seleccionada",
+	"doctor.env_example.missing":  "faltan variables: %s",
+	"doctor.env_example.fix":      "agrega las variables con sus valores de ejemplo",
+	"doctor.env.missing":          "no existe; la aplicación usará los valores por defecto",
+	"doctor.env.create":           "crea .env a partir de .env.example y ajusta sus valores",
+	"doctor.env.vars":             "faltan variables de .env.example: %s",
+	"doctor.env.fix":              "agrega las variables a .env (se copian los valores de ejemplo)",
+	"doctor.env.ok":               "declara todas las variables de .env.example",
+	"doctor.graphql.outdated":     "desactualizados o editados a mano: %s",
+	"doctor.graphql.fix":          "regenéralos; mueve tus resolvers propios a otros archivos del paquete",
+	"doctor.graphql.ok":           "schema.graphql y resolvers.go están al día",
+	"doctor.mocks.outdated":       "desactualizados: %s",
+	"doctor.mocks.ok":             "los mocks corresponden a las interfaces actuales",
+	"doctor.modified":             "Archivos generados",
+	"doctor.modified.ok":          "los %d archivos generados por cleango no tienen cambios a mano",
+	"doctor.modified.files":       "editados desde que cleango los generó: %s",
+	"doctor.modified.fix":         "'cleango upgrade' aplica plantillas nuevas conservando tus cambios; para volver a partir de la plantilla ejecuta 'cleango remove <tipo> <nombre> --force' y 'cleango add'",
+
+	// Generators
+	"err.update.openapi":     "error actualizando la especificación OpenAPI: %w",
+	"mocks.skipped":          "%s.%s no se simula: %s",
+	"mocks.generic":          "es genérica",
+	"mocks.embeds":           "embebe otras interfaces",
+	"mocks.unexported":       "tiene métodos no exportados",
+	"mocks.type":             "tipo no soportado: %w",
+	"openapi.marshal":        "error serializando la especificación: %w",
+	"openapi.outdated":       "%s está desactualizado, ejecuta 'cleango openapi generate'",
+	"err.write":              "error escribiendo %s: %w",
+	"openapi.docs.route":     "docs generados, pero no se pudieron registrar (agrega RegisterDocs(r) a RegisterRoutes): %w",
+	"templates.unknown":      "plantilla %q desconocida",
+	"templates.read":         "error leyendo la plantilla %s: %w",
+	"templates.unknown.list": "plantilla %q desconocida (disponibles: %s)",
+	"templates.no_gomod":     "no se encontró go.mod. Ejecuta el comando en la raíz del proyecto o usa --user",
+	"templates.exists":       "%s ya existe (usa --force para sobrescribirla)",
+	"remove.kind":            "componente %q no soportado (usa %s)",
+	"remove.missing":         "no existe %s",
+	"remove.modified":        "modificados desde que se generaron: %s",
+	"remove.referenced": `aún referenciado en:
+   %s`,
+	"remove.refused": `no se eliminó el %s %s, %s
+usa --force para eliminarlo de todos modos`,
+	"remove.uses":         "%s:%d usa %s",
+	"upgrade.deleted":     "%s fue eliminado; no se vuelve a crear",
+	"upgrade.no_pristine": "%s no tiene versión original registrada; compáralo a mano con la plantilla actual",
+	"upgrade.record":      "error registrando los archivos generados: %w",
+	"utils.marker":        "no se encontró el marcador %q en %s",
+	"utils.match":         "no se encontró %q en %s",
+	"config.no_module":    "go.mod no declara un módulo",
+	"manifest.header":     "# Generado por cleango. Los comandos 'cleango add' lo leen y actualizan.",
+	"project.chdir":       "error entrando al directorio del proyecto: %w",
+	"project.require":     "error agregando las dependencias a go.mod: %w",
+	"project.errors":      "error generando el modelo de errores: %w",
+	"project.database":    "error generando los archivos de base de datos: %w",
+	"project.pack":        "error generando los archivos del pack: %w",
+
+	// Lint and graph
+	"lint.rule.outer":       "El dominio no puede importar infraestructura, config ni cmd",
+	"lint.rule.frameworks":  "El dominio no puede depender de frameworks HTTP, drivers ni librerías de infraestructura",
+	"lint.rule.models":      "Los modelos no pueden importar casos de uso",
+	"lint.rule.entrypoints": "Los entrypoints deben llamar a casos de uso, no a adaptadores",
+	"lint.rule.adapters":    "Los adaptadores no pueden importar entrypoints ni cmd",
+	"lint.config.no_id":     "regla de lint sin id",
+	"lint.config.from_deny": "la regla de lint %q necesita from y deny",
+	"lint.config.severity":  "severidad %q inválida para la regla %q (usa error o warning)",
+	"lint.violation":        "%s importa %s: %s",
+	"format.unsupported":    "formato %q no soportado (usa %s)",
+	"lint.clean":            "✅ Sin violaciones de dependencias (%d reglas)",
+	"lint.summary":          "%d violaciones (%d errores)",
+
+	// OpenAPI
+	"openapi.no_handlers": "no se encontraron handlers en %s",
+	"openapi.no_routes":   "no se encontraron rutas: los handlers deben declarar un método RegisterRoutes",
+	"openapi.parse":       "error analizando %s: %w",
+	"openapi.not_v3":      "%s no es un documento OpenAPI 3 (openapi: %q)",
+
+	// init and conflicts
+	"init.scan":          "error analizando los imports: %w",
+	"conflict.no_prompt": "--on-conflict prompt requiere una terminal interactiva",
+	"conflict.exists":    "el archivo %s ya existe (elige qué hacer con --on-conflict)",
+
+	// README of the generated projects
+	"readme.title":                      "Proyecto Go con Clean Architecture",
+	"readme.structure":                  "Estructura del Proyecto",
+	"readme.structure.intro":            "Este proyecto sigue los principios de Clean Architecture:",
+	"readme.tree.cmd":                   "Punto de entrada de la aplicación",
+	"readme.tree.server":                "Arranque y apagado ordenado de los servidores",
+	"readme.tree.config":                "Configuraciones",
+	"readme.tree.domain":                "Capa de Dominio (Reglas de Negocio)",
+	"readme.tree.errors":                "Errores de dominio tipados",
+	"readme.tree.models":                "Entidades de dominio",
+	"readme.tree.usecases":              "Casos de uso (puertos)",
+	"readme.tree.infrastructure":        "Capa de Infraestructura",
+	"readme.tree.adapters":              "Adaptadores (implementaciones)",
+	"readme.tree.database":              "Repositorios de base de datos",
+	"readme.tree.logger":                "Sistema de logging",
+	"readme.tree.entrypoints":           "Puntos de entrada",
+	"readme.tree.http":                  "Handlers HTTP",
+	"readme.tree.migrations":            "Migraciones de base de datos",
+	"readme.tree.env":                   "Variables de entorno ejemplo",
+	"readme.tree.manifest":              "Manifiesto leído por los comandos de cleango",
+	"readme.tree.makefile":              "Comandos útiles",
+	"readme.layers":                     "Capas de Clean Architecture",
+	"readme.layers.domain":              "Domain (Dominio)",
+	"readme.layers.errors":              "Errores tipados (NotFound, Conflict, Validation, Unauthorized)",
+	"readme.layers.models":              "Entidades de dominio, objetos de negocio puros sin dependencias externas",
+	"readme.layers.usecases":            "Lógica de negocio, casos de uso de la aplicación (interfaces/puertos)",
+	"readme.layers.infrastructure":      "Infrastructure (Infraestructura)",
+	"readme.layers.adapters":            "Implementaciones concretas de los puertos definidos en domain",
+	"readme.layers.database":            "Repositorios que acceden a la base de datos",
+	"readme.layers.logger":              "Sistema de logging",
+	"readme.layers.entrypoints":         "Puntos de entrada a la aplicación",
+	"readme.layers.http":                "Handlers HTTP, controladores REST",
+	"readme.errors":                     "Manejo de errores",
+	"readme.errors.intro":               "Los casos de uso devuelven errores de `domain/errors` y los handlers los responden con\n`WriteError`, que los traduce a códigos HTTP y cuerpos `application/problem+json` (RFC 7807):",
+	"readme.errors.error":               "Error",
+	"readme.errors.status":              "Código HTTP",
+	"readme.errors.other":               "otros",
+	"readme.config":                     "Configuración",
+	"readme.config.database":            "Base de datos",
+	"readme.postgres":                   "Inicio Rápido con PostgreSQL",
+	"readme.postgres.env":               "Configurar variables de entorno",
+	"readme.postgres.env.intro":         "Copia el archivo `.env.example` a `.env` y configura tu URL de PostgreSQL:",
+	"readme.postgres.env.edit":          "Edita .env con tus credenciales",
+	"readme.postgres.db":                "Iniciar base de datos (opcional con Docker)",
+	"readme.postgres.tests":             "Ejecutar tests",
+	"readme.postgres.tests.short":       "Solo tests unitarios (sin base de datos)",
+	"readme.postgres.tests.integration": "Tests de integración (requiere base de datos)",
+	"readme.postgres.tests.all":         "Todos los tests",
+	"readme.postgres.or":                "o",
+	"readme.run":                        "Ejecutar la aplicación",
+	"readme.add":                        "Agregar componentes",
+	"readme.add.model":                  "Agregar un nuevo modelo de dominio",
+	"readme.add.usecase":                "Agregar un nuevo caso de uso",
+	"readme.add.adapter":                "Agregar un nuevo adaptador/repositorio",
+	"readme.add.handler":                "Agregar un nuevo handler HTTP",
+	"readme.principles":                 "Principios de Clean Architecture",
+	"readme.principles.frameworks":      "**Independencia de frameworks**: El dominio no depende de frameworks",
+	"readme.principles.testable":        "**Testeable**: La lógica de negocio puede testearse sin UI, BD, etc.",
+	"readme.principles.ui":              "**Independencia de la UI**: La UI puede cambiar sin afectar el dominio",
+	"readme.principles.database":        "**Independencia de la BD**: Puedes cambiar de BD sin afectar el negocio",
+	"readme.principles.external":        "**Independencia de agentes externos**: El dominio no conoce nada del mundo exterior",
+	"readme.flow":                       "Flujo de dependencias",
+	"readme.flow.inward":                "Las dependencias siempre apuntan hacia adentro (hacia el dominio).",
+}
@@ -0,0 +1,142 @@
+// Package i18n holds the message catalogues of the CLI and selects the
+// language of its output.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Supported languages
+const (
+	Spanish = "es"
+	English = "en"
+)
+
+// Languages lists the supported languages; the first one is the default
+var Languages = []string{Spanish, English}
+
+// catalogues maps each language to its messages by key. Messages are
+// fmt formats; a key has the same verbs in every language.
+var catalogues = map[string]map[string]string{
+	Spanish: es,
+	English: en,
+}
+
+// lang is the language of the messages
+var lang = Spanish
+
+// The language is chosen before the CLI builds its commands, whose help is
+// translated when they are declared, so --lang is read here from the command
+// line; the CLI validates its value later like any other flag.
+func init() {
+	lang = Detect()
+	if language := Parse(langFlag(os.Args[1:])); language != "" {
+		lang = language
+	}
+}
+
+// langFlag returns the value of --lang in args, or ""
+func langFlag(args []string) string {
+	value := ""
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if v, ok := strings.CutPrefix(arg, "--lang="); ok {
+			value = v
+		} else if arg == "--lang" && i+1 < len(args) {
+			value = args[i+1]
+		}
+	}
+	return value
+}
+
+// Set selects the language of the messages
+func Set(language string) error {
+	if _, ok := catalogues[language]; !ok {
+		return fmt.Errorf("idioma %q no soportado / unsupported language %q (%s)", language, language, strings.Join(Languages, ", "))
+	}
+	lang = language
+	return nil
+}
+
+// Lang returns the language of the messages
+func Lang() string {
+	return lang
+}
+
+// Supported reports whether language has a catalogue
+func Supported(language string) bool {
+	_, ok := catalogues[language]
+	return ok
+}
+
+// localeVars are the environment variables that choose the language, by
+// priority
+var localeVars = []string{"CLEANGO_LANG", "LC_ALL", "LC_MESSAGES", "LANG"}
+
+// Detect returns the language chosen by the environment: CLEANGO_LANG, or
+// the locale of the user ("en_US.UTF-8" is English). It falls back to the
+// default language when none names a supported one.
+func Detect() string {
+	for _, name := range localeVars {
+		if language := Parse(os.Getenv(name)); language != "" {
+			return language
+		}
+	}
+	return Languages[0]
+}
+
+// Parse returns the supported language of a locale or language name such as
+// "en", "en_US.UTF-8" or "es-MX", or "" when it names none
+func Parse(locale string) string {
+	language := strings.ToLower(locale)
+	if i := strings.IndexAny(language, "_-.@"); i >= 0 {
+		language = language[:i]
+	}
+	if Supported(language) {
+		return language
+	}
+	return ""
+}
+
+// Has reports whether key has a message in the current language
+func Has(key string) bool {
+	_, ok := catalogues[lang][key]
+	return ok
+}
+
+// message returns the format of key in language. Missing messages fall back
+// to the default language, and then to the key itself so they stand out.
+func message(language, key string) string {
+	if format, ok := catalogues[language][key]; ok {
+		return format
+	}
+	if format, ok := catalogues[Languages[0]][key]; ok {
+		return format
+	}
+	return key
+}
+
+// T returns the message key in the current language, formatted with args
+func T(key string, args ...interface{}) string {
+	return Translate(lang, key, args...)
+}
+
+// Translate returns the message key in language, formatted with args. It is
+// used for files generated in the language of a project rather than in the
+// one of the CLI; unsupported languages get the default one.
+func Translate(language, key string, args ...interface{}) string {
+	if len(args) == 0 {
+		return message(language, key)
+	}
+	return fmt.Sprintf(message(language, key), args...)
+}
+
+// Error returns an error with the message key formatted with args; a %w verb
+// wraps its argument as in fmt.Errorf
+func Error(key string, args ...interface{}) error {
+	return fmt.Errorf(message(lang, key), args...)
+}
@@ -0,0 +1,121 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestCataloguesHaveTheSameKeys checks that every message exists in every
+// language
+func TestCataloguesHaveTheSameKeys(t *testing.T) {
+	for _, language := range Languages {
+		for _, other := range Languages {
+			for key := range catalogues[language] {
+				if _, ok := catalogues[other][key]; !ok {
+					t.Errorf("%q is in %s but not in %s", key, language, other)
+				}
+			}
+		}
+	}
+}
+
+// verbPattern matches the fmt verbs of a message
+var verbPattern = regexp.MustCompile(`%[-+# 0]*[0-9]*(?:\.[0-9]+)?[a-zA-Z%]`)
+
+// TestCataloguesHaveTheSameVerbs checks that the translations of a message
+// take the same arguments in the same order
+func TestCataloguesHaveTheSameVerbs(t *testing.T) {
+	reference := catalogues[Languages[0]]
+	for _, language := range Languages[1:] {
+		for key, format := range catalogues[language] {
+			want := strings.Join(verbPattern.FindAllString(reference[key], -1), " ")
+			if got := strings.Join(verbPattern.FindAllString(format, -1), " "); got != want {
+				t.Errorf("%q in %s has verbs %q, want %q", key, language, got, want)
+			}
+		}
+	}
+}
+
+// keyUses match the message keys written as literals in the sources, with the
+// prefix their call adds to them; keys built at run time are not checked
+var keyUses = []struct {
+	pattern *regexp.Regexp
+	prefix  string
+}{
+	{regexp.MustCompile(`i18n\.(?:T|Error)\(\s*"` + keyLiteral), ""},
+	{regexp.MustCompile(`i18n\.Translate\([^,]+,\s*"` + keyLiteral), ""},
+	{regexp.MustCompile(`codedError\(\w+,\s*"` + keyLiteral), ""},
+	{regexp.MustCompile(`\badd\("` + keyLiteral), ""},
+	{regexp.MustCompile(`\bt\("` + keyLiteral), "readme."},
+	{regexp.MustCompile(`\btree\("[^"]*",\s*"` + keyLiteral), "readme.tree."},
+}
+
+// keyLiteral matches the rest of a complete key literal, not followed by a
+// concatenation
+const keyLiteral = `([a-z0-9_]+(?:\.[a-z0-9_]+)*)"\s*[,)]`
+
+// TestSourcesUseKnownKeys checks that the keys used by the CLI exist, so no
+// message falls back to its key
+func TestSourcesUseKnownKeys(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "*", "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	used := 0
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, use := range keyUses {
+			for _, match := range use.pattern.FindAllStringSubmatch(string(content), -1) {
+				used++
+				key := use.prefix + match[1]
+				if _, ok := catalogues[Languages[0]][key]; !ok {
+					t.Errorf("%s uses the unknown message %q", file, key)
+				}
+			}
+		}
+	}
+	if used == 0 {
+		t.Fatal("no message keys found in the sources")
+	}
+}
+
+func TestParse(t *testing.T) {
+	for locale, want := range map[string]string{
+		"en":          English,
+		"en_US.UTF-8": English,
+		"es-MX":       Spanish,
+		"ES":          Spanish,
+		"C":           "",
+		"fr_FR":       "",
+		"":            "",
+	} {
+		if got := Parse(locale); got != want {
+			t.Errorf("Parse(%q) = %q, want %q", locale, got, want)
+		}
+	}
+}
+
+func TestLangFlag(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"new", "--lang", "en"}, "en"},
+		{[]string{"--lang=es", "doctor"}, "es"},
+		{[]string{"new", "--", "--lang", "en"}, ""},
+		{[]string{"new", "--lang"}, ""},
+	} {
+		if got := langFlag(tc.args); got != tc.want {
+			t.Errorf("langFlag(%q) = %q, want %q", tc.args, got, tc.want)
+		}
+	}
+}
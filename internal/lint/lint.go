@@ -0,0 +1,329 @@
+package lint
+
+import (
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// Severity levels of a rule
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Rule forbids the packages under From from importing the paths in Deny,
+// except those in Allow. Entries are import path prefixes; entries whose first
+// element has no dot and is not a standard library package are directories of
+// the project, relative to its module.
+type Rule struct {
+	ID          string   `yaml:"id" json:"id"`
+	Description string   `yaml:"description" json:"description"`
+	From        []string `yaml:"from" json:"from"`
+	Deny        []string `yaml:"deny" json:"deny"`
+	Allow       []string `yaml:"allow,omitempty" json:"allow,omitempty"`
+	Severity    string   `yaml:"severity,omitempty" json:"severity,omitempty"`
+}
+
+// Config customises the rules, and is read from the lint section of the
+// project manifest
+type Config struct {
+	// Disable lists the IDs of default rules to skip
+	Disable []string `yaml:"disable,omitempty"`
+	// Rules adds rules, or replaces the default rule with the same ID
+	Rules []Rule `yaml:"rules,omitempty"`
+	// Severity overrides the severity of rules by ID
+	Severity map[string]string `yaml:"severity,omitempty"`
+	// Tests also checks _test.go files
+	Tests bool `yaml:"tests,omitempty"`
+}
+
+// Options controls a lint run
+type Options struct {
+	Dir        string
+	ModulePath string
+	Config     Config
+}
+
+// Violation is an import breaking a rule
+type Violation struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Package  string `json:"package"`
+	Import   string `json:"import"`
+	Message  string `json:"message"`
+}
+
+// Report is the result of a lint run
+type Report struct {
+	Rules      []Rule      `json:"rules"`
+	Violations []Violation `json:"violations"`
+}
+
+// Errors counts the violations with error severity
+func (r *Report) Errors() int {
+	count := 0
+	for _, v := range r.Violations {
+		if v.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+// frameworkPackages are drivers and frameworks the domain must not depend on
+var frameworkPackages = []string{
+	"net/http",
+	"database/sql",
+	"github.com/gin-gonic/gin",
+	"github.com/go-chi/chi",
+	"github.com/gofiber/fiber",
+	"github.com/labstack/echo",
+	"github.com/jackc/pgx",
+	"github.com/lib/pq",
+	"github.com/go-sql-driver/mysql",
+	"go.mongodb.org/mongo-driver",
+	"github.com/godror/godror",
+	"github.com/redis/go-redis",
+	"github.com/segmentio/kafka-go",
+	"google.golang.org/grpc",
+	"github.com/graph-gophers/graphql-go",
+	"gorm.io",
+	"go.uber.org/zap",
+}
+
+// DefaultRules returns the dependency rules of Clean Architecture for the
+// layout created by 'cleango new'
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			ID:          "domain-no-outer-layers",
+			Description: i18n.T("lint.rule.outer"),
+			From:        []string{"domain"},
+			Deny:        []string{"infrastructure", "config", "cmd"},
+			Severity:    SeverityError,
+		},
+		{
+			ID:          "domain-no-frameworks",
+			Description: i18n.T("lint.rule.frameworks"),
+			From:        []string{"domain"},
+			Deny:        frameworkPackages,
+			Severity:    SeverityError,
+		},
+		{
+			ID:          "models-no-usecases",
+			Description: i18n.T("lint.rule.models"),
+			From:        []string{"domain/models"},
+			Deny:        []string{"domain/usecases"},
+			Severity:    SeverityError,
+		},
+		{
+			ID:          "entrypoints-no-adapters",
+			Description: i18n.T("lint.rule.entrypoints"),
+			From:        []string{"infrastructure/entrypoints"},
+			Deny:        []string{"infrastructure/adapters"},
+			Allow:       []string{"infrastructure/adapters/logger"},
+			Severity:    SeverityError,
+		},
+		{
+			ID:          "adapters-no-entrypoints",
+			Description: i18n.T("lint.rule.adapters"),
+			From:        []string{"infrastructure/adapters"},
+			Deny:        []string{"infrastructure/entrypoints", "cmd"},
+			Severity:    SeverityError,
+		},
+	}
+}
+
+// rules returns the default rules with the configuration applied
+func (c Config) rules() []Rule {
+	disabled := map[string]bool{}
+	for _, id := range c.Disable {
+		disabled[id] = true
+	}
+
+	custom := map[string]Rule{}
+	for _, rule := range c.Rules {
+		custom[rule.ID] = rule
+	}
+
+	var rules []Rule
+	for _, rule := range DefaultRules() {
+		if replacement, ok := custom[rule.ID]; ok {
+			rule = replacement
+			delete(custom, rule.ID)
+		}
+		if !disabled[rule.ID] {
+			rules = append(rules, rule)
+		}
+	}
+	for _, rule := range c.Rules {
+		if _, ok := custom[rule.ID]; ok && !disabled[rule.ID] {
+			rules = append(rules, rule)
+		}
+	}
+
+	for i := range rules {
+		if severity, ok := c.Severity[rules[i].ID]; ok {
+			rules[i].Severity = severity
+		}
+		if rules[i].Severity == "" {
+			rules[i].Severity = SeverityError
+		}
+	}
+	return rules
+}
+
+// Validate checks that the configured rules are well formed
+func (c Config) Validate() error {
+	for _, rule := range c.Rules {
+		if rule.ID == "" {
+			return i18n.Error("lint.config.no_id")
+		}
+		if len(rule.From) == 0 || len(rule.Deny) == 0 {
+			return i18n.Error("lint.config.from_deny", rule.ID)
+		}
+		if rule.Severity != "" && rule.Severity != SeverityError && rule.Severity != SeverityWarning {
+			return i18n.Error("lint.config.severity", rule.Severity, rule.ID)
+		}
+	}
+	for id, severity := range c.Severity {
+		if severity != SeverityError && severity != SeverityWarning {
+			return i18n.Error("lint.config.severity", severity, id)
+		}
+	}
+	return nil
+}
+
+// Run checks the imports of every Go file in the project against the rules
+func Run(opts Options) (*Report, error) {
+	if err := opts.Config.Validate(); err != nil {
+		return nil, err
+	}
+
+	report := &Report{Rules: opts.Config.rules(), Violations: []Violation{}}
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(opts.Dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if path != opts.Dir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || (!opts.Config.Tests && strings.HasSuffix(path, "_test.go")) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(opts.Dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		pkg := strings.TrimSuffix(filepath.ToSlash(filepath.Dir(rel)), "/.")
+
+		file, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+		if err != nil {
+			return i18n.Error("err.read", rel, err)
+		}
+
+		for _, spec := range file.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			for _, rule := range report.Rules {
+				if !matchesAny(pkg, rule.From, "") {
+					continue
+				}
+				if !matchesAny(importPath, rule.Deny, opts.ModulePath) || matchesAny(importPath, rule.Allow, opts.ModulePath) {
+					continue
+				}
+
+				pos := fset.Position(spec.Path.Pos())
+				report.Violations = append(report.Violations, Violation{
+					Rule:     rule.ID,
+					Severity: rule.Severity,
+					File:     rel,
+					Line:     pos.Line,
+					Column:   pos.Column,
+					Package:  pkg,
+					Import:   importPath,
+					Message:  i18n.T("lint.violation", pkg, importPath, lowerFirst(rule.Description)),
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if os.IsNotExist(err) {
+			return report, nil
+		}
+		return nil, err
+	}
+
+	sort.SliceStable(report.Violations, func(i, j int) bool {
+		a, b := report.Violations[i], report.Violations[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return report, nil
+}
+
+// matchesAny reports whether path equals or is nested under one of the
+// prefixes. Module-relative prefixes are resolved against modulePath.
+func matchesAny(path string, prefixes []string, modulePath string) bool {
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if modulePath != "" && isLocal(prefix) {
+			prefix = modulePath + "/" + prefix
+		}
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// isLocal reports whether a rule entry names a directory of the project
+// rather than an import path. Standard library paths listed in rules are
+// known by having no dot either, so they are recognised by their root.
+func isLocal(entry string) bool {
+	first := strings.SplitN(entry, "/", 2)[0]
+	if strings.Contains(first, ".") {
+		return false
+	}
+	return !stdlibRoots[first]
+}
+
+// stdlibRoots are the top-level standard library packages that may appear in
+// rule entries
+var stdlibRoots = map[string]bool{
+	"archive": true, "bufio": true, "bytes": true, "context": true, "crypto": true,
+	"database": true, "encoding": true, "errors": true, "fmt": true, "go": true,
+	"hash": true, "html": true, "image": true, "io": true, "log": true, "math": true,
+	"mime": true, "net": true, "os": true, "path": true, "reflect": true, "regexp": true,
+	"runtime": true, "sort": true, "strconv": true, "strings": true, "sync": true,
+	"syscall": true, "text": true, "time": true, "unicode": true, "unsafe": true,
+}
+
+// lowerFirst lowercases the first letter of s
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
@@ -0,0 +1,155 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// Output formats
+const (
+	FormatHuman = "human"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatHuman, FormatJSON, FormatSARIF}
+
+// Write renders the report in the given format
+func Write(w io.Writer, report *Report, format string) error {
+	switch format {
+	case FormatHuman, "":
+		return writeHuman(w, report)
+	case FormatJSON:
+		return writeJSON(w, report)
+	case FormatSARIF:
+		return writeJSON(w, toSARIF(report))
+	default:
+		return i18n.Error("format.unsupported", format, strings.Join(Formats, ", "))
+	}
+}
+
+// writeHuman lists one violation per line in the file:line:column form
+// understood by editors
+func writeHuman(w io.Writer, report *Report) error {
+	if len(report.Violations) == 0 {
+		_, err := fmt.Fprintln(w, i18n.T("lint.clean", len(report.Rules)))
+		return err
+	}
+
+	for _, v := range report.Violations {
+		if _, err := fmt.Fprintf(w, "%s:%d:%d: %s [%s] %s\n", v.File, v.Line, v.Column, v.Severity, v.Rule, v.Message); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "\n"+i18n.T("lint.summary", len(report.Violations), report.Errors()))
+	return err
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// SARIF 2.1.0 log, limited to the properties code scanning tools read
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+	Region           sarifRegion   `json:"region"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+// toSARIF converts the report into a SARIF log
+func toSARIF(report *Report) sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "cleango",
+			InformationURI: "https://github.com/YeridStick/cleango",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	for _, rule := range report.Rules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:                   rule.ID,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: rule.Severity},
+		})
+	}
+	for _, v := range report.Violations {
+		run.Results = append(run.Results, sarifResult{
+			RuleID:  v.Rule,
+			Level:   v.Severity,
+			Message: sarifMessage{Text: v.Message},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifact{URI: v.File},
+				Region:           sarifRegion{StartLine: v.Line, StartColumn: v.Column},
+			}}},
+		})
+	}
+
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+}
@@ -0,0 +1,112 @@
+package merge
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// diffLine is a line of an edit script: ' ' kept, '-' removed, '+' added
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// Unified returns the unified diff turning a into b, or "" when they are
+// equal. fromName and toName label the two versions.
+func Unified(fromName, toName string, a, b []byte) string {
+	al, bl := splitLines(a), splitLines(b)
+	script := editScript(al, bl)
+
+	var out strings.Builder
+	for start := 0; start < len(script); {
+		// Find the next change and the end of its hunk
+		first := start
+		for first < len(script) && script[first].op == ' ' {
+			first++
+		}
+		if first == len(script) {
+			break
+		}
+		last := first
+		for i := first; i < len(script); i++ {
+			if script[i].op != ' ' {
+				last = i
+			} else if i-last > 2*diffContext {
+				break
+			}
+		}
+
+		from := max(first-diffContext, start)
+		to := min(last+diffContext+1, len(script))
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		writeHunk(&out, script, from, to)
+		start = to
+	}
+	return out.String()
+}
+
+// writeHunk writes script[from:to] with its @@ header
+func writeHunk(out *strings.Builder, script []diffLine, from, to int) {
+	// Line numbers are 1-based positions in a and b
+	aStart, bStart := 1, 1
+	for _, line := range script[:from] {
+		if line.op != '+' {
+			aStart++
+		}
+		if line.op != '-' {
+			bStart++
+		}
+	}
+	aLen, bLen := 0, 0
+	for _, line := range script[from:to] {
+		if line.op != '+' {
+			aLen++
+		}
+		if line.op != '-' {
+			bLen++
+		}
+	}
+	if aLen == 0 {
+		aStart--
+	}
+	if bLen == 0 {
+		bStart--
+	}
+
+	fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", aStart, aLen, bStart, bLen)
+	for _, line := range script[from:to] {
+		out.WriteByte(line.op)
+		out.WriteString(line.text)
+		if !strings.HasSuffix(line.text, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// editScript returns the lines kept, removed and added to turn a into b
+func editScript(a, b []string) []diffLine {
+	pairs := match(a, b)
+
+	var script []diffLine
+	j := 0
+	for i, line := range a {
+		if pairs[i] < 0 {
+			script = append(script, diffLine{'-', line})
+			continue
+		}
+		for ; j < pairs[i]; j++ {
+			script = append(script, diffLine{'+', b[j]})
+		}
+		script = append(script, diffLine{' ', line})
+		j++
+	}
+	for ; j < len(b); j++ {
+		script = append(script, diffLine{'+', b[j]})
+	}
+	return script
+}
@@ -0,0 +1,82 @@
+package merge
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// numbered returns the lines 1 to n, replacing those in changed
+func numbered(n int, changed map[int]string) string {
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		if line, ok := changed[i]; ok {
+			b.WriteString(line + "\n")
+		} else {
+			fmt.Fprintf(&b, "%d\n", i)
+		}
+	}
+	return b.String()
+}
+
+// TestUnified diffs two versions and checks the hunks and their headers
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "equal",
+			a:    "a\nb\n", b: "a\nb\n",
+			want: "",
+		},
+		{
+			name: "change with context",
+			a:    numbered(10, nil), b: numbered(10, map[int]string{5: "five"}),
+			want: "--- a\n+++ b\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name: "distant changes in two hunks",
+			a:    numbered(20, nil), b: numbered(20, map[int]string{2: "two", 18: "eighteen"}),
+			want: "--- a\n+++ b\n" +
+				"@@ -1,5 +1,5 @@\n 1\n-2\n+two\n 3\n 4\n 5\n" +
+				"@@ -15,6 +15,6 @@\n 15\n 16\n 17\n-18\n+eighteen\n 19\n 20\n",
+		},
+		{
+			name: "close changes in one hunk",
+			a:    numbered(12, nil), b: numbered(12, map[int]string{3: "three", 9: "nine"}),
+			want: "--- a\n+++ b\n@@ -1,12 +1,12 @@\n 1\n 2\n-3\n+three\n 4\n 5\n 6\n 7\n 8\n-9\n+nine\n 10\n 11\n 12\n",
+		},
+		{
+			name: "insertion shifts the new line numbers",
+			a:    numbered(20, nil), b: numbered(20, map[int]string{1: "1\nnew", 18: "eighteen"}),
+			want: "--- a\n+++ b\n" +
+				"@@ -1,4 +1,5 @@\n 1\n+new\n 2\n 3\n 4\n" +
+				"@@ -15,6 +16,6 @@\n 15\n 16\n 17\n-18\n+eighteen\n 19\n 20\n",
+		},
+		{
+			name: "into an empty file",
+			a:    "", b: "x\ny\n",
+			want: "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+x\n+y\n",
+		},
+		{
+			name: "to an empty file",
+			a:    "x\ny\n", b: "",
+			want: "--- a\n+++ b\n@@ -1,2 +0,0 @@\n-x\n-y\n",
+		},
+		{
+			name: "without a trailing newline",
+			a:    "a\nb", b: "a\nc",
+			want: "--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unified("a", "b", []byte(tt.a), []byte(tt.b)); got != tt.want {
+				t.Errorf("diff:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
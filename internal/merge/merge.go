@@ -0,0 +1,142 @@
+package merge
+
+import "strings"
+
+// Labels name the versions shown in conflict markers
+type Labels struct {
+	Ours   string
+	Base   string
+	Theirs string
+}
+
+// Merge performs a line-based three-way merge of ours and theirs, two
+// descendants of base. Changes made on a single side are applied; regions
+// changed differently on both sides are kept with git-style conflict markers.
+// It returns the merged content and the number of conflicts.
+func Merge(base, ours, theirs []byte, labels Labels) ([]byte, int) {
+	b, o, t := splitLines(base), splitLines(ours), splitLines(theirs)
+	mo, mt := match(b, o), match(b, t)
+
+	var out []string
+	conflicts := 0
+	i, oi, ti := 0, 0, 0
+	for {
+		// Find the next base line kept by both sides
+		j := i
+		for j < len(b) && (mo[j] < 0 || mt[j] < 0) {
+			j++
+		}
+		oe, te := len(o), len(t)
+		if j < len(b) {
+			oe, te = mo[j], mt[j]
+		}
+
+		lines, conflict := mergeChunk(b[i:j], o[oi:oe], t[ti:te], labels)
+		out = append(out, lines...)
+		if conflict {
+			conflicts++
+		}
+
+		if j == len(b) {
+			break
+		}
+		out = append(out, b[j])
+		i, oi, ti = j+1, oe+1, te+1
+	}
+
+	return []byte(strings.Join(out, "")), conflicts
+}
+
+// mergeChunk resolves a region between two stable lines
+func mergeChunk(base, ours, theirs []string, labels Labels) ([]string, bool) {
+	switch {
+	case equal(ours, base):
+		return theirs, false
+	case equal(theirs, base), equal(ours, theirs):
+		return ours, false
+	}
+
+	out := []string{"<<<<<<< " + labels.Ours + "\n"}
+	out = append(out, terminated(ours)...)
+	if labels.Base != "" {
+		out = append(out, "||||||| "+labels.Base+"\n")
+		out = append(out, terminated(base)...)
+	}
+	out = append(out, "=======\n")
+	out = append(out, terminated(theirs)...)
+	out = append(out, ">>>>>>> "+labels.Theirs+"\n")
+	return out, true
+}
+
+// match pairs the lines of a and b along a longest common subsequence,
+// returning for each line of a the index of its pair in b, or -1
+func match(a, b []string) []int {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	pairs := make([]int, len(a))
+	for i := range pairs {
+		pairs[i] = -1
+	}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			pairs[i] = j
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return pairs
+}
+
+// splitLines splits content into lines, keeping their line endings
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// terminated ensures the last line ends with a newline, so a conflict marker
+// that follows starts on its own line
+func terminated(lines []string) []string {
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		lines = append(lines[:n-1:n-1], lines[n-1]+"\n")
+	}
+	return lines
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,145 @@
+package merge
+
+import "testing"
+
+// TestMerge merges two descendants of a base line by line
+func TestMerge(t *testing.T) {
+	labels := Labels{Ours: "local", Base: "original", Theirs: "cleango"}
+	tests := []struct {
+		name               string
+		base, ours, theirs string
+		labels             Labels
+		want               string
+		wantConflicts      int
+	}{
+		{
+			name: "change on our side only",
+			base: "a\nb\nc\n", ours: "a\nB\nc\n", theirs: "a\nb\nc\n",
+			want: "a\nB\nc\n",
+		},
+		{
+			name: "change on their side only",
+			base: "a\nb\nc\n", ours: "a\nb\nc\n", theirs: "a\nb\nC\n",
+			want: "a\nb\nC\n",
+		},
+		{
+			name: "changes to different lines",
+			base: "a\nb\nc\nd\ne\n", ours: "A\nb\nc\nd\ne\n", theirs: "a\nb\nc\nd\nE\n",
+			want: "A\nb\nc\nd\nE\n",
+		},
+		{
+			name: "same change on both sides",
+			base: "a\nb\nc\n", ours: "a\nB\nc\n", theirs: "a\nB\nc\n",
+			want: "a\nB\nc\n",
+		},
+		{
+			name: "conflicting changes",
+			base: "a\nb\nc\n", ours: "a\nX\nc\n", theirs: "a\nY\nc\n",
+			want:          "a\n<<<<<<< local\nX\n||||||| original\nb\n=======\nY\n>>>>>>> cleango\nc\n",
+			wantConflicts: 1,
+		},
+		{
+			name: "conflicting changes without a base label",
+			base: "a\nb\nc\n", ours: "a\nX\nc\n", theirs: "a\nY\nc\n",
+			labels:        Labels{Ours: "local", Theirs: "cleango"},
+			want:          "a\n<<<<<<< local\nX\n=======\nY\n>>>>>>> cleango\nc\n",
+			wantConflicts: 1,
+		},
+		{
+			name: "two conflicts",
+			base: "a\nb\nc\nd\ne\n", ours: "a\nX\nc\nX\ne\n", theirs: "a\nY\nc\nY\ne\n",
+			want: "a\n<<<<<<< local\nX\n||||||| original\nb\n=======\nY\n>>>>>>> cleango\n" +
+				"c\n<<<<<<< local\nX\n||||||| original\nd\n=======\nY\n>>>>>>> cleango\ne\n",
+			wantConflicts: 2,
+		},
+		{
+			name: "insertions at the same point",
+			base: "a\nb\n", ours: "a\nX\nb\n", theirs: "a\nY\nb\n",
+			want:          "a\n<<<<<<< local\nX\n||||||| original\n=======\nY\n>>>>>>> cleango\nb\n",
+			wantConflicts: 1,
+		},
+		{
+			name: "same insertion on both sides",
+			base: "a\nb\n", ours: "a\nX\nb\n", theirs: "a\nX\nb\n",
+			want: "a\nX\nb\n",
+		},
+		{
+			name: "insertions at different points",
+			base: "a\nb\nc\n", ours: "a\nX\nb\nc\n", theirs: "a\nb\nc\nY\n",
+			want: "a\nX\nb\nc\nY\n",
+		},
+		{
+			name: "deletion of an unchanged line",
+			base: "a\nb\nc\n", ours: "a\nc\n", theirs: "a\nb\nc\nd\n",
+			want: "a\nc\nd\n",
+		},
+		{
+			name: "deletion against an edit",
+			base: "a\nb\nc\n", ours: "a\nc\n", theirs: "a\nB\nc\n",
+			want:          "a\n<<<<<<< local\n||||||| original\nb\n=======\nB\n>>>>>>> cleango\nc\n",
+			wantConflicts: 1,
+		},
+		{
+			name: "edit against a deletion",
+			base: "a\nb\nc\n", ours: "a\nB\nc\n", theirs: "a\nc\n",
+			want:          "a\n<<<<<<< local\nB\n||||||| original\nb\n=======\n>>>>>>> cleango\nc\n",
+			wantConflicts: 1,
+		},
+		{
+			name: "same deletion on both sides",
+			base: "a\nb\nc\n", ours: "a\nc\n", theirs: "a\nc\n",
+			want: "a\nc\n",
+		},
+		{
+			name: "append to a file without a trailing newline",
+			base: "a\nb", ours: "a\nb\nc", theirs: "a\nb",
+			want: "a\nb\nc",
+		},
+		{
+			name: "trailing newline added on one side",
+			base: "a\nb", ours: "a\nb", theirs: "a\nb\n",
+			want: "a\nb\n",
+		},
+		{
+			name: "conflict at the end of a file without a trailing newline",
+			base: "a\nb", ours: "a\nX", theirs: "a\nY",
+			want:          "a\n<<<<<<< local\nX\n||||||| original\nb\n=======\nY\n>>>>>>> cleango\n",
+			wantConflicts: 1,
+		},
+		{
+			name: "empty base, content on one side",
+			base: "", ours: "", theirs: "a\nb\n",
+			want: "a\nb\n",
+		},
+		{
+			name: "empty base, same content on both sides",
+			base: "", ours: "a\n", theirs: "a\n",
+			want: "a\n",
+		},
+		{
+			name: "empty base, different content",
+			base: "", ours: "a\n", theirs: "b\n",
+			want:          "<<<<<<< local\na\n||||||| original\n=======\nb\n>>>>>>> cleango\n",
+			wantConflicts: 1,
+		},
+		{
+			name: "everything empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := tt.labels
+			if l == (Labels{}) {
+				l = labels
+			}
+			got, conflicts := Merge([]byte(tt.base), []byte(tt.ours), []byte(tt.theirs), l)
+			if string(got) != tt.want {
+				t.Errorf("merged:\n%s\nwant:\n%s", got, tt.want)
+			}
+			if conflicts != tt.wantConflicts {
+				t.Errorf("%d conflicts, want %d", conflicts, tt.wantConflicts)
+			}
+		})
+	}
+}
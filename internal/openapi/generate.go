@@ -0,0 +1,842 @@
+package openapi
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// HTTPDir is the package holding the generated HTTP handlers and routes
+const HTTPDir = "infrastructure/entrypoints/http"
+
+// Options controls how a document is built from project sources
+type Options struct {
+	Dir        string
+	ModulePath string
+	Title      string
+	Version    string
+}
+
+// Generate builds an OpenAPI document by parsing the handlers, routes and
+// DTOs of a generated project. The result only depends on the sources, so
+// regenerating an unchanged project yields an identical document.
+func Generate(opts Options) (*Document, error) {
+	a := &analyzer{
+		opts:     opts,
+		fset:     token.NewFileSet(),
+		packages: map[string]*sourcePackage{},
+		names:    map[string]string{},
+		doc: &Document{
+			OpenAPI: Version,
+			Info:    Info{Title: opts.Title, Version: opts.Version},
+			Paths:   map[string]*PathItem{},
+			Components: Components{
+				Schemas: map[string]*Schema{},
+			},
+		},
+	}
+
+	pkg, err := a.load(HTTPDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkg.files) == 0 {
+		return nil, i18n.Error("openapi.no_handlers", HTTPDir)
+	}
+
+	routes := a.routes(pkg)
+	if len(routes) == 0 {
+		return nil, i18n.Error("openapi.no_routes")
+	}
+
+	for _, r := range routes {
+		item, ok := a.doc.Paths[r.path]
+		if !ok {
+			item = &PathItem{}
+			a.doc.Paths[r.path] = item
+		}
+		item.setOperation(r.method, a.operation(pkg, r))
+	}
+
+	return a.doc, nil
+}
+
+// route is an endpoint registered by a handler's RegisterRoutes method
+type route struct {
+	method  string
+	path    string
+	handler string
+	fn      string
+}
+
+// declaredStruct is a struct type together with the file declaring it
+type declaredStruct struct {
+	file *ast.File
+	st   *ast.StructType
+}
+
+// sourcePackage is a parsed package directory of the project
+type sourcePackage struct {
+	dir        string
+	files      []*ast.File
+	structs    map[string]declaredStruct
+	interfaces map[string]*ast.InterfaceType
+	funcs      map[string]*ast.FuncDecl
+}
+
+type analyzer struct {
+	opts     Options
+	fset     *token.FileSet
+	packages map[string]*sourcePackage
+	names    map[string]string
+	doc      *Document
+}
+
+// load parses the non-test Go files of a project directory
+func (a *analyzer) load(dir string) (*sourcePackage, error) {
+	if pkg, ok := a.packages[dir]; ok {
+		return pkg, nil
+	}
+
+	pkg := &sourcePackage{
+		dir:        dir,
+		structs:    map[string]declaredStruct{},
+		interfaces: map[string]*ast.InterfaceType{},
+		funcs:      map[string]*ast.FuncDecl{},
+	}
+	a.packages[dir] = pkg
+
+	entries, err := os.ReadDir(filepath.Join(a.opts.Dir, dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return pkg, nil
+		}
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(a.fset, filepath.Join(a.opts.Dir, dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, i18n.Error("openapi.parse", filepath.Join(dir, name), err)
+		}
+		pkg.files = append(pkg.files, file)
+
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						switch t := ts.Type.(type) {
+						case *ast.StructType:
+							pkg.structs[ts.Name.Name] = declaredStruct{file: file, st: t}
+						case *ast.InterfaceType:
+							pkg.interfaces[ts.Name.Name] = t
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				pkg.funcs[funcKey(d)] = d
+			}
+		}
+	}
+
+	return pkg, nil
+}
+
+// funcKey identifies a function as "Recv.Name" or "Name"
+func funcKey(fn *ast.FuncDecl) string {
+	if recv := receiverName(fn); recv != "" {
+		return recv + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
+
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// routes collects the endpoints registered in RegisterRoutes methods
+func (a *analyzer) routes(pkg *sourcePackage) []route {
+	var routes []route
+
+	for _, fn := range pkg.funcs {
+		handler := receiverName(fn)
+		if handler == "" || fn.Name.Name != "RegisterRoutes" || fn.Body == nil {
+			continue
+		}
+
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) < 2 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			target, ok := call.Args[len(call.Args)-1].(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			pattern, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				return true
+			}
+
+			method := strings.ToUpper(sel.Sel.Name)
+			if method == "HANDLEFUNC" || method == "HANDLE" {
+				parts := strings.Fields(pattern)
+				if len(parts) != 2 {
+					return true
+				}
+				method, pattern = strings.ToUpper(parts[0]), parts[1]
+			}
+			if !isHTTPMethod(method) {
+				return true
+			}
+
+			routes = append(routes, route{
+				method:  method,
+				path:    normalizePath(pattern),
+				handler: handler,
+				fn:      target.Sel.Name,
+			})
+			return true
+		})
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].path != routes[j].path {
+			return routes[i].path < routes[j].path
+		}
+		return routes[i].method < routes[j].method
+	})
+	return routes
+}
+
+func isHTTPMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^}:.]+)[^}]*\}`)
+
+// normalizePath converts router specific path parameters (":id", "{id:[0-9]+}",
+// "{rest...}") into OpenAPI "{id}" parameters
+func normalizePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return pathParamPattern.ReplaceAllString(strings.Join(segments, "/"), "{$1}")
+}
+
+// pathParams returns the parameter names of an OpenAPI path, in order
+func pathParams(p string) []string {
+	var params []string
+	for _, match := range pathParamPattern.FindAllStringSubmatch(p, -1) {
+		params = append(params, match[1])
+	}
+	return params
+}
+
+// genericMethods are handler method names that do not identify a resource
+var genericMethods = map[string]bool{
+	"Get":    true,
+	"List":   true,
+	"Post":   true,
+	"Create": true,
+	"Put":    true,
+	"Update": true,
+	"Patch":  true,
+	"Delete": true,
+}
+
+// operation builds the operation for a route by inspecting the handler body
+func (a *analyzer) operation(pkg *sourcePackage, r route) *Operation {
+	tag := strings.TrimSuffix(strings.TrimSuffix(r.handler, "Handler"), "API")
+	// Handlers from "cleango add handler" use bare verbs, so the tag keeps
+	// their operation ids unique; contract-first handlers already are
+	operationID := strings.ToLower(r.fn[:1]) + r.fn[1:]
+	if genericMethods[r.fn] {
+		operationID += tag
+	}
+	op := &Operation{
+		OperationID: operationID,
+		Tags:        []string{tag},
+		Responses:   map[string]*Response{},
+	}
+
+	params := pathParams(r.path)
+	for _, name := range params {
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+
+	fn := pkg.funcs[r.handler+"."+r.fn]
+	if fn == nil || fn.Body == nil {
+		op.Responses["200"] = &Response{Description: http.StatusText(http.StatusOK)}
+		return op
+	}
+	if fn.Doc != nil {
+		op.Summary = strings.SplitN(strings.TrimSpace(fn.Doc.Text()), "\n", 2)[0]
+	}
+
+	file := a.fileOf(pkg, fn)
+	vars := localVars(fn.Body)
+	a.resultVars(pkg, file, r.handler, fn.Body, vars)
+	usesErrors := false
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		switch callName(call) {
+		case "DecodeJSON", "ShouldBindJSON", "BindJSON", "BodyParser", "Decode":
+			if len(call.Args) == 0 {
+				return true
+			}
+			if typ := a.typeOfArg(call.Args[len(call.Args)-1], vars); typ != nil {
+				op.RequestBody = &RequestBody{
+					Required: true,
+					Content: map[string]MediaType{
+						"application/json": {Schema: a.schemaFor(pkg, file, typ)},
+					},
+				}
+			}
+		case "WriteJSON":
+			if len(call.Args) < 2 {
+				return true
+			}
+			status := statusCode(call.Args[len(call.Args)-2])
+			if status == 0 {
+				return true
+			}
+			body := call.Args[len(call.Args)-1]
+			schema := &Schema{Type: "object"}
+			if typ := a.typeOfArg(body, vars); typ != nil {
+				schema = a.schemaFor(pkg, file, typ)
+			}
+			op.Responses[strconv.Itoa(status)] = &Response{
+				Description: http.StatusText(status),
+				Content: map[string]MediaType{
+					"application/json": {Schema: schema},
+				},
+			}
+		case "WriteHeader", "Status", "SendStatus":
+			if len(call.Args) != 1 {
+				return true
+			}
+			if status := statusCode(call.Args[0]); status != 0 {
+				key := strconv.Itoa(status)
+				if _, exists := op.Responses[key]; !exists {
+					op.Responses[key] = &Response{Description: http.StatusText(status)}
+				}
+			}
+		case "WriteError":
+			usesErrors = true
+		}
+		return true
+	})
+
+	if usesErrors {
+		if problem := a.problemSchema(pkg); problem != nil {
+			errorResponse := func(description string) *Response {
+				return &Response{
+					Description: description,
+					Content: map[string]MediaType{
+						"application/problem+json": {Schema: problem},
+					},
+				}
+			}
+			if op.RequestBody != nil {
+				op.Responses["413"] = errorResponse(http.StatusText(http.StatusRequestEntityTooLarge))
+				op.Responses["422"] = errorResponse(http.StatusText(http.StatusUnprocessableEntity))
+			}
+			if len(params) > 0 {
+				op.Responses["404"] = errorResponse(http.StatusText(http.StatusNotFound))
+			}
+			op.Responses["default"] = errorResponse("Error")
+		}
+	}
+
+	if len(op.Responses) == 0 {
+		op.Responses["200"] = &Response{Description: http.StatusText(http.StatusOK)}
+	}
+	return op
+}
+
+// problemSchema returns a reference to the RFC 7807 Problem schema, if the
+// HTTP package declares one
+func (a *analyzer) problemSchema(pkg *sourcePackage) *Schema {
+	decl, ok := pkg.structs["Problem"]
+	if !ok {
+		return nil
+	}
+	return a.schemaFor(pkg, decl.file, ast.NewIdent("Problem"))
+}
+
+func (a *analyzer) fileOf(pkg *sourcePackage, node ast.Node) *ast.File {
+	for _, file := range pkg.files {
+		if file.Pos() <= node.Pos() && node.End() <= file.End() {
+			return file
+		}
+	}
+	return nil
+}
+
+// callName returns the name of the called function or method
+func callName(call *ast.CallExpr) string {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return fun.Name
+	case *ast.SelectorExpr:
+		return fun.Sel.Name
+	}
+	return ""
+}
+
+// localVars maps the variables declared in a function body to their types
+func localVars(body *ast.BlockStmt) map[string]ast.Expr {
+	vars := map[string]ast.Expr{}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.ValueSpec:
+			if stmt.Type != nil {
+				for _, name := range stmt.Names {
+					vars[name.Name] = stmt.Type
+				}
+			}
+		case *ast.AssignStmt:
+			if stmt.Tok != token.DEFINE || len(stmt.Lhs) != len(stmt.Rhs) {
+				return true
+			}
+			for i, lhs := range stmt.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					if typ := literalType(stmt.Rhs[i]); typ != nil {
+						vars[ident.Name] = typ
+					}
+				}
+			}
+		}
+		return true
+	})
+	return vars
+}
+
+// resultVars adds to vars the variables assigned from calls to dependencies
+// of the handler, such as "out, err := h.getUser.Execute(...)", typing them
+// with the first result of the method declared by the dependency interface
+func (a *analyzer) resultVars(pkg *sourcePackage, file *ast.File, handler string, body *ast.BlockStmt, vars map[string]ast.Expr) {
+	decl, ok := pkg.structs[handler]
+	if !ok {
+		return
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		stmt, ok := n.(*ast.AssignStmt)
+		if !ok || stmt.Tok != token.DEFINE || len(stmt.Rhs) != 1 {
+			return true
+		}
+		out, ok := stmt.Lhs[0].(*ast.Ident)
+		if !ok || out.Name == "_" {
+			return true
+		}
+		call, ok := stmt.Rhs[0].(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		method, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		dep, ok := method.X.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		if typ := a.methodResult(decl, file, dep.Sel.Name, method.Sel.Name); typ != nil {
+			vars[out.Name] = typ
+		}
+		return true
+	})
+}
+
+// methodResult returns the first result type of a method of the interface
+// type of a struct field, qualified with the package alias of file
+func (a *analyzer) methodResult(decl declaredStruct, file *ast.File, field, method string) ast.Expr {
+	for _, f := range decl.st.Fields.List {
+		for _, name := range f.Names {
+			if name.Name != field {
+				continue
+			}
+			sel, ok := f.Type.(*ast.SelectorExpr)
+			if !ok {
+				return nil
+			}
+			alias, ok := sel.X.(*ast.Ident)
+			if !ok {
+				return nil
+			}
+			dir := a.localImport(file, alias.Name)
+			if dir == "" {
+				return nil
+			}
+			other, err := a.load(dir)
+			if err != nil {
+				return nil
+			}
+			iface, ok := other.interfaces[sel.Sel.Name]
+			if !ok {
+				return nil
+			}
+			for _, m := range iface.Methods.List {
+				fn, ok := m.Type.(*ast.FuncType)
+				if !ok || len(m.Names) == 0 || m.Names[0].Name != method || fn.Results == nil || len(fn.Results.List) == 0 {
+					continue
+				}
+				return qualify(fn.Results.List[0].Type, alias.Name)
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// qualify rewrites the named types of expr, declared in another package, as
+// selectors on that package's alias
+func qualify(expr ast.Expr, alias string) ast.Expr {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualify(t.X, alias)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: t.Len, Elt: qualify(t.Elt, alias)}
+	case *ast.Ident:
+		if basicSchema(t.Name) != nil || t.Name == "any" || t.Name == "error" {
+			return t
+		}
+		return &ast.SelectorExpr{X: ast.NewIdent(alias), Sel: ast.NewIdent(t.Name)}
+	}
+	return expr
+}
+
+// literalType returns the type of a composite literal, or of its address
+func literalType(expr ast.Expr) ast.Expr {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	if lit, ok := expr.(*ast.CompositeLit); ok {
+		return lit.Type
+	}
+	return nil
+}
+
+// typeOfArg resolves the type of a body argument such as &req or T{...}
+func (a *analyzer) typeOfArg(expr ast.Expr, vars map[string]ast.Expr) ast.Expr {
+	if typ := literalType(expr); typ != nil {
+		return typ
+	}
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return vars[ident.Name]
+	}
+	return nil
+}
+
+// statusCode resolves http.StatusXxx selectors and integer literals
+func statusCode(expr ast.Expr) int {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if code, err := strconv.Atoi(e.Value); err == nil {
+			return code
+		}
+	case *ast.SelectorExpr:
+		return statusCodes[e.Sel.Name]
+	}
+	return 0
+}
+
+// statusCodes maps net/http status constant names to their codes
+var statusCodes = map[string]int{
+	"StatusOK":                    http.StatusOK,
+	"StatusCreated":               http.StatusCreated,
+	"StatusAccepted":              http.StatusAccepted,
+	"StatusNoContent":             http.StatusNoContent,
+	"StatusMovedPermanently":      http.StatusMovedPermanently,
+	"StatusFound":                 http.StatusFound,
+	"StatusNotModified":           http.StatusNotModified,
+	"StatusBadRequest":            http.StatusBadRequest,
+	"StatusUnauthorized":          http.StatusUnauthorized,
+	"StatusForbidden":             http.StatusForbidden,
+	"StatusNotFound":              http.StatusNotFound,
+	"StatusMethodNotAllowed":      http.StatusMethodNotAllowed,
+	"StatusConflict":              http.StatusConflict,
+	"StatusGone":                  http.StatusGone,
+	"StatusPreconditionFailed":    http.StatusPreconditionFailed,
+	"StatusRequestEntityTooLarge": http.StatusRequestEntityTooLarge,
+	"StatusUnsupportedMediaType":  http.StatusUnsupportedMediaType,
+	"StatusUnprocessableEntity":   http.StatusUnprocessableEntity,
+	"StatusTooManyRequests":       http.StatusTooManyRequests,
+	"StatusInternalServerError":   http.StatusInternalServerError,
+	"StatusNotImplemented":        http.StatusNotImplemented,
+	"StatusBadGateway":            http.StatusBadGateway,
+	"StatusServiceUnavailable":    http.StatusServiceUnavailable,
+	"StatusGatewayTimeout":        http.StatusGatewayTimeout,
+}
+
+// schemaFor converts a Go type expression into a schema. Struct types of the
+// project become component schemas referenced by $ref.
+func (a *analyzer) schemaFor(pkg *sourcePackage, file *ast.File, expr ast.Expr) *Schema {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return a.schemaFor(pkg, file, t.X)
+	case *ast.ArrayType:
+		if ident, ok := t.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: a.schemaFor(pkg, file, t.Elt)}
+	case *ast.MapType:
+		return &Schema{Type: "object"}
+	case *ast.InterfaceType:
+		return &Schema{}
+	case *ast.Ident:
+		if schema := basicSchema(t.Name); schema != nil {
+			return schema
+		}
+		if t.Name == "any" {
+			return &Schema{}
+		}
+		if decl, ok := pkg.structs[t.Name]; ok {
+			return a.component(pkg, t.Name, decl)
+		}
+	case *ast.SelectorExpr:
+		alias, ok := t.X.(*ast.Ident)
+		if !ok {
+			break
+		}
+		if alias.Name == "time" && t.Sel.Name == "Time" {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		if dir := a.localImport(file, alias.Name); dir != "" {
+			if other, err := a.load(dir); err == nil {
+				if decl, ok := other.structs[t.Sel.Name]; ok {
+					return a.component(other, t.Sel.Name, decl)
+				}
+			}
+		}
+	}
+	return &Schema{Type: "object"}
+}
+
+func basicSchema(name string) *Schema {
+	switch name {
+	case "string":
+		return &Schema{Type: "string"}
+	case "bool":
+		return &Schema{Type: "boolean"}
+	case "int", "int8", "int16", "int32", "uint", "uint8", "uint16", "uint32":
+		return &Schema{Type: "integer", Format: "int32"}
+	case "int64", "uint64":
+		return &Schema{Type: "integer", Format: "int64"}
+	case "float32":
+		return &Schema{Type: "number", Format: "float"}
+	case "float64":
+		return &Schema{Type: "number", Format: "double"}
+	}
+	return nil
+}
+
+// localImport returns the project directory of an import alias that points
+// inside the module, or "" otherwise
+func (a *analyzer) localImport(file *ast.File, alias string) string {
+	if file == nil {
+		return ""
+	}
+	for _, imp := range file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path.Base(importPath)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if name != alias {
+			continue
+		}
+		if rel, ok := strings.CutPrefix(importPath, a.opts.ModulePath+"/"); ok {
+			return rel
+		}
+	}
+	return ""
+}
+
+// component registers the struct as a component schema and returns a
+// reference to it. Name clashes between packages are resolved by prefixing
+// the package name.
+func (a *analyzer) component(pkg *sourcePackage, typeName string, decl declaredStruct) *Schema {
+	key := pkg.dir + "." + typeName
+	name, ok := a.names[key]
+	if !ok {
+		name = typeName
+		for _, taken := range a.names {
+			if taken == name {
+				name = capitalize(path.Base(pkg.dir)) + typeName
+				break
+			}
+		}
+		a.names[key] = name
+		a.doc.Components.Schemas[name] = &Schema{}
+		*a.doc.Components.Schemas[name] = *a.structSchema(pkg, decl)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// structSchema converts a struct into an object schema, honouring json tags
+// and the validate rules written by cleango
+func (a *analyzer) structSchema(pkg *sourcePackage, decl declaredStruct) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for _, field := range decl.st.Fields.List {
+		if len(field.Names) == 0 {
+			// Embedded structs contribute their properties
+			if ident, ok := field.Type.(*ast.Ident); ok {
+				if embedded, ok := pkg.structs[ident.Name]; ok {
+					inner := a.structSchema(pkg, embedded)
+					for name, prop := range inner.Properties {
+						schema.Properties[name] = prop
+					}
+					schema.Required = append(schema.Required, inner.Required...)
+				}
+			}
+			continue
+		}
+
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			if unquoted, err := strconv.Unquote(field.Tag.Value); err == nil {
+				tag = reflect.StructTag(unquoted)
+			}
+		}
+
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+
+			jsonName, omitEmpty := name.Name, false
+			if jsonTag := tag.Get("json"); jsonTag != "" {
+				parts := strings.Split(jsonTag, ",")
+				if parts[0] == "-" {
+					continue
+				}
+				if parts[0] != "" {
+					jsonName = parts[0]
+				}
+				for _, opt := range parts[1:] {
+					omitEmpty = omitEmpty || opt == "omitempty"
+				}
+			}
+
+			prop := a.schemaFor(pkg, decl.file, field.Type)
+			if applyRules(prop, tag.Get("validate")) && !omitEmpty {
+				schema.Required = append(schema.Required, jsonName)
+			}
+			schema.Properties[jsonName] = prop
+		}
+	}
+
+	if len(schema.Properties) == 0 {
+		schema.Properties = nil
+	}
+	return schema
+}
+
+// applyRules maps validate rules onto schema constraints and reports whether
+// the field is required
+func applyRules(schema *Schema, rules string) bool {
+	if rules == "" || schema.Ref != "" {
+		return strings.Contains(rules, "required")
+	}
+
+	required := false
+	for _, rule := range strings.Split(rules, ",") {
+		name, arg, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "email":
+			schema.Format = "email"
+		case "oneof":
+			schema.Enum = strings.FieldsFunc(arg, func(r rune) bool { return r == '|' || r == ' ' })
+		case "min", "max":
+			n, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				continue
+			}
+			if schema.Type == "string" {
+				length := int(n)
+				if name == "min" {
+					schema.MinLength = &length
+				} else {
+					schema.MaxLength = &length
+				}
+			} else if name == "min" {
+				schema.Minimum = &n
+			} else {
+				schema.Maximum = &n
+			}
+		}
+	}
+	return required
+}
@@ -0,0 +1,238 @@
+// Package openapi models the subset of OpenAPI 3 documents that cleango
+// reads and writes, and builds them from generated project sources.
+package openapi
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/YeridStick/cleango/internal/i18n"
+)
+
+// Version is the OpenAPI version written by cleango
+const Version = "3.0.3"
+
+// Document is an OpenAPI 3 document
+type Document struct {
+	OpenAPI    string               `yaml:"openapi"`
+	Info       Info                 `yaml:"info"`
+	Paths      map[string]*PathItem `yaml:"paths"`
+	Components Components           `yaml:"components,omitempty"`
+}
+
+// Info holds the API metadata
+type Info struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description,omitempty"`
+	Version     string `yaml:"version"`
+}
+
+// PathItem groups the operations available on a path
+type PathItem struct {
+	Parameters []*Parameter `yaml:"parameters,omitempty"`
+	Get        *Operation   `yaml:"get,omitempty"`
+	Post       *Operation   `yaml:"post,omitempty"`
+	Put        *Operation   `yaml:"put,omitempty"`
+	Patch      *Operation   `yaml:"patch,omitempty"`
+	Delete     *Operation   `yaml:"delete,omitempty"`
+}
+
+// Operation describes a single API operation on a path
+type Operation struct {
+	OperationID string               `yaml:"operationId,omitempty"`
+	Summary     string               `yaml:"summary,omitempty"`
+	Tags        []string             `yaml:"tags,omitempty"`
+	Parameters  []*Parameter         `yaml:"parameters,omitempty"`
+	RequestBody *RequestBody         `yaml:"requestBody,omitempty"`
+	Responses   map[string]*Response `yaml:"responses"`
+}
+
+// Parameter describes a path, query or header parameter
+type Parameter struct {
+	Ref      string  `yaml:"$ref,omitempty"`
+	Name     string  `yaml:"name,omitempty"`
+	In       string  `yaml:"in,omitempty"`
+	Required bool    `yaml:"required,omitempty"`
+	Schema   *Schema `yaml:"schema,omitempty"`
+}
+
+// RequestBody describes the body accepted by an operation
+type RequestBody struct {
+	Ref      string               `yaml:"$ref,omitempty"`
+	Required bool                 `yaml:"required,omitempty"`
+	Content  map[string]MediaType `yaml:"content,omitempty"`
+}
+
+// Response describes a single response of an operation
+type Response struct {
+	Ref         string               `yaml:"$ref,omitempty"`
+	Description string               `yaml:"description,omitempty"`
+	Content     map[string]MediaType `yaml:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body for a content type
+type MediaType struct {
+	Schema *Schema `yaml:"schema,omitempty"`
+}
+
+// Components holds reusable objects referenced with $ref
+type Components struct {
+	Schemas       map[string]*Schema      `yaml:"schemas,omitempty"`
+	Parameters    map[string]*Parameter   `yaml:"parameters,omitempty"`
+	RequestBodies map[string]*RequestBody `yaml:"requestBodies,omitempty"`
+	Responses     map[string]*Response    `yaml:"responses,omitempty"`
+}
+
+// Schema is a JSON schema as used by OpenAPI 3.0
+type Schema struct {
+	Ref         string             `yaml:"$ref,omitempty"`
+	Type        string             `yaml:"type,omitempty"`
+	Format      string             `yaml:"format,omitempty"`
+	Description string             `yaml:"description,omitempty"`
+	Required    []string           `yaml:"required,omitempty"`
+	Properties  map[string]*Schema `yaml:"properties,omitempty"`
+	Items       *Schema            `yaml:"items,omitempty"`
+	Enum        []string           `yaml:"enum,omitempty"`
+	MinLength   *int               `yaml:"minLength,omitempty"`
+	MaxLength   *int               `yaml:"maxLength,omitempty"`
+	Minimum     *float64           `yaml:"minimum,omitempty"`
+	Maximum     *float64           `yaml:"maximum,omitempty"`
+}
+
+// Operations returns the operations of the path item in a fixed order
+func (p *PathItem) Operations() []MethodOperation {
+	var ops []MethodOperation
+	for _, candidate := range []MethodOperation{
+		{"GET", p.Get},
+		{"POST", p.Post},
+		{"PUT", p.Put},
+		{"PATCH", p.Patch},
+		{"DELETE", p.Delete},
+	} {
+		if candidate.Operation != nil {
+			ops = append(ops, candidate)
+		}
+	}
+	return ops
+}
+
+// MethodOperation pairs an operation with its uppercase HTTP method
+type MethodOperation struct {
+	Method    string
+	Operation *Operation
+}
+
+// setOperation stores op under the given HTTP method
+func (p *PathItem) setOperation(method string, op *Operation) {
+	switch strings.ToLower(method) {
+	case "get":
+		p.Get = op
+	case "post":
+		p.Post = op
+	case "put":
+		p.Put = op
+	case "patch":
+		p.Patch = op
+	case "delete":
+		p.Delete = op
+	}
+}
+
+// Marshal renders the document as YAML. Map keys are sorted, so the output
+// is stable across runs.
+func Marshal(doc *Document) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Load reads an OpenAPI 3 document from a local YAML or JSON file. Remote
+// references are never fetched.
+func Load(path string) (*Document, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc Document
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, i18n.Error("err.read", path, err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, i18n.Error("openapi.not_v3", path, doc.OpenAPI)
+	}
+	return &doc, nil
+}
+
+// RefName returns the component name a local $ref points to
+func RefName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// Schema follows a local $ref to its component schema
+func (d *Document) Schema(s *Schema) *Schema {
+	for depth := 0; s != nil && s.Ref != "" && depth < 16; depth++ {
+		s = d.Components.Schemas[RefName(s.Ref)]
+	}
+	return s
+}
+
+// Parameter follows a local $ref to its component parameter
+func (d *Document) Parameter(p *Parameter) *Parameter {
+	if p != nil && p.Ref != "" {
+		return d.Components.Parameters[RefName(p.Ref)]
+	}
+	return p
+}
+
+// RequestBody follows a local $ref to its component request body
+func (d *Document) RequestBody(b *RequestBody) *RequestBody {
+	if b != nil && b.Ref != "" {
+		return d.Components.RequestBodies[RefName(b.Ref)]
+	}
+	return b
+}
+
+// Response follows a local $ref to its component response
+func (d *Document) Response(r *Response) *Response {
+	if r != nil && r.Ref != "" {
+		return d.Components.Responses[RefName(r.Ref)]
+	}
+	return r
+}
+
+// JSONSchema returns the schema of the JSON content of a body, if any
+func JSONSchema(content map[string]MediaType) *Schema {
+	for _, contentType := range []string{"application/json", "application/problem+json"} {
+		if media, ok := content[contentType]; ok {
+			return media.Schema
+		}
+	}
+	for contentType, media := range content {
+		if strings.HasSuffix(contentType, "+json") {
+			return media.Schema
+		}
+	}
+	return nil
+}
+
+// StatusName returns the net/http constant name for a status code, such as
+// "StatusCreated", or "" for codes without a known constant
+func StatusName(code int) string {
+	for name, value := range statusCodes {
+		if value == code {
+			return name
+		}
+	}
+	return ""
+}